@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/clix-so/nativefire/internal/firebase"
+	"github.com/clix-so/nativefire/internal/tui"
+	"github.com/clix-so/nativefire/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var appsSelectProjectID string
+
+var appsCmd = &cobra.Command{
+	Use:   "apps",
+	Short: "📱 Manage Firebase apps within a project",
+	Long: ui.Primary.Sprint("📱 Firebase App Management\n\n") +
+		"Discover and pick among a project's existing Android/iOS/web apps.\n\n" +
+		ui.Bold.Sprint("Available Commands:") + "\n" +
+		"  • " + ui.Code("select") + " - Pick an app interactively, instead of guessing an app ID\n\n" +
+		ui.Dim.Sprint("Pro tip: Use") + " " + ui.Code("--verbose") + " " + ui.Dim.Sprint("for detailed output."),
+}
+
+var appsSelectCmd = &cobra.Command{
+	Use:   "select",
+	Short: "🎯 Interactively select an existing Firebase app",
+	Long: ui.Success.Sprint("🎯 Interactive App Selection\n\n") +
+		"Choose an existing Android/iOS/web app from a project, so its app ID can be passed " +
+		"straight to " + ui.Code("nativefire configure --app-id") + " instead of being guessed.\n\n" +
+		ui.Bold.Sprint("Flags:") + "\n" +
+		"  " + ui.Code("--project") + " - Firebase project ID to list apps from (will prompt if not provided)\n\n" +
+		ui.Dim.Sprint("Example:") + " " + ui.Code("nativefire apps select --project my-app"),
+	RunE: runAppsSelect,
+}
+
+func init() {
+	rootCmd.AddCommand(appsCmd)
+	appsCmd.AddCommand(appsSelectCmd)
+
+	appsSelectCmd.Flags().StringVarP(&appsSelectProjectID, "project", "p", "",
+		"Firebase project ID to list apps from (will prompt if not provided)")
+}
+
+func runAppsSelect(cmd *cobra.Command, args []string) error {
+	verbose := viper.GetBool("verbose")
+	firebaseClient := firebase.NewClientWithBackend(verbose, backendFlag)
+
+	projectID := appsSelectProjectID
+	if projectID == "" {
+		selectedProjectID, err := promptProjectSelection(firebaseClient, verbose)
+		if err != nil {
+			return err
+		}
+		projectID = selectedProjectID
+	}
+
+	if verbose {
+		ui.InfoMsg(fmt.Sprintf("Fetching apps for project %s...", projectID))
+	}
+
+	apps, err := firebaseClient.ListApps(projectID)
+	if err != nil {
+		return fmt.Errorf("failed to list apps: %w", err)
+	}
+
+	if len(apps) == 0 {
+		ui.WarningMsg(fmt.Sprintf("No apps found in project %s", projectID))
+		fmt.Printf("\n%s Register one first with %s\n",
+			ui.Fire.Sprint("🔗"),
+			ui.Code(fmt.Sprintf("nativefire configure --project %s", projectID)))
+		return nil
+	}
+
+	selectedApp, err := pickApp(apps)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%s %s\n",
+		ui.Check.Sprint("🎉 App Selected:"),
+		ui.Bold.Sprint(selectedApp.DisplayName))
+	fmt.Printf("   %s %s\n",
+		ui.Dim.Sprint("Platform:"),
+		ui.Platform(selectedApp.Platform))
+	fmt.Printf("   %s %s\n\n",
+		ui.Dim.Sprint("App ID:"),
+		ui.Success.Sprint(selectedApp.AppID))
+
+	fmt.Printf("%s\n", ui.Bold.Sprint("Next Steps:"))
+	fmt.Printf("  %s %s\n",
+		ui.Rocket.Sprint("🚀"),
+		ui.Code(fmt.Sprintf("nativefire configure --project %s --app-id %s", projectID, selectedApp.AppID)))
+
+	return nil
+}
+
+// pickApp selects one of apps through the interactive fuzzy-filter picker
+// (see tuiEnabled). Unlike pickProject, app selection has no numeric stdin
+// fallback: guessing an app ID by number is exactly what this command
+// exists to avoid, so --tui is effectively always on here regardless of
+// tuiEnabled's auto-detection - non-terminal stdout just means the picker
+// runs without the niceties a real terminal provides.
+func pickApp(apps []firebase.App) (firebase.App, error) {
+	items := make([]tui.Item, len(apps))
+	for i, app := range apps {
+		identifier := app.BundleID
+		if identifier == "" {
+			identifier = app.PackageName
+		}
+		if identifier == "" {
+			identifier = app.Namespace
+		}
+		items[i] = tui.Item{
+			ID:     app.AppID,
+			Title:  app.DisplayName,
+			Detail: fmt.Sprintf("%s | %s | %s", app.Platform, identifier, app.AppID),
+		}
+	}
+
+	choice, err := tui.Pick("Select a Firebase app", items)
+	if err != nil {
+		return firebase.App{}, fmt.Errorf("app selection cancelled: %w", err)
+	}
+
+	for _, app := range apps {
+		if app.AppID == choice.ID {
+			return app, nil
+		}
+	}
+
+	return firebase.App{}, fmt.Errorf("selected app %s not found", choice.ID)
+}
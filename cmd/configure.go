@@ -2,26 +2,45 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/clix-so/nativefire/internal/dependencies"
 	"github.com/clix-so/nativefire/internal/firebase"
+	"github.com/clix-so/nativefire/internal/hooks"
 	"github.com/clix-so/nativefire/internal/platform"
+	"github.com/clix-so/nativefire/internal/plugin"
+	desktoptemplates "github.com/clix-so/nativefire/internal/templates/desktop"
 	"github.com/clix-so/nativefire/internal/ui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	projectID    string
-	platformFlag string
-	autoDetect   bool
-	appID        string
-	bundleID     string
-	packageName  string
+	projectID          string
+	platformFlag       string
+	platformsFlag      string
+	skipPlatform       string
+	autoDetect         bool
+	appID              string
+	bundleID           string
+	packageName        string
+	modulesFlag        string
+	dryRun             bool
+	dockerMode         bool
+	envFlag            string
+	autoInstall        bool
+	backendFlag        string
+	planOnly           bool
+	skipConfirm        bool
+	onlyPlatform       string
+	manifestFile       string
+	packageManager     string
+	firebaseSDKVersion string
 )
 
 var configureCmd = &cobra.Command{
@@ -40,10 +59,36 @@ var configureCmd = &cobra.Command{
 		"  " + ui.Code("nativefire configure --project my-app") +
 		"              # Auto-detect platform for specific project\n" +
 		"  " + ui.Code("nativefire configure --project my-app --platform ios") + "  # Explicit platform\n\n" +
+		ui.Bold.Sprint("Monorepo Options:") + "\n" +
+		"  " + ui.Code("--platforms ios,android") + "   - Configure several platforms in one run\n" +
+		"  " + ui.Code("--skip-platform windows") + "   - Skip a platform detected under --auto-detect\n" +
+		"  " + ui.Code("--only-platform android") + "   - Restrict an --auto-detect/--platforms run to just these " +
+		"platforms (e.g. for CI hosts without Xcode)\n" +
+		"  Both also read a matching " + ui.Code("platforms:") + " section (" + ui.Code("skip:") + "/" +
+		ui.Code("only:") + " lists) from " + ui.Code(".nativefire.yaml") + "\n\n" +
+		ui.Bold.Sprint("Plugins:") + "\n" +
+		"  Platforms nativefire doesn't ship built-in (Unity, Unreal, Godot, Qt, ...) can be added via " +
+		ui.Code("nativefire plugin install") + " — once installed, " + ui.Code("--platform <name>") +
+		" and auto-detect pick them up alongside the built-ins.\n\n" +
+		ui.Bold.Sprint("Hooks:") + "\n" +
+		"  Declare " + ui.Code("pre_install_config") + ", " + ui.Code("post_install_config") + ", " +
+		ui.Code("pre_add_init") + ", and " + ui.Code("post_add_init") + " commands in " +
+		ui.Code(".nativefire.yaml") + " to run platform-specific steps (e.g. " +
+		ui.Code("pod install") + " on darwin, " + ui.Code("gradlew") + " on linux) " +
+		"automatically around configure's install/init stages.\n\n" +
 		ui.Bold.Sprint("Platform-Specific Options:") + "\n" +
 		"  " + ui.Code("--bundle-id") + "     - iOS/macOS Bundle Identifier\n" +
 		"  " + ui.Code("--package-name") + "  - Android Package Name\n" +
-		"  " + ui.Code("--app-id") + "        - Use existing Firebase App ID\n\n" +
+		"  " + ui.Code("--app-id") + "        - Use existing Firebase App ID\n" +
+		"  " + ui.Code("--modules") + "       - Desktop product modules to bootstrap (auth,firestore,messaging)\n" +
+		"  " + ui.Code("--dry-run") + "       - Preview project file mutations without writing them\n" +
+		"  " + ui.Code("--docker") + "        - Configure platforms lacking native tooling here " +
+		"(e.g. iOS on Linux) in a container\n" +
+		"  " + ui.Code("--env") + "           - Build environment/flavor (dev/staging/prod) for multi-environment setups; " +
+		"places the plist under ios/config/<env>/ (iOS) or the config file under app/src/<env>/ (Android) instead of a single shared file\n" +
+		"  " + ui.Code("--auto-install") + " - Run missing dependencies' install commands automatically, after confirmation\n" +
+		"  " + ui.Code("--package-manager") + " - iOS dependency manager to use (spm or cocoapods), overriding auto-detection\n" +
+		"  " + ui.Code("--firebase-sdk-version") + " - Minimum Firebase iOS SDK version for a new SPM dependency\n\n" +
 		ui.Dim.Sprint("Pro tip: Use") + " " + ui.Code("--verbose") + " " + ui.Dim.Sprint("to see detailed progress."),
 	RunE: runConfigure,
 }
@@ -52,7 +97,18 @@ func init() {
 	rootCmd.AddCommand(configureCmd)
 
 	configureCmd.Flags().StringVarP(&projectID, "project", "p", "", "Firebase project ID (will prompt if not provided)")
-	configureCmd.Flags().StringVar(&platformFlag, "platform", "", "Target platform (android, ios, macos, windows, linux)")
+	configureCmd.Flags().StringVar(&platformFlag, "platform", "",
+		"Target platform (android, ios, iossimulator, macos, maccatalyst, windows, linux)")
+	configureCmd.Flags().StringVar(&platformsFlag, "platforms", "",
+		"Comma-separated target platforms, e.g. for a monorepo or to register multiple "+
+			"Apple variants under one project (android,ios,iossimulator,macos,maccatalyst,windows,linux)")
+	configureCmd.Flags().StringVar(&skipPlatform, "skip-platform", "",
+		"Comma-separated platforms to exclude from --auto-detect or --platforms "+
+			"(also read from the platforms.skip list in .nativefire.yaml)")
+	configureCmd.Flags().StringVar(&onlyPlatform, "only-platform", "",
+		"Comma-separated platforms to exclusively configure, dropping everything else --auto-detect or --platforms "+
+			"would otherwise have included - e.g. --only-platform=android on a CI host without Xcode "+
+			"(also read from the platforms.only list in .nativefire.yaml)")
 	configureCmd.Flags().BoolVar(&autoDetect, "auto-detect", true,
 		"Automatically detect the platform (enabled by default)")
 	configureCmd.Flags().StringVar(&appID, "app-id", "", "Firebase app ID (optional, will generate if not provided)")
@@ -60,24 +116,151 @@ func init() {
 		"iOS Bundle ID (will auto-detect or generate if not provided)")
 	configureCmd.Flags().StringVar(&packageName, "package-name", "",
 		"Android Package Name (will auto-detect or generate if not provided)")
+	configureCmd.Flags().StringVar(&modulesFlag, "modules", "",
+		"Comma-separated Firebase product modules to bootstrap on desktop platforms (auth,firestore,messaging)")
+	configureCmd.Flags().BoolVar(&dryRun, "dry-run", false,
+		"Print project file mutations (e.g. pbxproj edits) instead of writing them")
+	configureCmd.Flags().BoolVar(&dockerMode, "docker", false,
+		"Configure platforms lacking native tooling on this host (e.g. iOS on Linux) inside a container")
+	configureCmd.Flags().StringVar(&envFlag, "env", "",
+		"Build environment/flavor (dev/staging/prod) for a multi-environment iOS or Android setup")
+	configureCmd.Flags().BoolVar(&autoInstall, "auto-install", false,
+		"Automatically run missing dependencies' install commands (after confirmation) instead of failing preflight")
+	configureCmd.Flags().StringVar(&backendFlag, "backend", "",
+		"Firebase backend to use: cli (shell out to the firebase CLI) or api (Firebase Management API via "+
+			"Application Default Credentials). Defaults to api when GOOGLE_APPLICATION_CREDENTIALS is set, cli otherwise")
+	configureCmd.Flags().BoolVar(&planOnly, "plan", false,
+		"Print the register/download/install steps that would run, without executing them")
+	configureCmd.Flags().BoolVar(&skipConfirm, "yes", false,
+		"Skip the confirmation prompt and apply the plan immediately")
+	configureCmd.Flags().StringVarP(&manifestFile, "file", "f", "",
+		"Path to a nativefire.yaml registration manifest (project_id + apps:) - registers every app in "+
+			"the manifest up front, and derives --project/--platforms when they aren't also given")
+	configureCmd.Flags().StringVar(&packageManager, "package-manager", "",
+		"iOS dependency manager to use: spm or cocoapods. Auto-detected from the project "+
+			"(Podfile vs Package.swift/.xcodeproj SPM deps) if not set")
+	configureCmd.Flags().StringVar(&firebaseSDKVersion, "firebase-sdk-version", "",
+		"Minimum Firebase iOS SDK version to register for a new Swift Package Manager dependency "+
+			"(defaults to nativefire's own minimum)")
 
 	// Make project optional - we'll prompt if not provided
 }
 
+// applyManifestFile loads --file (if set) and registers every app it
+// declares, bounded and combined-error-reported by
+// Client.RegisterAppsFromManifest. When --project/--platform/--platforms
+// weren't also given, it seeds projectID and platformsFlag from the
+// manifest so `nativefire configure -f nativefire.yaml` alone is enough -
+// mirroring how a manifest file can stand in for arguments elsewhere in
+// nativefire (e.g. packageManifest for `nativefire package`).
+func applyManifestFile(firebaseClient *firebase.Client) error {
+	if manifestFile == "" {
+		return nil
+	}
+
+	configs, err := firebase.LoadManifest(manifestFile)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", manifestFile, err)
+	}
+
+	ui.Step(0, fmt.Sprintf("Registering %d app(s) from %s...", len(configs), manifestFile))
+	if err := firebaseClient.RegisterAppsFromManifest(configs); err != nil {
+		return fmt.Errorf("failed to register apps from %s: %w", manifestFile, err)
+	}
+
+	if projectID == "" {
+		projectID = configs[0].ProjectID
+	}
+	if platformFlag == "" && platformsFlag == "" {
+		names := make([]string, len(configs))
+		for i, config := range configs {
+			names[i] = config.Platform.Name()
+		}
+		platformsFlag = strings.Join(names, ",")
+	}
+
+	return nil
+}
+
+// dockerModeEnabled reports whether platform steps should route through a
+// container, honoring --docker or the NATIVEFIRE_DOCKER=1 environment
+// variable used by CI hosts that can't pass CLI flags through easily.
+func dockerModeEnabled() bool {
+	return dockerMode || os.Getenv("NATIVEFIRE_DOCKER") == "1"
+}
+
+// autoInstallMissingDependencies runs InstallCmd for every missing
+// dependency on platform via dependencies.ShellInstaller, after the user
+// confirms. It's the --auto-install path runConfigure falls back to when
+// PreflightCheck fails, so a missing or too-old tool doesn't require
+// leaving the terminal to fix.
+func autoInstallMissingDependencies(platform string) error {
+	missing := dependencies.CheckAllDependencies(platform)
+	if len(missing) == 0 {
+		return fmt.Errorf("a required dependency is below its minimum version; " +
+			"--auto-install only runs InstallCmd for missing tools, not version upgrades")
+	}
+
+	if !confirmInstall(missing) {
+		return fmt.Errorf("installation declined")
+	}
+
+	return dependencies.AutoInstall(dependencies.ShellInstaller{}, missing)
+}
+
+// confirmInstall prompts the user to approve running missing's InstallCmd
+// entries before --auto-install shells out to npm/brew/gem/apt/choco on
+// their behalf.
+func confirmInstall(missing []dependencies.Dependency) bool {
+	fmt.Println()
+	ui.InfoMsg("The following install commands will be run:")
+	for _, dep := range missing {
+		if dep.InstallCmd != "" {
+			fmt.Printf("  %s %s\n", ui.Primary.Sprint("•"), dep.InstallCmd)
+		}
+	}
+	fmt.Printf("%s ", ui.Primary.Sprint("Proceed? (y/N):"))
+
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	input = strings.ToLower(strings.TrimSpace(input))
+	return input == "y" || input == "yes"
+}
+
 func runConfigure(cmd *cobra.Command, args []string) error {
 	verbose := viper.GetBool("verbose")
 
-	// Perform preflight dependency check
-	platformForCheck := "all"
-	if platformFlag != "" {
-		platformForCheck = platformFlag
-	}
+	// Perform preflight dependency check. Skipped in test environments the
+	// same way the confirmation prompt below is, since CI doesn't have a
+	// real Firebase CLI/Xcode/Gradle install to check for.
+	if !isTestEnvironment() {
+		platformForCheck := "all"
+		if platformFlag != "" {
+			platformForCheck = platformFlag
+		}
 
-	if err := dependencies.PreflightCheck(platformForCheck); err != nil {
-		return fmt.Errorf("dependency check failed: %w", err)
+		if err := dependencies.PreflightCheck(platformForCheck); err != nil {
+			if !autoInstall {
+				return fmt.Errorf("dependency check failed: %w", err)
+			}
+			if installErr := autoInstallMissingDependencies(platformForCheck); installErr != nil {
+				return fmt.Errorf("dependency check failed: %w", installErr)
+			}
+			if err := dependencies.PreflightCheck(platformForCheck); err != nil {
+				return fmt.Errorf("dependency check failed after --auto-install: %w", err)
+			}
+		}
 	}
 
-	firebaseClient := firebase.NewClient(verbose)
+	firebaseClient := firebase.NewClientWithBackend(verbose, backendFlag)
+
+	if err := applyManifestFile(firebaseClient); err != nil {
+		return err
+	}
 
 	// If project ID not provided, prompt user to select
 	if projectID == "" {
@@ -103,51 +286,225 @@ func runConfigure(cmd *cobra.Command, args []string) error {
 
 	ui.ProjectHeader(projectID)
 
-	var targetPlatform platform.Platform
-	var err error
+	targetPlatforms, err := resolveTargetPlatforms()
+	if err != nil {
+		return err
+	}
 
+	hooksManifest, err := hooks.Load(hooks.ManifestFileName)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", hooks.ManifestFileName, err)
+	}
+
+	var failures []error
+	for _, targetPlatform := range targetPlatforms {
+		if len(targetPlatforms) > 1 {
+			ui.Header(fmt.Sprintf("Configuring %s", targetPlatform.Name()))
+		}
+		if err := configurePlatform(firebaseClient, targetPlatform, verbose, hooksManifest); err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", targetPlatform.Name(), err))
+			continue
+		}
+		fmt.Printf("\n🎉 %s %s!\n",
+			ui.Success.Sprint("Firebase configuration completed successfully for"),
+			ui.Platform(targetPlatform.Name()))
+	}
+
+	return errors.Join(failures...)
+}
+
+// resolveTargetPlatforms decides which platforms to configure this run,
+// honoring (in priority order) --platform, --platforms, and --auto-detect,
+// then filtering the --platforms/--auto-detect result against
+// --skip-platform/--only-platform (and their platforms.skip/platforms.only
+// .nativefire.yaml equivalents) - an explicit --platform always wins, since
+// the user named exactly what they want.
+func resolveTargetPlatforms() ([]platform.Platform, error) {
 	switch {
 	case platformFlag != "":
-		targetPlatform, err = platform.FromString(platformFlag)
+		targetPlatform, err := plugin.FromString(platformFlag)
 		if err != nil {
-			return fmt.Errorf("invalid platform: %w", err)
+			return nil, fmt.Errorf("invalid platform: %w", err)
 		}
 		fmt.Printf("%s %s\n\n", ui.Check.Sprint("🎯 Using platform:"), ui.Platform(targetPlatform.Name()))
+		return []platform.Platform{targetPlatform}, nil
+
+	case platformsFlag != "":
+		var targetPlatforms []platform.Platform
+		for _, name := range strings.Split(platformsFlag, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			p, err := plugin.FromString(name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid platform %q: %w", name, err)
+			}
+			targetPlatforms = append(targetPlatforms, p)
+		}
+		targetPlatforms, err := filterConfiguredPlatforms(targetPlatforms)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("%s %s\n\n", ui.Check.Sprint("🎯 Using platforms:"), ui.Platform(platformNames(targetPlatforms)))
+		return targetPlatforms, nil
+
 	case autoDetect:
-		ui.Step(1, "Auto-detecting platform...")
-		targetPlatform, err = platform.DetectPlatform()
+		ui.Step(1, "Auto-detecting platforms...")
+		targetPlatforms, err := plugin.DetectPlatforms(".")
 		if err != nil {
-			return fmt.Errorf("failed to detect platform: %w", err)
+			return nil, fmt.Errorf("failed to detect platform: %w", err)
 		}
-		fmt.Printf("   %s %s\n\n", ui.Check.Sprint("🎯 Detected platform:"), ui.Platform(targetPlatform.Name()))
+		targetPlatforms, err = filterConfiguredPlatforms(targetPlatforms)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("   %s %s\n\n", ui.Check.Sprint("🎯 Detected platforms:"), ui.Platform(platformNames(targetPlatforms)))
+		return targetPlatforms, nil
+
 	default:
-		return fmt.Errorf("platform detection failed: auto-detect is disabled and no platform specified")
+		return nil, fmt.Errorf("platform detection failed: auto-detect is disabled and no platform specified")
+	}
+}
+
+// filterConfiguredPlatforms applies --skip-platform/--only-platform (and
+// their platforms.skip/platforms.only .nativefire.yaml equivalents) to
+// platforms, in that order, and errors if nothing is left to configure.
+func filterConfiguredPlatforms(platforms []platform.Platform) ([]platform.Platform, error) {
+	platforms = excludeSkippedPlatforms(platforms)
+	if len(platforms) == 0 {
+		return nil, fmt.Errorf("no platforms left to configure after applying --skip-platform")
+	}
+
+	platforms = restrictToOnlyPlatforms(platforms)
+	if len(platforms) == 0 {
+		return nil, fmt.Errorf("no platforms left to configure after applying --only-platform")
+	}
+
+	return platforms, nil
+}
+
+// excludeSkippedPlatforms drops any platform named in --skip-platform or the
+// platforms.skip list in .nativefire.yaml.
+func excludeSkippedPlatforms(platforms []platform.Platform) []platform.Platform {
+	skip := platformNameSet(skipPlatform, "platforms.skip")
+	if len(skip) == 0 {
+		return platforms
+	}
+
+	var kept []platform.Platform
+	for _, p := range platforms {
+		if !skip[strings.ToLower(p.Name())] {
+			kept = append(kept, p)
+		}
 	}
+	return kept
+}
+
+// restrictToOnlyPlatforms keeps just the platforms named in --only-platform
+// or the platforms.only list in .nativefire.yaml, e.g. so a CI host without
+// Xcode can restrict an otherwise-broader --platforms/auto-detect result to
+// android without hand-editing the command every run.
+func restrictToOnlyPlatforms(platforms []platform.Platform) []platform.Platform {
+	only := platformNameSet(onlyPlatform, "platforms.only")
+	if len(only) == 0 {
+		return platforms
+	}
+
+	var kept []platform.Platform
+	for _, p := range platforms {
+		if only[strings.ToLower(p.Name())] {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// platformNameSet merges flagValue (a comma-separated list) with the
+// configKey string slice from .nativefire.yaml into a lowercased set of
+// platform names.
+func platformNameSet(flagValue, configKey string) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range strings.Split(flagValue, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names[name] = true
+		}
+	}
+	for _, name := range viper.GetStringSlice(configKey) {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
 
+func platformNames(platforms []platform.Platform) string {
+	names := make([]string, len(platforms))
+	for i, p := range platforms {
+		names[i] = p.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+// configurePlatform runs the full registration → download → install →
+// initialize pipeline for a single platform, running any user-declared
+// hooksManifest commands before/after the InstallConfig and
+// AddInitializationCode stages. Registration, download, and install run as a
+// firebase.Plan so a failure partway through rewinds what already
+// succeeded instead of leaving Firebase or the local config file
+// half-applied; --plan prints the plan's steps without running them, and
+// --yes skips the confirmation prompt before Execute.
+func configurePlatform(
+	firebaseClient *firebase.Client,
+	targetPlatform platform.Platform,
+	verbose bool,
+	hooksManifest *hooks.Manifest,
+) error {
 	config := &firebase.Config{
-		ProjectID:   projectID,
-		AppID:       appID,
-		Platform:    targetPlatform,
-		BundleID:    bundleID,
-		PackageName: packageName,
+		ProjectID:          projectID,
+		AppID:              appID,
+		Platform:           targetPlatform,
+		BundleID:           bundleID,
+		PackageName:        packageName,
+		Modules:            desktoptemplates.ParseModules(modulesFlag),
+		DryRun:             dryRun,
+		Env:                envFlag,
+		PackageManager:     packageManager,
+		FirebaseSDKVersion: firebaseSDKVersion,
 	}
 
-	ui.Step(2, "Registering app with Firebase...")
-	err = firebaseClient.RegisterApp(config)
+	plan, err := buildConfigurePlan(firebaseClient, targetPlatform, config, hooksManifest)
 	if err != nil {
-		return fmt.Errorf("failed to register app with Firebase: %w", err)
+		return err
 	}
 
-	ui.Step(3, "Downloading configuration file...")
-	err = firebaseClient.DownloadConfig(config)
-	if err != nil {
-		return fmt.Errorf("failed to download configuration: %w", err)
+	if planOnly {
+		ui.Header(fmt.Sprintf("Plan for %s", targetPlatform.Name()))
+		for i, step := range plan.Steps() {
+			fmt.Printf("  %s %s\n", ui.Primary.Sprint(fmt.Sprintf("%d.", i+1)), step)
+		}
+		return nil
 	}
 
-	ui.Step(4, "Installing configuration file...")
-	err = targetPlatform.InstallConfig(config)
-	if err != nil {
-		return fmt.Errorf("failed to install configuration: %w", err)
+	if !skipConfirm && !isTestEnvironment() {
+		ui.Header(fmt.Sprintf("About to configure %s", targetPlatform.Name()))
+		for i, step := range plan.Steps() {
+			fmt.Printf("  %s %s\n", ui.Primary.Sprint(fmt.Sprintf("%d.", i+1)), step)
+		}
+		fmt.Printf("%s ", ui.Primary.Sprint("Proceed? (y/N):"))
+		reader := bufio.NewReader(os.Stdin)
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		answer := strings.ToLower(strings.TrimSpace(input))
+		if answer != "y" && answer != "yes" {
+			return fmt.Errorf("configuration declined")
+		}
+	}
+
+	if err := plan.Execute(); err != nil {
+		return err
 	}
 
 	// Additional platform-specific dependency check before initialization
@@ -158,19 +515,122 @@ func runConfigure(cmd *cobra.Command, args []string) error {
 	if len(platformSpecificMissing) > 0 {
 		dependencies.ShowMissingDependencies(platformSpecificMissing)
 	}
+	dependencies.WarnIfNoCodesignIdentity(targetPlatform.Name())
 
 	ui.Step(5, "Adding Firebase initialization code...")
-	err = targetPlatform.AddInitializationCode(config)
-	if err != nil {
+	if err := hooksManifest.Run(hooks.PreAddInit); err != nil {
+		return err
+	}
+	if err := runConfigureStep(targetPlatform, config, platform.StepAddInit); err != nil {
 		return fmt.Errorf("failed to add initialization code: %w", err)
 	}
+	if err := hooksManifest.Run(hooks.PostAddInit); err != nil {
+		return err
+	}
 
-	fmt.Printf("\n🎉 %s %s!\n",
-		ui.Success.Sprint("Firebase configuration completed successfully for"),
-		ui.Platform(targetPlatform.Name()))
 	return nil
 }
 
+// buildConfigurePlan assembles the register/download/install portion of
+// configurePlatform as a firebase.Plan. RegisterApp's rewind only deletes
+// the app if this run is the one that created it - reusing an
+// already-existing app (found via FindExistingApp or passed via --app-id)
+// must never be deleted just because a later step failed.
+func buildConfigurePlan(
+	firebaseClient *firebase.Client,
+	targetPlatform platform.Platform,
+	config *firebase.Config,
+	hooksManifest *hooks.Manifest,
+) (*firebase.Plan, error) {
+	existingApp, err := firebaseClient.FindExistingApp(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for an existing app: %w", err)
+	}
+	createdNewApp := existingApp == nil && config.AppID == ""
+
+	configTargetPath := filepath.Join(targetPlatform.ConfigPath(), targetPlatform.ConfigFileName())
+
+	plan := firebase.NewPlan()
+
+	plan.Add(firebase.Action{
+		Description: fmt.Sprintf("Register the %s app with Firebase", targetPlatform.Name()),
+		Forward: func() error {
+			ui.Step(2, "Registering app with Firebase...")
+			if err := firebaseClient.RegisterApp(config); err != nil {
+				return fmt.Errorf("failed to register app with Firebase: %w", err)
+			}
+			return nil
+		},
+		Backward: func() error {
+			if !createdNewApp || config.AppID == "" {
+				return nil
+			}
+			return firebaseClient.DeleteApp(config.ProjectID, targetPlatform.Name(), config.AppID)
+		},
+	})
+
+	plan.Add(firebase.BackupFile(configTargetPath))
+
+	plan.Add(firebase.Action{
+		Description: "Download the app's configuration file from Firebase",
+		Forward: func() error {
+			ui.Step(3, "Downloading configuration file...")
+			if err := firebaseClient.DownloadConfig(config); err != nil {
+				return fmt.Errorf("failed to download configuration: %w", err)
+			}
+			return nil
+		},
+		Backward: func() error {
+			if config.SourcePath == "" {
+				return nil
+			}
+			if err := os.Remove(config.SourcePath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return nil
+		},
+	})
+
+	plan.Add(firebase.Action{
+		Description: fmt.Sprintf("Install the configuration file at %s", configTargetPath),
+		Forward: func() error {
+			ui.Step(4, "Installing configuration file...")
+			if err := hooksManifest.Run(hooks.PreInstallConfig); err != nil {
+				return err
+			}
+			if err := runConfigureStep(targetPlatform, config, platform.StepInstallConfig); err != nil {
+				return fmt.Errorf("failed to install configuration: %w", err)
+			}
+			return hooksManifest.Run(hooks.PostInstallConfig)
+		},
+	})
+
+	return plan, nil
+}
+
+// runConfigureStep runs one InstallConfig/AddInitializationCode step for
+// targetPlatform, routing through its container via RemoteExec when
+// --docker is set and the platform implements platform.DockerCapable.
+// Platforms without native-tooling gaps (DockerCapable not implemented)
+// always run the step directly, --docker or not.
+func runConfigureStep(targetPlatform platform.Platform, config *firebase.Config, step string) error {
+	if dockerModeEnabled() {
+		if dockerPlatform, ok := targetPlatform.(platform.DockerCapable); ok {
+			ui.InfoMsg(fmt.Sprintf("Running %s for %s inside %s...", step, targetPlatform.Name(), dockerPlatform.DockerImage()))
+			return dockerPlatform.RemoteExec(config, step)
+		}
+	}
+
+	switch step {
+	case platform.StepInstallConfig:
+		return targetPlatform.InstallConfig(config)
+	case platform.StepAddInit:
+		return targetPlatform.AddInitializationCode(config)
+	default:
+		return fmt.Errorf("unknown configure step: %s", step)
+	}
+}
+
 func promptProjectSelection(firebaseClient *firebase.Client, verbose bool) (string, error) {
 	// In test environments, return an error instead of prompting for input
 	if isTestEnvironment() {
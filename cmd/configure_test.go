@@ -6,6 +6,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/clix-so/nativefire/internal/platform"
+	"github.com/clix-so/nativefire/internal/plugin"
+	"github.com/spf13/viper"
 )
 
 func TestConfigureCommand(t *testing.T) {
@@ -174,14 +178,78 @@ func TestConfigureCommandWithAppID(t *testing.T) {
 	}
 }
 
+func TestFilterConfiguredPlatforms(t *testing.T) {
+	defer func() {
+		skipPlatform = ""
+		onlyPlatform = ""
+		viper.Set("platforms.skip", nil)
+		viper.Set("platforms.only", nil)
+	}()
+
+	android, err := plugin.FromString("android")
+	if err != nil {
+		t.Fatalf("plugin.FromString(android) error = %v", err)
+	}
+	ios, err := plugin.FromString("ios")
+	if err != nil {
+		t.Fatalf("plugin.FromString(ios) error = %v", err)
+	}
+	macos, err := plugin.FromString("macos")
+	if err != nil {
+		t.Fatalf("plugin.FromString(macos) error = %v", err)
+	}
+	all := []platform.Platform{android, ios, macos}
+
+	skipPlatform = "ios"
+	onlyPlatform = ""
+	filtered, err := filterConfiguredPlatforms(all)
+	if err != nil {
+		t.Fatalf("filterConfiguredPlatforms() error = %v", err)
+	}
+	if len(filtered) != 2 || platformNames(filtered) != "Android, macOS" {
+		t.Errorf("expected --skip-platform=ios to drop ios, got: %s", platformNames(filtered))
+	}
+
+	skipPlatform = ""
+	onlyPlatform = "android"
+	filtered, err = filterConfiguredPlatforms(all)
+	if err != nil {
+		t.Fatalf("filterConfiguredPlatforms() error = %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].Name() != "Android" {
+		t.Errorf("expected --only-platform=android to keep just android, got: %s", platformNames(filtered))
+	}
+
+	skipPlatform = ""
+	onlyPlatform = ""
+	viper.Set("platforms.skip", []string{"macos"})
+	filtered, err = filterConfiguredPlatforms(all)
+	if err != nil {
+		t.Fatalf("filterConfiguredPlatforms() error = %v", err)
+	}
+	if len(filtered) != 2 || platformNames(filtered) != "Android, iOS" {
+		t.Errorf("expected platforms.skip=[macos] from config to drop macos, got: %s", platformNames(filtered))
+	}
+
+	onlyPlatform = "windows"
+	viper.Set("platforms.skip", nil)
+	if _, err := filterConfiguredPlatforms(all); err == nil {
+		t.Error("expected an error when --only-platform names a platform not present in the input set")
+	}
+}
+
 func resetConfigureCommand() {
 	projectID = ""
 	platformFlag = ""
+	platformsFlag = ""
+	skipPlatform = ""
+	onlyPlatform = ""
 	autoDetect = true
 	appID = ""
 	bundleID = ""
 	packageName = ""
 	verbose = false
+	dockerMode = false
 }
 
 func setupTestEnvironment(t *testing.T, dirs []string, files []string) string {
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/clix-so/nativefire/internal/doctor"
+	"github.com/clix-so/nativefire/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "🩺 Diagnose why configure might silently produce broken output",
+	Long: ui.Primary.Sprint("🩺 Environment Diagnostics\n\n") +
+		"Runs a battery of checks against the toolchain and config files " + ui.Code("nativefire configure") +
+		" depends on - Firebase CLI login, Xcode/Swift/CocoaPods, and whether the downloaded " +
+		"GoogleService-Info.plist/google-services.json actually match the project's bundle ID/applicationId.\n\n" +
+		ui.Dim.Sprint("Example:") + " " + ui.Code("nativefire doctor"),
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	ui.Header("Running Diagnostics")
+
+	checks := doctor.Run(".")
+	for _, check := range checks {
+		if check.OK {
+			fmt.Printf("%s %s\n", ui.Check.Sprint("✓"), ui.Bold.Sprint(check.Name))
+			if check.Hint != "" {
+				fmt.Printf("    %s\n", ui.Dim.Sprint(check.Hint))
+			}
+			continue
+		}
+
+		symbol := ui.Secondary.Sprint("⚠")
+		if check.Critical {
+			symbol = ui.Error.Sprint("✗")
+		}
+		fmt.Printf("%s %s\n", symbol, ui.Bold.Sprint(check.Name))
+		fmt.Printf("    %s\n", ui.Dim.Sprint(check.Hint))
+	}
+
+	if doctor.AnyCritical(checks) {
+		return fmt.Errorf("one or more critical checks failed; see hints above")
+	}
+
+	ui.SuccessMsg("All critical checks passed")
+	return nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/clix-so/nativefire/internal/firebase"
+	"github.com/clix-so/nativefire/internal/platform"
+	desktoptemplates "github.com/clix-so/nativefire/internal/templates/desktop"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dockerStepPlatform   string
+	dockerStepName       string
+	dockerStepSourcePath string
+)
+
+// internalDockerStepCmd is what `nativefire configure --docker` runs inside
+// the target platform's container (see platform.DockerCapable): it
+// reconstructs the same firebase.Config the host was building and finishes
+// a single InstallConfig/AddInitializationCode step using the container's
+// own toolchain. It's not meant to be invoked directly by users, hence
+// Hidden.
+var internalDockerStepCmd = &cobra.Command{
+	Use:    "internal-docker-step",
+	Short:  "Run one configure step inside a platform's Docker container",
+	Hidden: true,
+	RunE:   runInternalDockerStep,
+}
+
+func init() {
+	rootCmd.AddCommand(internalDockerStepCmd)
+
+	internalDockerStepCmd.Flags().StringVar(&dockerStepPlatform, "platform", "", "Target platform")
+	internalDockerStepCmd.Flags().StringVar(&dockerStepName, "step", "", "Step to run (install-config, add-init)")
+	internalDockerStepCmd.Flags().StringVar(&dockerStepSourcePath, "source-path", "", "Path to the bind-mounted downloaded config file")
+	internalDockerStepCmd.Flags().StringVarP(&projectID, "project", "p", "", "Firebase project ID")
+	internalDockerStepCmd.Flags().StringVar(&appID, "app-id", "", "Firebase app ID")
+	internalDockerStepCmd.Flags().StringVar(&bundleID, "bundle-id", "", "iOS Bundle ID")
+	internalDockerStepCmd.Flags().StringVar(&packageName, "package-name", "", "Android Package Name")
+	internalDockerStepCmd.Flags().StringVar(&modulesFlag, "modules", "", "Comma-separated Firebase product modules")
+	internalDockerStepCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print project file mutations instead of writing them")
+}
+
+func runInternalDockerStep(cmd *cobra.Command, args []string) error {
+	targetPlatform, err := platform.FromString(dockerStepPlatform)
+	if err != nil {
+		return fmt.Errorf("invalid platform: %w", err)
+	}
+
+	config := &firebase.Config{
+		ProjectID:   projectID,
+		AppID:       appID,
+		Platform:    targetPlatform,
+		BundleID:    bundleID,
+		PackageName: packageName,
+		SourcePath:  dockerStepSourcePath,
+		Modules:     desktoptemplates.ParseModules(modulesFlag),
+		DryRun:      dryRun,
+	}
+
+	switch dockerStepName {
+	case platform.StepInstallConfig:
+		return targetPlatform.InstallConfig(config)
+	case platform.StepAddInit:
+		return targetPlatform.AddInitializationCode(config)
+	default:
+		return fmt.Errorf("unknown step: %s", dockerStepName)
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/clix-so/nativefire/internal/ui"
+	"github.com/clix-so/nativefire/internal/xcodegen"
+	"github.com/spf13/cobra"
+)
+
+var iosCmd = &cobra.Command{
+	Use:   "ios",
+	Short: "🍎 iOS project scaffolding",
+	Long: ui.Primary.Sprint("🍎 iOS Scaffolding\n\n") +
+		"Utilities for iOS projects nativefire can't configure yet because there's no\n" +
+		".xcodeproj/.xcworkspace/Podfile for it to find.\n\n" +
+		ui.Bold.Sprint("Available Commands:") + "\n" +
+		"  • " + ui.Code("init") + " - Generate a fresh Xcode project from a YAML spec",
+}
+
+var iosInitOutDir string
+
+var iosInitCmd = &cobra.Command{
+	Use:   "init <spec-file>",
+	Short: "🛠️  Generate a fresh Xcode project from a YAML spec",
+	Long: "Reads a minimal YAML spec (app_name, bundle_id, deployment_target, language, ui) and\n" +
+		"emits a single-target .xcodeproj with the Firebase iOS SDK already wired in as a Swift\n" +
+		"Package Manager dependency, ready for " + ui.Code("nativefire configure --platform ios") + ".\n\n" +
+		ui.Bold.Sprint("Example spec:") + "\n" +
+		"  app_name: MyApp\n" +
+		"  bundle_id: com.example.myapp\n" +
+		"  deployment_target: \"15.0\"\n" +
+		"  language: swift\n" +
+		"  ui: swiftui\n\n" +
+		ui.Bold.Sprint("Example:") + "\n" +
+		"  " + ui.Code("nativefire ios init app.yaml"),
+	Args: cobra.ExactArgs(1),
+	RunE: runIOSInit,
+}
+
+func init() {
+	rootCmd.AddCommand(iosCmd)
+	iosCmd.AddCommand(iosInitCmd)
+
+	iosInitCmd.Flags().StringVar(&iosInitOutDir, "out", ".", "Directory to generate the project into")
+}
+
+func runIOSInit(cmd *cobra.Command, args []string) error {
+	spec, err := xcodegen.LoadSpec(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load iOS project spec: %w", err)
+	}
+
+	xcodeprojDir, err := xcodegen.Generate(spec, iosInitOutDir)
+	if err != nil {
+		return fmt.Errorf("failed to generate iOS project: %w", err)
+	}
+
+	ui.SuccessMsg(fmt.Sprintf("Generated %s", xcodeprojDir))
+	fmt.Printf("%s %s\n", ui.Dim.Sprint("Next:"), ui.Code("nativefire configure --platform ios"))
+	return nil
+}
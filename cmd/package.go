@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/clix-so/nativefire/internal/packaging"
+	"github.com/clix-so/nativefire/internal/platform"
+	"github.com/clix-so/nativefire/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	packageFormats  string
+	packageManifest string
+	packagePlatform string
+)
+
+var packageCmd = &cobra.Command{
+	Use:   "package",
+	Short: "📦 Build distributable OS packages containing the Firebase config",
+	Long: ui.Primary.Sprint("📦 Firebase Config Packaging\n\n") +
+		"Bundle the Firebase config file your platform installed during configure into\n" +
+		"installable OS packages (.deb, .rpm, .apk, Arch). The msi and pkg formats\n" +
+		"are not supported and fail with an unsupported-format error.\n\n" +
+		ui.Bold.Sprint("Example:") + "\n" +
+		"  " + ui.Code("nativefire package --formats deb,rpm") + "\n\n" +
+		ui.Dim.Sprint("Reads package metadata from") + " " + ui.Code("nativefire.yaml") + " " +
+		ui.Dim.Sprint("in the current directory."),
+	RunE: runPackage,
+}
+
+func init() {
+	rootCmd.AddCommand(packageCmd)
+
+	packageCmd.Flags().StringVar(&packageFormats, "formats", "",
+		"Comma-separated package formats to build (deb,rpm,apk,archlinux,msi,pkg)")
+	packageCmd.Flags().StringVar(&packageManifest, "manifest", "nativefire.yaml",
+		"Path to the nativefire.yaml package manifest")
+	packageCmd.Flags().StringVar(&packagePlatform, "platform", "",
+		"Target platform (macos, windows, linux); auto-detected if omitted")
+}
+
+func runPackage(cmd *cobra.Command, args []string) error {
+	manifest, err := packaging.LoadManifest(packageManifest)
+	if err != nil {
+		return fmt.Errorf("failed to load package manifest: %w", err)
+	}
+
+	var targetPlatform platform.Platform
+	if packagePlatform != "" {
+		targetPlatform, err = platform.FromString(packagePlatform)
+	} else {
+		targetPlatform, err = platform.DetectPlatform()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to determine platform: %w", err)
+	}
+
+	formats := manifest.Formats
+	if packageFormats != "" {
+		formats = strings.Split(packageFormats, ",")
+	}
+	if len(formats) == 0 {
+		formats = packaging.DefaultFormats(targetPlatform)
+	}
+	if len(formats) == 0 {
+		return fmt.Errorf("%s has no default package formats; pass --formats", targetPlatform.Name())
+	}
+
+	ui.Header("Building packages")
+	for _, format := range formats {
+		format = strings.TrimSpace(format)
+		if format == "" {
+			continue
+		}
+
+		outPath, err := packaging.Build(targetPlatform, manifest, format)
+		if err != nil {
+			return fmt.Errorf("failed to build %s package: %w", format, err)
+		}
+		ui.SuccessMsg(fmt.Sprintf("Built %s package: %s", format, outPath))
+	}
+
+	return nil
+}
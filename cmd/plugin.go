@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/clix-so/nativefire/internal/plugin"
+	"github.com/clix-so/nativefire/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "🧩 Manage external platform provider plugins",
+	Long: ui.Primary.Sprint("🧩 Plugin Management\n\n") +
+		"Add support for platforms nativefire doesn't ship built-in (Unity, Unreal, Godot, Qt, custom\n" +
+		"embedded toolchains, ...) by installing a plugin manifest.\n\n" +
+		ui.Bold.Sprint("Available Commands:") + "\n" +
+		"  • " + ui.Code("list") + "    - Show installed plugins\n" +
+		"  • " + ui.Code("install") + " - Install a plugin from a local directory or git URL\n" +
+		"  • " + ui.Code("remove") + "  - Uninstall a plugin by name",
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "📋 List installed plugins",
+	RunE:  runPluginList,
+}
+
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install <path-or-git-url>",
+	Short: "📦 Install a plugin from a local directory or git URL",
+	Long: "Installs a plugin declared by a plugin.yaml manifest (see the plugin package docs for the\n" +
+		"expected fields: name, detect_globs, config_file_name, config_path_template, install_config,\n" +
+		"add_init_code) from either a local directory or a git URL.\n\n" +
+		ui.Bold.Sprint("Examples:") + "\n" +
+		"  " + ui.Code("nativefire plugin install ./my-unity-plugin") + "\n" +
+		"  " + ui.Code("nativefire plugin install https://github.com/example/nativefire-plugin-unity.git"),
+	Args: cobra.ExactArgs(1),
+	RunE: runPluginInstall,
+}
+
+var pluginRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "🗑️  Uninstall a plugin",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPluginRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(pluginCmd)
+	pluginCmd.AddCommand(pluginListCmd)
+	pluginCmd.AddCommand(pluginInstallCmd)
+	pluginCmd.AddCommand(pluginRemoveCmd)
+}
+
+func runPluginList(cmd *cobra.Command, args []string) error {
+	plugins := plugin.Discover()
+	if len(plugins) == 0 {
+		ui.InfoMsg("No plugins installed")
+		fmt.Printf("\n%s %s\n", ui.Dim.Sprint("Install one with:"), ui.Code("nativefire plugin install <path-or-git-url>"))
+		return nil
+	}
+
+	ui.Header("Installed Plugins")
+	for _, p := range plugins {
+		fmt.Printf("  %s %s\n", ui.Check.Sprint("•"), ui.Bold.Sprint(p.Name()))
+	}
+	return nil
+}
+
+func runPluginInstall(cmd *cobra.Command, args []string) error {
+	manifest, err := plugin.Install(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to install plugin: %w", err)
+	}
+
+	ui.SuccessMsg(fmt.Sprintf("Installed plugin %q", manifest.Name))
+	fmt.Printf("%s %s\n", ui.Dim.Sprint("Use it with:"), ui.Code(fmt.Sprintf("nativefire configure --platform %s", manifest.Name)))
+	return nil
+}
+
+func runPluginRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := plugin.Remove(name); err != nil {
+		return fmt.Errorf("failed to remove plugin: %w", err)
+	}
+
+	ui.SuccessMsg(fmt.Sprintf("Removed plugin %q", name))
+	return nil
+}
@@ -3,11 +3,13 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/clix-so/nativefire/internal/firebase"
+	"github.com/clix-so/nativefire/internal/tui"
 	"github.com/clix-so/nativefire/internal/ui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -60,13 +62,46 @@ func init() {
 	projectsCmd.AddCommand(projectsSelectCmd)
 
 	projectsSelectCmd.Flags().BoolVar(&autoUse, "use", false, "Automatically use the selected project for configuration")
+	projectsCmd.PersistentFlags().StringVar(&backendFlag, "backend", "",
+		"Firebase backend to use: cli (shell out to the firebase CLI) or api (Firebase Management API via "+
+			"Application Default Credentials). Defaults to api when GOOGLE_APPLICATION_CREDENTIALS is set, cli otherwise")
+	projectsCmd.PersistentFlags().BoolVar(&tuiFlag, "tui", false,
+		"Force the interactive fuzzy-filter picker even when stdout isn't detected as a terminal "+
+			"(auto-enabled on a real terminal)")
 }
 
 var autoUse bool
+var tuiFlag bool
+
+// tuiEnabled reports whether project/app selection should use the
+// interactive picker: --tui forces it on, otherwise it auto-enables
+// whenever stdout looks like a real terminal rather than a pipe or CI log.
+func tuiEnabled() bool {
+	return tuiFlag || tui.IsInteractiveTerminal()
+}
+
+// projectPickerItems builds one tui.Item per project, with a detail line
+// showing the project number and, when it can be fetched without error, a
+// breakdown of existing apps per platform so the picker doubles as a quick
+// "does this project already have an iOS app" check.
+func projectPickerItems(firebaseClient *firebase.Client, projects []firebase.Project) []tui.Item {
+	items := make([]tui.Item, len(projects))
+	for i, project := range projects {
+		detail := fmt.Sprintf("Number: %s", project.ProjectNumber)
+		if apps, err := firebaseClient.ListApps(project.ProjectID); err == nil {
+			detail += fmt.Sprintf(" | Android: %d, iOS: %d, Web: %d",
+				len(firebase.FilterAppsByPlatform(apps, "android")),
+				len(firebase.FilterAppsByPlatform(apps, "ios")),
+				len(firebase.FilterAppsByPlatform(apps, "web")))
+		}
+		items[i] = tui.Item{ID: project.ProjectID, Title: project.DisplayName, Detail: detail}
+	}
+	return items
+}
 
 func runProjectsList(cmd *cobra.Command, args []string) error {
 	verbose := viper.GetBool("verbose")
-	firebaseClient := firebase.NewClient(verbose)
+	firebaseClient := firebase.NewClientWithBackend(verbose, backendFlag)
 
 	if verbose {
 		ui.InfoMsg("Fetching Firebase projects...")
@@ -124,7 +159,7 @@ func runProjectsList(cmd *cobra.Command, args []string) error {
 
 func runProjectsSelect(cmd *cobra.Command, args []string) error {
 	verbose := viper.GetBool("verbose")
-	firebaseClient := firebase.NewClient(verbose)
+	firebaseClient := firebase.NewClientWithBackend(verbose, backendFlag)
 
 	if verbose {
 		ui.InfoMsg("Fetching Firebase projects...")
@@ -143,11 +178,84 @@ func runProjectsSelect(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Beautiful header for selection
+	selectedProject, err := pickProject(firebaseClient, projects, verbose)
+	if err != nil {
+		return err
+	}
+
+	// Success message with project info
+	fmt.Printf("\n%s %s\n",
+		ui.Check.Sprint("🎉 Project Selected:"),
+		ui.Bold.Sprint(selectedProject.DisplayName))
+	fmt.Printf("   %s %s\n\n",
+		ui.Dim.Sprint("Project ID:"),
+		ui.Success.Sprint(selectedProject.ProjectID))
+
+	if autoUse {
+		ui.InfoMsg("Setting as default project for Firebase CLI...")
+		fmt.Printf("%s %s\n",
+			ui.Dim.Sprint("Command:"),
+			ui.Code(fmt.Sprintf("firebase use %s", selectedProject.ProjectID)))
+	}
+
+	// Next steps
+	fmt.Printf("%s\n", ui.Bold.Sprint("Next Steps:"))
+	fmt.Printf("  %s %s\n",
+		ui.Rocket.Sprint("🚀"),
+		ui.Code(fmt.Sprintf("nativefire configure --project %s", selectedProject.ProjectID)))
+
+	fmt.Printf("\n%s Project ID ready to use: %s\n",
+		ui.Info.Sprint("💡"),
+		ui.Secondary.Sprint(selectedProject.ProjectID))
+
+	return nil
+}
+
+// ProjectSelector selects one project from a list of candidates. It exists
+// so `projects select` (and anything else that needs to ask "which Firebase
+// project?") can swap presentation - interactive picker vs. plain stdin
+// prompt - without the caller caring which one ran.
+type ProjectSelector interface {
+	Select(projects []firebase.Project) (firebase.Project, error)
+}
+
+// tuiProjectSelector selects via the bubbletea fuzzy-filter picker, with a
+// detail line per project built from firebaseClient (see projectPickerItems).
+type tuiProjectSelector struct {
+	firebaseClient *firebase.Client
+}
+
+func (s tuiProjectSelector) Select(projects []firebase.Project) (firebase.Project, error) {
+	choice, err := tui.Pick("Select a Firebase project", projectPickerItems(s.firebaseClient, projects))
+	if err != nil {
+		return firebase.Project{}, fmt.Errorf("project selection cancelled: %w", err)
+	}
+	for _, project := range projects {
+		if project.ProjectID == choice.ID {
+			return project, nil
+		}
+	}
+	return firebase.Project{}, fmt.Errorf("selected project %s not found", choice.ID)
+}
+
+// NonInteractive selects by reading a single number from Input, the fallback
+// `projects select` uses when stdout isn't a terminal (see tuiEnabled) so CI
+// and scripted invocations keep working without a TTY. Input defaults to
+// os.Stdin when left unset.
+type NonInteractive struct {
+	Input   io.Reader
+	Verbose bool
+}
+
+func (s NonInteractive) Select(projects []firebase.Project) (firebase.Project, error) {
+	input := s.Input
+	if input == nil {
+		input = os.Stdin
+	}
+
 	ui.Header("Select Your Firebase Project")
 	fmt.Printf("Choose from %s available project(s):\n\n", ui.Success.Sprint(fmt.Sprintf("%d", len(projects))))
 
-	// Display projects with beautiful formatting
 	for i, project := range projects {
 		fmt.Printf("  %s %s\n",
 			ui.Primary.Sprint(fmt.Sprintf("[%d]", i+1)),
@@ -155,7 +263,7 @@ func runProjectsSelect(cmd *cobra.Command, args []string) error {
 		fmt.Printf("      %s %s\n",
 			ui.Dim.Sprint("ID:"),
 			ui.Secondary.Sprint(project.ProjectID))
-		if verbose {
+		if s.Verbose {
 			fmt.Printf("      %s %s\n",
 				ui.Dim.Sprint("Number:"),
 				ui.Dim.Sprint(project.ProjectNumber))
@@ -163,52 +271,37 @@ func runProjectsSelect(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
-	// Get user selection with styled prompt
 	fmt.Printf("%s ", ui.Primary.Sprint(fmt.Sprintf("Select a project (1-%d):", len(projects))))
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
+	reader := bufio.NewReader(input)
+	line, err := reader.ReadString('\n')
 	if err != nil {
-		return fmt.Errorf("failed to read input: %w", err)
+		return firebase.Project{}, fmt.Errorf("failed to read input: %w", err)
 	}
 
-	input = strings.TrimSpace(input)
-	selection, err := strconv.Atoi(input)
+	line = strings.TrimSpace(line)
+	selection, err := strconv.Atoi(line)
 	if err != nil {
-		ui.ErrorMsg(fmt.Sprintf("Invalid selection: %s", input))
-		return fmt.Errorf("invalid selection: %s", input)
+		ui.ErrorMsg(fmt.Sprintf("Invalid selection: %s", line))
+		return firebase.Project{}, fmt.Errorf("invalid selection: %s", line)
 	}
 
 	if selection < 1 || selection > len(projects) {
 		ui.ErrorMsg(fmt.Sprintf("Selection out of range: %d (valid: 1-%d)", selection, len(projects)))
-		return fmt.Errorf("selection out of range: %d (valid range: 1-%d)", selection, len(projects))
+		return firebase.Project{}, fmt.Errorf("selection out of range: %d (valid range: 1-%d)", selection, len(projects))
 	}
 
-	selectedProject := projects[selection-1]
-
-	// Success message with project info
-	fmt.Printf("\n%s %s\n",
-		ui.Check.Sprint("🎉 Project Selected:"),
-		ui.Bold.Sprint(selectedProject.DisplayName))
-	fmt.Printf("   %s %s\n\n",
-		ui.Dim.Sprint("Project ID:"),
-		ui.Success.Sprint(selectedProject.ProjectID))
+	return projects[selection-1], nil
+}
 
-	if autoUse {
-		ui.InfoMsg("Setting as default project for Firebase CLI...")
-		fmt.Printf("%s %s\n",
-			ui.Dim.Sprint("Command:"),
-			ui.Code(fmt.Sprintf("firebase use %s", selectedProject.ProjectID)))
+// pickProject resolves the ProjectSelector for this run - the interactive
+// fuzzy-filter picker when tuiEnabled, the numeric stdin prompt otherwise -
+// and selects one of projects with it.
+func pickProject(firebaseClient *firebase.Client, projects []firebase.Project, verbose bool) (firebase.Project, error) {
+	var selector ProjectSelector
+	if tuiEnabled() {
+		selector = tuiProjectSelector{firebaseClient: firebaseClient}
+	} else {
+		selector = NonInteractive{Verbose: verbose}
 	}
-
-	// Next steps
-	fmt.Printf("%s\n", ui.Bold.Sprint("Next Steps:"))
-	fmt.Printf("  %s %s\n",
-		ui.Rocket.Sprint("🚀"),
-		ui.Code(fmt.Sprintf("nativefire configure --project %s", selectedProject.ProjectID)))
-
-	fmt.Printf("\n%s Project ID ready to use: %s\n",
-		ui.Info.Sprint("💡"),
-		ui.Secondary.Sprint(selectedProject.ProjectID))
-
-	return nil
+	return selector.Select(projects)
 }
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+
+	"github.com/clix-so/nativefire/internal/firebase"
 )
 
 func TestProjectsListCommand(t *testing.T) {
@@ -267,6 +269,50 @@ func TestConfigureCommandWithProjectSelection(t *testing.T) {
 	}
 }
 
+// TestNonInteractiveProjectSelector covers the numeric stdin prompt
+// TestProjectsSelectCommand can't reach, since that test only drives the
+// real `projects select` command (which needs a live Firebase CLI and a
+// real TTY to exercise either selector path end to end).
+func TestNonInteractiveProjectSelector(t *testing.T) {
+	projects := []firebase.Project{
+		{ProjectID: "alpha", DisplayName: "Alpha", ProjectNumber: "1"},
+		{ProjectID: "beta", DisplayName: "Beta", ProjectNumber: "2"},
+	}
+
+	tests := []struct {
+		name        string
+		input       string
+		expectID    string
+		expectError bool
+	}{
+		{name: "selects by number", input: "2\n", expectID: "beta"},
+		{name: "trims surrounding whitespace", input: "  1  \n", expectID: "alpha"},
+		{name: "rejects non-numeric input", input: "nope\n", expectError: true},
+		{name: "rejects out-of-range input", input: "9\n", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := NonInteractive{Input: strings.NewReader(tt.input)}
+
+			project, err := selector.Select(projects)
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got project %+v", project)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if project.ProjectID != tt.expectID {
+				t.Errorf("ProjectID = %q, want %q", project.ProjectID, tt.expectID)
+			}
+		})
+	}
+}
+
 func resetProjectsCommand() {
 	verbose = false
 	cfgFile = ""
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/clix-so/nativefire/internal/logger"
 	"github.com/clix-so/nativefire/internal/ui"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -12,6 +13,11 @@ import (
 
 var cfgFile string
 var verbose bool
+var logLevel string
+var logFile string
+var logFormat string
+var noUpdateCheck bool
+var progressMode string
 
 // Version information
 var (
@@ -53,10 +59,11 @@ Need help? Use ` + ui.Code("nativefire [command] --help") + ` for detailed infor
 }
 
 func Execute() {
-	err := rootCmd.Execute()
+	ranCmd, err := rootCmd.ExecuteC()
 	if err != nil {
 		os.Exit(1)
 	}
+	notifyIfUpdateAvailable(ranCmd)
 }
 
 func init() {
@@ -64,8 +71,20 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.nativefire.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level: debug, info, warn, or error")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "write structured JSON logs to this file in addition to the console")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "console log format: text (human-readable) or json (for CI)")
+	rootCmd.PersistentFlags().BoolVar(&noUpdateCheck, "no-update-check", false,
+		"Skip the background check for a newer nativefire release after this command finishes")
+	rootCmd.PersistentFlags().StringVar(&progressMode, "progress", "",
+		"progress output mode: interactive (default on a TTY), text (CI-friendly log lines), or json (newline-delimited JSON events)")
 
 	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	_ = viper.BindPFlag("no-update-check", rootCmd.PersistentFlags().Lookup("no-update-check"))
+	_ = viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level"))
+	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
+	_ = viper.BindPFlag("log-format", rootCmd.PersistentFlags().Lookup("log-format"))
+	_ = viper.BindPFlag("progress", rootCmd.PersistentFlags().Lookup("progress"))
 }
 
 func initConfig() {
@@ -85,4 +104,50 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err == nil && verbose {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	configureLogger()
+	configureRenderer()
+}
+
+// configureRenderer builds the process-wide ui.Renderer from
+// --progress/NATIVEFIRE_PROGRESS, so ShowLoader and friends pick up
+// CI-friendly or JSON output without call sites needing their own flags.
+func configureRenderer() {
+	if mode := viper.GetString("progress"); mode != "" {
+		ui.SetCurrentRenderer(ui.NewRenderer(ui.Options{Mode: ui.Mode(mode)}))
+	}
+	if style := viper.GetString("spinner-style"); style != "" {
+		ui.SetDefaultSpinnerStyle(style)
+	}
+}
+
+// configureLogger builds the process-wide logger.Default() from
+// --log-level/--log-file/--log-format (and config/env equivalents via
+// viper), so every package that logs through logger.Default() picks up the
+// user's preferences without needing its own flags.
+func configureLogger() {
+	level, err := logger.ParseLevel(viper.GetString("log-level"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v, defaulting to info\n", err)
+	}
+	if viper.GetBool("verbose") && viper.GetString("log-level") == "info" {
+		level = logger.LevelDebug
+	}
+
+	var sinks []logger.Sink
+	if viper.GetString("log-format") == "json" {
+		sinks = append(sinks, logger.NewJSONSink(os.Stdout))
+	} else {
+		sinks = append(sinks, logger.NewConsoleSink(os.Stderr))
+	}
+	if path := viper.GetString("log-file"); path != "" {
+		fileSink, err := logger.NewRotatingFileSink(path, 0)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  could not open log file %s: %v\n", path, err)
+		} else {
+			sinks = append(sinks, fileSink)
+		}
+	}
+
+	logger.SetDefault(logger.New(level, sinks...))
 }
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+
+	"github.com/clix-so/nativefire/internal/logger"
+	"github.com/spf13/viper"
 )
 
 func TestRootCommand(t *testing.T) {
@@ -90,6 +93,24 @@ func TestInitConfig(t *testing.T) {
 	})
 }
 
+func TestConfigureLogger(t *testing.T) {
+	original := logger.Default()
+	defer func() {
+		logger.SetDefault(original)
+		viper.Set("log-level", nil)
+		viper.Set("log-format", nil)
+	}()
+
+	viper.Set("log-level", "debug")
+	viper.Set("log-format", "json")
+
+	configureLogger()
+
+	if logger.Default() == original {
+		t.Error("expected configureLogger to replace the default logger")
+	}
+}
+
 func resetRootCommand() {
 	verbose = false
 	cfgFile = ""
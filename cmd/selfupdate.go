@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/clix-so/nativefire/internal/selfupdate"
+	"github.com/clix-so/nativefire/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	selfUpdateCheck   bool
+	selfUpdateChannel string
+)
+
+var selfUpdateCmd = &cobra.Command{
+	Use:     "self-update",
+	Aliases: []string{"update"},
+	Short:   "⬆️  Update nativefire to the latest release",
+	Long: ui.Primary.Sprint("⬆️  Self Update\n\n") +
+		"Downloads the latest nativefire release from GitHub, verifies its checksum\n" +
+		"(and its minisign signature, when the release has one), and replaces the running binary.\n\n" +
+		ui.Bold.Sprint("Examples:") + "\n" +
+		"  " + ui.Code("nativefire self-update") + "\n" +
+		"  " + ui.Code("nativefire self-update --check") + "\n" +
+		"  " + ui.Code("nativefire self-update --channel prerelease") + "\n\n" +
+		ui.Dim.Sprint("Set "+selfupdate.DisabledEnvVar+"=1 to disable self-update, e.g. for OS-packaged installs."),
+	RunE: runSelfUpdate,
+}
+
+func init() {
+	rootCmd.AddCommand(selfUpdateCmd)
+
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateCheck, "check", false, "Report an available update without installing it")
+	selfUpdateCmd.Flags().StringVar(&selfUpdateChannel, "channel", "stable", "Release channel: stable or prerelease")
+}
+
+func runSelfUpdate(cmd *cobra.Command, args []string) error {
+	if selfupdate.Disabled() {
+		ui.WarningMsg(fmt.Sprintf("Self-update is disabled via %s", selfupdate.DisabledEnvVar))
+		return nil
+	}
+
+	channel := selfupdate.Channel(selfUpdateChannel)
+	if channel != selfupdate.Stable && channel != selfupdate.Prerelease {
+		return fmt.Errorf("invalid --channel %q; expected stable or prerelease", selfUpdateChannel)
+	}
+
+	ui.Header("Checking for updates")
+
+	release, err := selfupdate.FetchLatest(channel)
+	if err != nil {
+		return fmt.Errorf("failed to check for updates: %w", err)
+	}
+
+	latestVersion := strings.TrimPrefix(release.TagName, "v")
+	if latestVersion == version {
+		ui.SuccessMsg(fmt.Sprintf("nativefire %s is already up to date", version))
+		return nil
+	}
+
+	ui.InfoMsg(fmt.Sprintf("%s → %s available", version, latestVersion))
+	if selfUpdateCheck {
+		return nil
+	}
+
+	assetName := selfupdate.AssetName(latestVersion)
+	asset, err := selfupdate.FindAsset(release, assetName)
+	if err != nil {
+		return fmt.Errorf("no release asset for this platform: %w", err)
+	}
+
+	checksumsAsset, err := selfupdate.FindAsset(release, "checksums.txt")
+	if err != nil {
+		return fmt.Errorf("release %s has no checksums.txt: %w", release.TagName, err)
+	}
+
+	var archiveData, checksumsData []byte
+	err = ui.ShowLoader(fmt.Sprintf("Downloading %s", assetName), func() error {
+		archiveData, err = selfupdate.Download(asset.BrowserDownloadURL)
+		if err != nil {
+			return err
+		}
+		checksumsData, err = selfupdate.Download(checksumsAsset.BrowserDownloadURL)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to download update: %w", err)
+	}
+
+	if err := selfupdate.VerifyChecksum(checksumsData, assetName, archiveData); err != nil {
+		return fmt.Errorf("update verification failed: %w", err)
+	}
+
+	if sigAsset, err := selfupdate.FindAsset(release, "checksums.txt.minisig"); err == nil {
+		signature, err := selfupdate.Download(sigAsset.BrowserDownloadURL)
+		if err != nil {
+			return fmt.Errorf("failed to download checksums.txt.minisig: %w", err)
+		}
+		if err := selfupdate.VerifyMinisignSignature(checksumsData, signature); err != nil {
+			return fmt.Errorf("update verification failed: %w", err)
+		}
+	}
+
+	binary, err := selfupdate.ExtractBinary(archiveData, selfupdate.BinaryName())
+	if err != nil {
+		return fmt.Errorf("failed to extract update: %w", err)
+	}
+
+	if err := selfupdate.Apply(bytes.NewReader(binary)); err != nil {
+		return fmt.Errorf("failed to install update: %w", err)
+	}
+
+	ui.SuccessMsg(fmt.Sprintf("Updated nativefire %s → %s", version, latestVersion))
+	return nil
+}
+
+// updateNudgeTimeout bounds how long notifyIfUpdateAvailable will wait on
+// the GitHub Releases API before giving up, so a slow or unreachable
+// network can't add a perceptible delay after a command has already
+// finished.
+const updateNudgeTimeout = 2 * time.Second
+
+// notifyIfUpdateAvailable prints a one-line "update available" nudge after a
+// command has already finished successfully, unless self-update itself ran
+// (nothing to nudge about), --no-update-check was passed, or the check is
+// disabled via config or NATIVEFIRE_UPDATE_NOTIFY_DISABLED - so CI and
+// air-gapped runs stay silent and fast.
+func notifyIfUpdateAvailable(ranCmd *cobra.Command) {
+	if ranCmd != nil && ranCmd.Name() == selfUpdateCmd.Name() {
+		return
+	}
+	if viper.GetBool("no-update-check") || selfupdate.Disabled() || selfupdate.NotifyDisabled() {
+		return
+	}
+
+	result := make(chan *selfupdate.Release, 1)
+	go func() {
+		release, err := selfupdate.FetchLatest(selfupdate.Stable)
+		if err != nil {
+			result <- nil
+			return
+		}
+		result <- release
+	}()
+
+	select {
+	case release := <-result:
+		if release == nil {
+			return
+		}
+		if latestVersion := strings.TrimPrefix(release.TagName, "v"); latestVersion != version {
+			ui.InfoMsg(fmt.Sprintf("💡 nativefire %s is available (you have %s) — run `nativefire self-update`",
+				latestVersion, version))
+		}
+	case <-time.After(updateNudgeTimeout):
+		// Don't block process exit on a slow or unreachable network.
+	}
+}
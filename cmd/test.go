@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/clix-so/nativefire/internal/devicetest"
+	"github.com/clix-so/nativefire/internal/platform"
+	"github.com/clix-so/nativefire/internal/plugin"
+	"github.com/clix-so/nativefire/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testPlatform string
+	testDevice   string
+	testOS       string
+	testScheme   string
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test",
+	Short: "🧪 Smoke-test Firebase integration on a simulator or device",
+	Long: ui.Primary.Sprint("🧪 Firebase Integration Smoke Test\n\n") +
+		"Builds and runs the project's test target on a booted iOS simulator, a paired\n" +
+		"physical device, or a connected Android device - confirming the config file and\n" +
+		"initialization code " + ui.Code("nativefire configure") + " wrote actually build and register with Firebase.\n\n" +
+		ui.Bold.Sprint("Flags:") + "\n" +
+		"  " + ui.Code("--platform") + " - ios or android; auto-detected if omitted\n" +
+		"  " + ui.Code("--device") + "   - Simulator/device name, UDID, or adb serial to target\n" +
+		"  " + ui.Code("--os") + "       - iOS Simulator runtime version to prefer, e.g. 17.4\n" +
+		"  " + ui.Code("--scheme") + "   - Xcode scheme to test (iOS only; required)\n\n" +
+		ui.Bold.Sprint("Examples:") + "\n" +
+		"  " + ui.Code("nativefire test --platform ios --scheme MyApp") + "\n" +
+		"  " + ui.Code("nativefire test --platform android --device emulator-5554"),
+	RunE: runTest,
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+
+	testCmd.Flags().StringVar(&testPlatform, "platform", "", "Target platform to test (ios, android); auto-detected if omitted")
+	testCmd.Flags().StringVar(&testDevice, "device", "", "Simulator/device name, UDID, or adb serial to target")
+	testCmd.Flags().StringVar(&testOS, "os", "", "iOS Simulator runtime version to prefer, e.g. 17.4")
+	testCmd.Flags().StringVar(&testScheme, "scheme", "", "Xcode scheme to test (iOS only)")
+}
+
+func runTest(cmd *cobra.Command, args []string) error {
+	targetPlatform, err := resolveTestPlatform()
+	if err != nil {
+		return err
+	}
+
+	ui.ProjectHeader(targetPlatform.Name())
+
+	switch targetPlatform.Name() {
+	case "iOS", "iOS Simulator":
+		if err := runIOSTest(); err != nil {
+			return err
+		}
+	case "Android":
+		ui.Step(1, "Checking for connected Android devices...")
+		if err := devicetest.RunAndroidTests(testDevice); err != nil {
+			return fmt.Errorf("android test run failed: %w", err)
+		}
+	default:
+		return fmt.Errorf("nativefire test does not support %s yet", targetPlatform.Name())
+	}
+
+	ui.SuccessMsg("Firebase integration test passed")
+	return nil
+}
+
+// resolveTestPlatform honors --platform (via plugin.FromString, so a plugin
+// platform can be targeted too), falling back to single-platform
+// auto-detection the same way `nativefire package` does.
+func resolveTestPlatform() (platform.Platform, error) {
+	if testPlatform != "" {
+		return plugin.FromString(testPlatform)
+	}
+	return platform.DetectPlatform()
+}
+
+// runIOSTest prefers a booted/matching simulator, the default destination
+// for a plain `nativefire test --platform ios`; --device only falls through
+// to a real device once it fails to match any simulator, since a real
+// device's name/UDID can't be told apart from a simulator's up front.
+func runIOSTest() error {
+	if testScheme == "" {
+		return fmt.Errorf("--scheme is required to test an iOS project")
+	}
+
+	ui.Step(1, "Looking for a simulator...")
+	sims, err := devicetest.ListSimulators()
+	if err == nil {
+		if sim, err := devicetest.SelectSimulator(sims, testOS, testDevice); err == nil {
+			ui.InfoMsg(fmt.Sprintf("Running %s on %s (%s, %s)", testScheme, sim.Name, sim.OS, sim.UDID))
+			if err := devicetest.RunIOSSimulatorTests(testScheme, sim); err != nil {
+				return fmt.Errorf("simulator test run failed: %w", err)
+			}
+			return nil
+		}
+	}
+
+	if testDevice == "" {
+		return fmt.Errorf("no matching simulator found; pass --device to target a real device")
+	}
+
+	ui.InfoMsg(fmt.Sprintf("No matching simulator found; targeting real device %q", testDevice))
+	if err := devicetest.RunIOSDeviceTests(testScheme, testDevice); err != nil {
+		return fmt.Errorf("device test run failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package apple
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveBundleID finds a project.pbxproj under projectDir (or its ios/
+// subdirectory) and returns PRODUCT_BUNDLE_IDENTIFIER fully resolved against
+// that build configuration's settings, following $(VAR)/${VAR} references
+// into any referenced .xcconfig file - so a project that only sets
+// PRODUCT_BUNDLE_IDENTIFIER = "$(PRODUCT_BUNDLE_IDENTIFIER)" at one layer and
+// the literal value in an .xcconfig still resolves correctly.
+//
+// configuration picks which XCBuildConfiguration to read ("Release",
+// "Debug", ...); pass "" to prefer Release, then Debug, then whichever
+// configuration is found first.
+func ResolveBundleID(projectDir, configuration string) (string, error) {
+	pbxprojPath, err := FindPbxproj(projectDir)
+	if err != nil {
+		return "", err
+	}
+
+	configs, err := ParseBuildConfigurations(pbxprojPath)
+	if err != nil {
+		return "", err
+	}
+
+	settings, ok := selectConfiguration(configs, configuration)
+	if !ok {
+		return "", fmt.Errorf("no %q build configuration found in %s", configuration, pbxprojPath)
+	}
+
+	raw, ok := settings["PRODUCT_BUNDLE_IDENTIFIER"]
+	if !ok {
+		return "", fmt.Errorf("PRODUCT_BUNDLE_IDENTIFIER not set in %s", pbxprojPath)
+	}
+
+	resolved := ResolveVariable(raw, settings)
+	if resolved == "" || strings.Contains(resolved, "$(") || strings.Contains(resolved, "${") {
+		return "", fmt.Errorf("could not fully resolve PRODUCT_BUNDLE_IDENTIFIER %q in %s", raw, pbxprojPath)
+	}
+
+	return resolved, nil
+}
+
+func selectConfiguration(configs map[string]BuildSettings, configuration string) (BuildSettings, bool) {
+	if configuration != "" {
+		settings, ok := configs[configuration]
+		return settings, ok
+	}
+
+	for _, name := range []string{"Release", "Debug"} {
+		if settings, ok := configs[name]; ok {
+			return settings, true
+		}
+	}
+	for _, settings := range configs {
+		return settings, true
+	}
+	return nil, false
+}
+
+// FindPbxproj locates the .xcodeproj/project.pbxproj under projectDir or
+// its ios/ subdirectory.
+func FindPbxproj(projectDir string) (string, error) {
+	for _, pattern := range []string{
+		filepath.Join(projectDir, "*.xcodeproj", "project.pbxproj"),
+		filepath.Join(projectDir, "ios", "*.xcodeproj", "project.pbxproj"),
+	} {
+		if matches, err := filepath.Glob(pattern); err == nil && len(matches) > 0 {
+			return matches[0], nil
+		}
+	}
+	return "", fmt.Errorf("no .xcodeproj found under %s", projectDir)
+}
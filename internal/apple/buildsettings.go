@@ -0,0 +1,174 @@
+package apple
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// BuildSettings maps a build setting name to its raw value for one
+// XCBuildConfiguration (e.g. "Debug", "Release") - raw meaning it may still
+// contain unresolved $(VAR)/${VAR} references. See ResolveVariable.
+type BuildSettings map[string]string
+
+var (
+	buildConfigSectionRe = regexp.MustCompile(
+		`(?s)/\* Begin XCBuildConfiguration section \*/\n(.*?)/\* End XCBuildConfiguration section \*/`)
+	buildConfigEntryRe = regexp.MustCompile(
+		`(?s)[0-9A-F]{24} /\* (\w+) \*/ = \{\s*isa = XCBuildConfiguration;(.*?)\n\t\t\};`)
+	buildSettingsBlockRe = regexp.MustCompile(`(?s)buildSettings = \{(.*?)\n\t+\};`)
+	baseConfigRe         = regexp.MustCompile(`baseConfigurationReference = [0-9A-F]+ /\* (.+?) \*/;`)
+	settingLineRe        = regexp.MustCompile(`(?m)^\s*([A-Za-z_][A-Za-z0-9_]*(?:\[[^\]]*])?)\s*=\s*(.+?);\s*$`)
+	variableRe           = regexp.MustCompile(`\$\(([A-Za-z_][A-Za-z0-9_]*)\)|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+)
+
+// ParseBuildConfigurations reads the XCBuildConfiguration sections out of
+// pbxprojPath and returns one BuildSettings per named configuration, each
+// already merged with its baseConfigurationReference .xcconfig file (the
+// xcconfig's values are overridden by anything set directly in
+// buildSettings, matching Xcode's own precedence — except a buildSettings
+// entry that's just a self-referencing placeholder like
+// PRODUCT_BUNDLE_IDENTIFIER = "$(PRODUCT_BUNDLE_IDENTIFIER)", which leaves
+// the xcconfig's value in place instead of clobbering it with a reference
+// to itself).
+func ParseBuildConfigurations(pbxprojPath string) (map[string]BuildSettings, error) {
+	data, err := os.ReadFile(pbxprojPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", pbxprojPath, err)
+	}
+
+	section := buildConfigSectionRe.FindStringSubmatch(string(data))
+	if section == nil {
+		return nil, fmt.Errorf("no XCBuildConfiguration section found in %s", pbxprojPath)
+	}
+
+	projectDir := filepath.Dir(pbxprojPath)
+	result := make(map[string]BuildSettings)
+
+	for _, entry := range buildConfigEntryRe.FindAllStringSubmatch(section[1], -1) {
+		name, body := entry[1], entry[2]
+
+		settings := BuildSettings{}
+		if match := baseConfigRe.FindStringSubmatch(body); match != nil {
+			if base, err := loadXCConfig(projectDir, match[1]); err == nil {
+				for k, v := range base {
+					settings[k] = v
+				}
+			}
+		}
+		if block := buildSettingsBlockRe.FindStringSubmatch(body); block != nil {
+			for k, v := range parseSettingLines(block[1]) {
+				if isSelfReference(k, v) {
+					// A buildSettings entry like PRODUCT_BUNDLE_IDENTIFIER =
+					// "$(PRODUCT_BUNDLE_IDENTIFIER)" only exists to pull the
+					// real value from an xcconfig; overriding with it would
+					// just clobber that value with a reference to itself.
+					continue
+				}
+				settings[k] = v
+			}
+		}
+
+		result[name] = settings
+	}
+
+	return result, nil
+}
+
+func parseSettingLines(block string) BuildSettings {
+	settings := BuildSettings{}
+	for _, m := range settingLineRe.FindAllStringSubmatch(block, -1) {
+		settings[m[1]] = strings.Trim(strings.TrimSpace(m[2]), `"`)
+	}
+	return settings
+}
+
+// loadXCConfig parses name as `KEY = value` lines, following #include
+// directives, searching for it alongside the .xcodeproj and one directory
+// up (the common place an .xcconfig sits relative to its .xcodeproj).
+func loadXCConfig(projectDir, name string) (BuildSettings, error) {
+	dirs := []string{projectDir, filepath.Dir(projectDir)}
+	return loadXCConfigFile(dirs, name, map[string]bool{})
+}
+
+func loadXCConfigFile(dirs []string, name string, seen map[string]bool) (BuildSettings, error) {
+	if seen[name] {
+		return BuildSettings{}, nil
+	}
+	seen[name] = true
+
+	var path string
+	for _, dir := range dirs {
+		candidate := filepath.Join(dir, name)
+		if _, err := os.Stat(candidate); err == nil {
+			path = candidate
+			break
+		}
+	}
+	if path == "" {
+		return nil, fmt.Errorf("xcconfig %s not found", name)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	settings := BuildSettings{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#include") {
+			included := strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "#include")), `"`)
+			if includedSettings, err := loadXCConfigFile(dirs, included, seen); err == nil {
+				for k, v := range includedSettings {
+					settings[k] = v
+				}
+			}
+			continue
+		}
+
+		if idx := strings.Index(line, "="); idx != -1 {
+			key := strings.TrimSpace(line[:idx])
+			value := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line[idx+1:]), ";"))
+			settings[key] = value
+		}
+	}
+	return settings, scanner.Err()
+}
+
+// isSelfReference reports whether value is exactly "$(key)" or "${key}",
+// the no-op placeholder Xcode writes when a setting is meant to be supplied
+// by a referenced .xcconfig rather than the buildSettings block itself.
+func isSelfReference(key, value string) bool {
+	return value == "$("+key+")" || value == "${"+key+"}"
+}
+
+// ResolveVariable expands $(VAR)/${VAR} references in value against
+// settings, recursively up to a fixed number of passes so a circular
+// reference can't loop forever. A reference with no matching setting is
+// left as-is.
+func ResolveVariable(value string, settings BuildSettings) string {
+	for i := 0; i < 10 && variableRe.MatchString(value); i++ {
+		value = variableRe.ReplaceAllStringFunc(value, func(match string) string {
+			groups := variableRe.FindStringSubmatch(match)
+			name := groups[1]
+			if name == "" {
+				name = groups[2]
+			}
+			if resolved, ok := settings[name]; ok {
+				return resolved
+			}
+			return match
+		})
+	}
+	return value
+}
@@ -0,0 +1,132 @@
+package apple
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const samplePbxproj = `// !$*UTF8*$!
+{
+	archiveVersion = 1;
+	objects = {
+		/* Begin XCBuildConfiguration section */
+		1111111111111111111111AA /* Debug */ = {
+			isa = XCBuildConfiguration;
+			baseConfigurationReference = 2222222222222222222222BB /* Debug.xcconfig */;
+			buildSettings = {
+				PRODUCT_BUNDLE_IDENTIFIER = "$(PRODUCT_BUNDLE_IDENTIFIER)";
+				INFOPLIST_FILE = Runner/Info.plist;
+			};
+			name = Debug;
+		};
+		3333333333333333333333CC /* Release */ = {
+			isa = XCBuildConfiguration;
+			buildSettings = {
+				PRODUCT_BUNDLE_IDENTIFIER = "com.example.release";
+			};
+			name = Release;
+		};
+		/* End XCBuildConfiguration section */
+	};
+}
+`
+
+func writeProject(t *testing.T, pbxproj string, xcconfigs map[string]string) (projectDir, pbxprojPath string) {
+	t.Helper()
+	projectDir = t.TempDir()
+	xcodeprojDir := filepath.Join(projectDir, "Runner.xcodeproj")
+	if err := os.MkdirAll(xcodeprojDir, 0o755); err != nil {
+		t.Fatalf("failed to create .xcodeproj dir: %v", err)
+	}
+	pbxprojPath = filepath.Join(xcodeprojDir, "project.pbxproj")
+	if err := os.WriteFile(pbxprojPath, []byte(pbxproj), 0o644); err != nil {
+		t.Fatalf("failed to write pbxproj: %v", err)
+	}
+	for name, contents := range xcconfigs {
+		if err := os.WriteFile(filepath.Join(projectDir, name), []byte(contents), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return projectDir, pbxprojPath
+}
+
+func TestParseBuildConfigurationsMergesXCConfig(t *testing.T) {
+	_, pbxprojPath := writeProject(t, samplePbxproj, map[string]string{
+		"Debug.xcconfig": "PRODUCT_BUNDLE_IDENTIFIER = com.example.debug\n",
+	})
+
+	configs, err := ParseBuildConfigurations(pbxprojPath)
+	if err != nil {
+		t.Fatalf("ParseBuildConfigurations() error = %v", err)
+	}
+
+	debug, ok := configs["Debug"]
+	if !ok {
+		t.Fatal("expected a Debug configuration")
+	}
+	if debug["PRODUCT_BUNDLE_IDENTIFIER"] != "com.example.debug" {
+		t.Errorf("expected the self-referencing buildSettings placeholder to leave the xcconfig value in place, got %q",
+			debug["PRODUCT_BUNDLE_IDENTIFIER"])
+	}
+	if debug["INFOPLIST_FILE"] != "Runner/Info.plist" {
+		t.Errorf("INFOPLIST_FILE = %q", debug["INFOPLIST_FILE"])
+	}
+
+	release, ok := configs["Release"]
+	if !ok {
+		t.Fatal("expected a Release configuration")
+	}
+	if release["PRODUCT_BUNDLE_IDENTIFIER"] != "com.example.release" {
+		t.Errorf("Release PRODUCT_BUNDLE_IDENTIFIER = %q", release["PRODUCT_BUNDLE_IDENTIFIER"])
+	}
+}
+
+func TestResolveVariableExpandsFromXCConfig(t *testing.T) {
+	settings := BuildSettings{"PRODUCT_BUNDLE_IDENTIFIER": "com.example.debug"}
+	resolved := ResolveVariable("$(PRODUCT_BUNDLE_IDENTIFIER)", settings)
+	if resolved != "com.example.debug" {
+		t.Errorf("ResolveVariable() = %q, want com.example.debug", resolved)
+	}
+}
+
+func TestResolveVariableLeavesUnknownReferencesAlone(t *testing.T) {
+	resolved := ResolveVariable("$(UNKNOWN_VAR)", BuildSettings{})
+	if resolved != "$(UNKNOWN_VAR)" {
+		t.Errorf("ResolveVariable() = %q, want it unchanged", resolved)
+	}
+}
+
+func TestResolveBundleIDFollowsXCConfigReference(t *testing.T) {
+	projectDir, _ := writeProject(t, samplePbxproj, map[string]string{
+		"Debug.xcconfig": "PRODUCT_BUNDLE_IDENTIFIER = com.example.debug\n",
+	})
+
+	bundleID, err := ResolveBundleID(projectDir, "Debug")
+	if err != nil {
+		t.Fatalf("ResolveBundleID() error = %v", err)
+	}
+	if bundleID != "com.example.debug" {
+		t.Errorf("ResolveBundleID() = %q, want com.example.debug", bundleID)
+	}
+}
+
+func TestResolveBundleIDPrefersReleaseWhenConfigurationUnspecified(t *testing.T) {
+	projectDir, _ := writeProject(t, samplePbxproj, map[string]string{
+		"Debug.xcconfig": "PRODUCT_BUNDLE_IDENTIFIER = com.example.debug\n",
+	})
+
+	bundleID, err := ResolveBundleID(projectDir, "")
+	if err != nil {
+		t.Fatalf("ResolveBundleID() error = %v", err)
+	}
+	if bundleID != "com.example.release" {
+		t.Errorf("ResolveBundleID() = %q, want com.example.release", bundleID)
+	}
+}
+
+func TestResolveBundleIDErrorsWhenNoProjectFound(t *testing.T) {
+	if _, err := ResolveBundleID(t.TempDir(), ""); err == nil {
+		t.Fatal("expected an error when no .xcodeproj exists")
+	}
+}
@@ -0,0 +1,196 @@
+// Package apple parses the Apple project file formats nativefire needs to
+// read real values out of instead of guessing: XML/binary property lists
+// and the Xcode project.pbxproj/.xcconfig build-setting format, including
+// $(VAR) resolution. See ResolveBundleID for the main entry point the
+// firebase client uses.
+package apple
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// DecodePlist parses the property list at path (XML or binary) into a
+// map[string]any. Binary plists are converted to XML via `plutil -convert
+// xml1 -o -` when available, since the standard library has no binary plist
+// decoder and pulling in a cgo-based one isn't worth it for this one value.
+func DecodePlist(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if isBinaryPlist(data) {
+		data, err = convertBinaryToXML(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert binary plist %s: %w", path, err)
+		}
+	}
+
+	value, err := decodeXMLPlist(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse plist %s: %w", path, err)
+	}
+	return value, nil
+}
+
+func isBinaryPlist(data []byte) bool {
+	return len(data) >= 8 && string(data[:8]) == "bplist00"
+}
+
+// convertBinaryToXML shells out to plutil, the only binary-plist decoder
+// reliably available without adding a new dependency - and only on macOS,
+// where binary plists actually show up in practice.
+func convertBinaryToXML(path string) ([]byte, error) {
+	plutil, err := exec.LookPath("plutil")
+	if err != nil {
+		return nil, fmt.Errorf("binary plist requires plutil, which is not available on this host: %w", err)
+	}
+	return exec.Command(plutil, "-convert", "xml1", "-o", "-", path).Output()
+}
+
+// decodeXMLPlist walks the plist's root <dict> with a token-based decoder,
+// since its heterogeneous key/value structure doesn't map onto Go struct
+// tags the way encoding/xml is usually used.
+func decodeXMLPlist(data []byte) (map[string]any, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if se, ok := tok.(xml.StartElement); ok && se.Name.Local == "dict" {
+			return decodeDict(dec)
+		}
+	}
+}
+
+func decodeDict(dec *xml.Decoder) (map[string]any, error) {
+	result := make(map[string]any)
+	var key string
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "key" {
+				text, err := readElementText(dec, t.Name.Local)
+				if err != nil {
+					return nil, err
+				}
+				key = text
+				continue
+			}
+			value, err := decodeValue(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			if key != "" {
+				result[key] = value
+				key = ""
+			}
+		case xml.EndElement:
+			if t.Name.Local == "dict" {
+				return result, nil
+			}
+		}
+	}
+}
+
+func decodeArray(dec *xml.Decoder) ([]any, error) {
+	var result []any
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			value, err := decodeValue(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+		case xml.EndElement:
+			if t.Name.Local == "array" {
+				return result, nil
+			}
+		}
+	}
+}
+
+func decodeValue(dec *xml.Decoder, start xml.StartElement) (any, error) {
+	switch start.Name.Local {
+	case "dict":
+		return decodeDict(dec)
+	case "array":
+		return decodeArray(dec)
+	case "string":
+		return readElementText(dec, start.Name.Local)
+	case "true":
+		if _, err := readElementText(dec, start.Name.Local); err != nil {
+			return nil, err
+		}
+		return true, nil
+	case "false":
+		if _, err := readElementText(dec, start.Name.Local); err != nil {
+			return nil, err
+		}
+		return false, nil
+	case "integer":
+		text, err := readElementText(dec, start.Name.Local)
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid <integer>: %w", err)
+		}
+		return n, nil
+	case "real":
+		text, err := readElementText(dec, start.Name.Local)
+		if err != nil {
+			return nil, err
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid <real>: %w", err)
+		}
+		return f, nil
+	default:
+		// <date>/<data> and anything else this parser doesn't need a typed
+		// value for: keep the raw text rather than failing the whole parse.
+		return readElementText(dec, start.Name.Local)
+	}
+}
+
+// readElementText reads character data up to the end of the currently open
+// element named name, handling both "<string>x</string>" and the empty
+// "<true/>"/"<key></key>" forms.
+func readElementText(dec *xml.Decoder, name string) (string, error) {
+	var buf bytes.Buffer
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return "", fmt.Errorf("unexpected end of plist inside <%s>", name)
+			}
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			buf.Write(t)
+		case xml.EndElement:
+			if t.Name.Local == name {
+				return buf.String(), nil
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package apple
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const samplePlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>$(PRODUCT_BUNDLE_IDENTIFIER)</string>
+	<key>CFBundleVersion</key>
+	<string>1</string>
+	<key>LSRequiresIPhoneOS</key>
+	<true/>
+	<key>UISupportedInterfaceOrientations</key>
+	<array>
+		<string>UIInterfaceOrientationPortrait</string>
+		<string>UIInterfaceOrientationLandscapeLeft</string>
+	</array>
+	<key>CFBundleShortVersionString</key>
+	<real>1.0</real>
+</dict>
+</plist>
+`
+
+func writePlist(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "Info.plist")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write plist: %v", err)
+	}
+	return path
+}
+
+func TestDecodePlistParsesStringsBoolsAndArrays(t *testing.T) {
+	path := writePlist(t, samplePlist)
+
+	value, err := DecodePlist(path)
+	if err != nil {
+		t.Fatalf("DecodePlist() error = %v", err)
+	}
+
+	if got := value["CFBundleIdentifier"]; got != "$(PRODUCT_BUNDLE_IDENTIFIER)" {
+		t.Errorf("CFBundleIdentifier = %v, want $(PRODUCT_BUNDLE_IDENTIFIER)", got)
+	}
+	if got := value["LSRequiresIPhoneOS"]; got != true {
+		t.Errorf("LSRequiresIPhoneOS = %v, want true", got)
+	}
+	orientations, ok := value["UISupportedInterfaceOrientations"].([]any)
+	if !ok || len(orientations) != 2 {
+		t.Fatalf("UISupportedInterfaceOrientations = %v, want a 2-element array", value["UISupportedInterfaceOrientations"])
+	}
+	if orientations[0] != "UIInterfaceOrientationPortrait" {
+		t.Errorf("orientations[0] = %v, want UIInterfaceOrientationPortrait", orientations[0])
+	}
+}
+
+func TestDecodePlistErrorsOnMissingFile(t *testing.T) {
+	_, err := DecodePlist(filepath.Join(t.TempDir(), "missing.plist"))
+	if err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
+
+func TestDecodePlistErrorsOnInvalidBinaryPlist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Info.plist")
+	// A well-formed bplist00 header but garbage after it: plutil (if present)
+	// rejects it, and its absence is itself an error - either way DecodePlist
+	// must return an error rather than panic.
+	if err := os.WriteFile(path, append([]byte("bplist00"), 0, 1, 2, 3), 0o644); err != nil {
+		t.Fatalf("failed to write binary plist fixture: %v", err)
+	}
+
+	if _, err := DecodePlist(path); err == nil {
+		t.Fatal("expected an error for a malformed binary plist")
+	}
+}
@@ -2,12 +2,52 @@ package dependencies
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
+	"strings"
 
 	"github.com/clix-so/nativefire/internal/ui"
 )
 
+// execCommand is exec.Command, indirected so tests can substitute a fake
+// process instead of actually shelling out to version-check or install
+// real tools.
+var execCommand = exec.Command
+
+// GradleWrapperVersion pins the Gradle distribution AndroidPlatform's Gradle
+// bootstrap downloads when a project has neither a gradlew wrapper nor a
+// system Gradle install, so `gradle wrapper --gradle-version` always
+// generates a deterministic, known-good wrapper.
+const GradleWrapperVersion = "8.7"
+
+// GradleWrapperDistSHA256 is the published sha256 checksum of the
+// gradle-<GradleWrapperVersion>-bin.zip distribution (see
+// https://gradle.org/release-checksums/), verified before the downloaded
+// zip is ever extracted or executed.
+const GradleWrapperDistSHA256 = "194717442575a6f96e1c1befa2c30e9a4fd84f3b74afa7b05c3f5d2e81d14b0a"
+
+// GradleWrapperDistURL returns the download URL for the pinned Gradle
+// distribution.
+func GradleWrapperDistURL() string {
+	return fmt.Sprintf("https://services.gradle.org/distributions/gradle-%s-bin.zip", GradleWrapperVersion)
+}
+
+// GradleBootstrapCacheDir is where the pinned Gradle distribution is
+// downloaded and extracted to when bootstrapping a wrapper, so repeated
+// `nativefire configure` runs across projects don't redownload it every
+// time.
+func GradleBootstrapCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".nativefire", "gradle-bootstrap"), nil
+}
+
 // Dependency represents an external CLI tool dependency
 type Dependency struct {
 	Name        string
@@ -17,18 +57,31 @@ type Dependency struct {
 	InstallURL  string
 	InstallCmd  string
 	Description string
+
+	// VersionCmd, VersionRegex, and MinVersion let CheckDependencyVersion
+	// catch an installed-but-too-old tool that exec.LookPath alone can't
+	// detect. VersionCmd is the args passed to Command to print its
+	// version (e.g. []string{"--version"}); VersionRegex must have exactly
+	// one capture group isolating a dotted version number from that
+	// output. A dependency that leaves these unset is never version-checked.
+	VersionCmd   []string
+	VersionRegex string
+	MinVersion   string
 }
 
 // Dependencies defines all external CLI dependencies
 var Dependencies = []Dependency{
 	{
-		Name:        "Firebase CLI",
-		Command:     "firebase",
-		Required:    true,
-		Platform:    "all",
-		InstallURL:  "https://firebase.google.com/docs/cli#install_the_firebase_cli",
-		InstallCmd:  "npm install -g firebase-tools",
-		Description: "Required for Firebase project and app management",
+		Name:         "Firebase CLI",
+		Command:      "firebase",
+		Required:     true,
+		Platform:     "all",
+		InstallURL:   "https://firebase.google.com/docs/cli#install_the_firebase_cli",
+		InstallCmd:   "npm install -g firebase-tools",
+		Description:  "Required for Firebase project and app management",
+		VersionCmd:   []string{"--version"},
+		VersionRegex: `(\d+\.\d+\.\d+)`,
+		MinVersion:   "12.0.0",
 	},
 	{
 		Name:        "CocoaPods",
@@ -46,7 +99,8 @@ var Dependencies = []Dependency{
 		Platform:    "android",
 		InstallURL:  "https://gradle.org/install/",
 		InstallCmd:  "Use Android Studio or install from https://gradle.org/install/",
-		Description: "Android build system (gradlew wrapper preferred)",
+		Description: fmt.Sprintf("Android build system (gradlew wrapper preferred; nativefire bootstraps one by "+
+			"downloading Gradle %s if neither a wrapper nor a system install is found)", GradleWrapperVersion),
 	},
 }
 
@@ -74,6 +128,167 @@ func CheckAllDependencies(platform string) []Dependency {
 	return missing
 }
 
+// CheckDependencyVersion runs dep's VersionCmd and compares the parsed
+// version against MinVersion. Dependencies that don't declare
+// VersionCmd/VersionRegex/MinVersion always pass: CheckDependency's
+// exec.LookPath check is all they ask for.
+func CheckDependencyVersion(dep Dependency) error {
+	if len(dep.VersionCmd) == 0 || dep.VersionRegex == "" || dep.MinVersion == "" {
+		return nil
+	}
+
+	output, err := execCommand(dep.Command, dep.VersionCmd...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to run %s %s: %w", dep.Command, strings.Join(dep.VersionCmd, " "), err)
+	}
+
+	re, err := regexp.Compile(dep.VersionRegex)
+	if err != nil {
+		return fmt.Errorf("invalid version regex for %s: %w", dep.Name, err)
+	}
+
+	match := re.FindStringSubmatch(string(output))
+	if len(match) < 2 {
+		return fmt.Errorf("could not determine %s's version from: %s", dep.Name, strings.TrimSpace(string(output)))
+	}
+
+	if compareVersions(match[1], dep.MinVersion) < 0 {
+		return fmt.Errorf("%s %s found, but %s or newer is required: %s", dep.Name, match[1], dep.MinVersion, dep.InstallCmd)
+	}
+
+	return nil
+}
+
+// compareVersions compares two dotted numeric versions, ignoring any
+// pre-release suffix introduced by a "-" (e.g. "13.2.0-beta.1" compares
+// equal to "13.2.0"), and returning a negative number if a < b, zero if
+// equal, and a positive number if a > b.
+func compareVersions(a, b string) int {
+	as := strings.Split(stripPreRelease(a), ".")
+	bs := strings.Split(stripPreRelease(b), ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var an, bn int
+		if i < len(as) {
+			an = leadingInt(as[i])
+		}
+		if i < len(bs) {
+			bn = leadingInt(bs[i])
+		}
+		if an != bn {
+			return an - bn
+		}
+	}
+	return 0
+}
+
+// stripPreRelease truncates v at its first "-", so a pre-release version
+// like "12.0.0-beta.1" compares as "12.0.0".
+func stripPreRelease(v string) string {
+	if i := strings.Index(v, "-"); i != -1 {
+		return v[:i]
+	}
+	return v
+}
+
+// leadingInt parses the leading run of digits in s, so a component like
+// "0-beta" is treated as 0 rather than failing to parse.
+func leadingInt(s string) int {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	n, _ := strconv.Atoi(s[:end])
+	return n
+}
+
+// VersionIssue describes a dependency that's installed (exec.LookPath
+// succeeds) but doesn't meet its declared MinVersion.
+type VersionIssue struct {
+	Dependency Dependency
+	Message    string
+}
+
+// CheckDependencyVersions runs CheckDependencyVersion for every present,
+// version-checked dependency on platform, returning one VersionIssue per
+// tool that's installed but too old. It skips dependencies CheckDependency
+// already reports missing, since those are handled by CheckAllDependencies.
+func CheckDependencyVersions(platform string) []VersionIssue {
+	var issues []VersionIssue
+
+	for _, dep := range Dependencies {
+		if dep.Platform != "all" && dep.Platform != platform {
+			continue
+		}
+		if CheckDependency(dep.Command) != nil {
+			continue
+		}
+		if err := CheckDependencyVersion(dep); err != nil {
+			issues = append(issues, VersionIssue{Dependency: dep, Message: err.Error()})
+		}
+	}
+
+	return issues
+}
+
+// Installer runs a dependency's InstallCmd through a package manager, kept
+// as an interface so AutoInstall can be unit-tested against a fake
+// implementation instead of actually shelling out to npm/brew/gem/apt/choco.
+type Installer interface {
+	Install(dep Dependency) error
+}
+
+// ShellInstaller is the default Installer: it runs InstallCmd through the
+// host shell (sh -c on macOS/Linux/CI containers, cmd /C on Windows),
+// streaming output to the user the same way runCommand does elsewhere in
+// nativefire.
+type ShellInstaller struct{}
+
+func (ShellInstaller) Install(dep Dependency) error {
+	if dep.InstallCmd == "" {
+		return fmt.Errorf("%s has no install command; see %s", dep.Name, dep.InstallURL)
+	}
+
+	shell, shellFlag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, shellFlag = "cmd", "/C"
+	}
+
+	cmd := execCommand(shell, shellFlag, dep.InstallCmd)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
+
+// AutoInstall runs installer.Install for every dependency in missing,
+// skipping (with a warning) any that have no InstallCmd. Confirming with
+// the user before calling this is the caller's responsibility, so
+// AutoInstall itself never blocks on stdin beyond what a given installer's
+// InstallCmd does.
+func AutoInstall(installer Installer, missing []Dependency) error {
+	var failures []string
+
+	for _, dep := range missing {
+		if dep.InstallCmd == "" {
+			ui.WarningMsg(fmt.Sprintf("No install command for %s; see %s", dep.Name, dep.InstallURL))
+			continue
+		}
+
+		ui.InfoMsg(fmt.Sprintf("Installing %s: %s", dep.Name, dep.InstallCmd))
+		if err := installer.Install(dep); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", dep.Name, err))
+			continue
+		}
+		ui.SuccessMsg(fmt.Sprintf("Installed %s", dep.Name))
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to auto-install: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
 // CheckRequiredDependencies checks only required dependencies
 func CheckRequiredDependencies(platform string) error {
 	missing := CheckAllDependencies(platform)
@@ -134,6 +349,82 @@ func (e *MissingDependencyError) Error() string {
 	return fmt.Sprintf("required dependencies missing: %d tools", len(e.Dependencies))
 }
 
+// codesignIdentityLineRe matches one line of `security find-identity -v -p
+// codesigning` output, e.g.:
+//
+//	1) ABCDEF0123456789ABCDEF0123456789ABCDEF01 "Apple Development: Jane Doe (ABCDE12345)"
+//
+// capturing the human-readable identity name.
+var codesignIdentityLineRe = regexp.MustCompile(`\)\s+\S+\s+"([^"]+)"`)
+
+// CodesignIdentities lists the valid codesigning identities in the local
+// keychain via `security find-identity -v -p codesigning`. security is
+// macOS-only, so this returns (nil, nil) on every other OS rather than
+// failing a check that platforms without code signing don't need.
+func CodesignIdentities() ([]string, error) {
+	if runtime.GOOS != "darwin" {
+		return nil, nil
+	}
+
+	output, err := execCommand("security", "find-identity", "-v", "-p", "codesigning").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run security find-identity: %w", err)
+	}
+
+	return parseCodesignIdentities(string(output)), nil
+}
+
+// parseCodesignIdentities extracts every identity name codesignIdentityLineRe
+// matches in output, split out from CodesignIdentities so its parsing can be
+// unit-tested without depending on runtime.GOOS or a real `security` binary.
+func parseCodesignIdentities(output string) []string {
+	var identities []string
+	for _, line := range strings.Split(output, "\n") {
+		if match := codesignIdentityLineRe.FindStringSubmatch(line); match != nil {
+			identities = append(identities, match[1])
+		}
+	}
+	return identities
+}
+
+// codesignRequiredPlatforms are the Apple targets that get archived onto a
+// device or Mac rather than only the Simulator, so a missing codesigning
+// identity blocks them even though the rest of `configure` otherwise
+// succeeds. Keys are platform.Platform.Name() lowercased with spaces
+// stripped, e.g. "Mac Catalyst" -> "maccatalyst".
+var codesignRequiredPlatforms = map[string]bool{
+	"ios":         true,
+	"macos":       true,
+	"maccatalyst": true,
+}
+
+// WarnIfNoCodesignIdentity prints a hint when platformName needs code
+// signing (anything Apple except the iOS Simulator) and the local keychain
+// has no valid codesigning identity, so `configure` surfaces a likely
+// Xcode build failure before the user hits it mid-build. It's a no-op for
+// platforms that don't need signing, and on non-macOS hosts where the
+// check can't run.
+func WarnIfNoCodesignIdentity(platformName string) {
+	key := strings.ReplaceAll(strings.ToLower(platformName), " ", "")
+	if !codesignRequiredPlatforms[key] {
+		return
+	}
+
+	identities, err := CodesignIdentities()
+	if err != nil || runtime.GOOS != "darwin" {
+		return
+	}
+
+	if len(identities) == 0 {
+		ui.WarningMsg("No codesigning identity found in the keychain; Xcode will fail to build " +
+			"for a device or Mac Catalyst target until one is added (Xcode > Settings > Accounts, " +
+			"or `security find-identity -v -p codesigning`)")
+		return
+	}
+
+	ui.InfoMsg(fmt.Sprintf("Using codesigning identity: %s", identities[0]))
+}
+
 // GetPlatformFromOS returns the platform string based on the current OS
 func GetPlatformFromOS() string {
 	switch runtime.GOOS {
@@ -182,7 +473,33 @@ func PreflightCheck(platform string) error {
 
 	if len(optionalMissing) > 0 {
 		ui.WarningMsg(fmt.Sprintf("Some optional dependencies are missing (%d), but you can continue", len(optionalMissing)))
-	} else {
+	}
+
+	// A present-but-too-old tool (e.g. an ancient Firebase CLI) can fail
+	// later in a way that's hard to diagnose, so check versions even for
+	// tools exec.LookPath found.
+	versionIssues := CheckDependencyVersions(platform)
+
+	var requiredVersionIssues []VersionIssue
+	for _, issue := range versionIssues {
+		if issue.Dependency.Required {
+			requiredVersionIssues = append(requiredVersionIssues, issue)
+		} else {
+			ui.WarningMsg(issue.Message)
+		}
+	}
+
+	if len(requiredVersionIssues) > 0 {
+		ui.AnimatedError("Cannot proceed: a required dependency is below its minimum version")
+		requiredVersionMissing := make([]Dependency, len(requiredVersionIssues))
+		for i, issue := range requiredVersionIssues {
+			ui.ErrorMsg(fmt.Sprintf("❌ %s", issue.Message))
+			requiredVersionMissing[i] = issue.Dependency
+		}
+		return &MissingDependencyError{Dependencies: requiredVersionMissing}
+	}
+
+	if len(optionalMissing) == 0 && len(versionIssues) == 0 {
 		ui.AnimatedSuccess("All dependencies are available")
 	}
 
@@ -0,0 +1,217 @@
+package dependencies
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+// fakeExecCommand builds an *exec.Cmd that re-invokes this test binary as a
+// helper process instead of running command for real, the standard pattern
+// for faking exec.Command (see TestHelperProcess below).
+func fakeExecCommand(stdout string, exitCode int) func(string, ...string) *exec.Cmd {
+	return func(command string, args ...string) *exec.Cmd {
+		cs := []string{"-test.run=TestHelperProcess", "--", command}
+		cs = append(cs, args...)
+		cmd := exec.Command(os.Args[0], cs...)
+		cmd.Env = []string{
+			"GO_WANT_HELPER_PROCESS=1",
+			fmt.Sprintf("HELPER_PROCESS_STDOUT=%s", stdout),
+			fmt.Sprintf("HELPER_PROCESS_EXIT_CODE=%d", exitCode),
+		}
+		return cmd
+	}
+}
+
+// TestHelperProcess isn't a real test: it's the subprocess fakeExecCommand
+// re-execs, printing HELPER_PROCESS_STDOUT and exiting with
+// HELPER_PROCESS_EXIT_CODE. go test runs it like any other test function,
+// but it no-ops unless GO_WANT_HELPER_PROCESS is set.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	fmt.Fprint(os.Stdout, os.Getenv("HELPER_PROCESS_STDOUT"))
+	exitCode := 0
+	fmt.Sscanf(os.Getenv("HELPER_PROCESS_EXIT_CODE"), "%d", &exitCode)
+	os.Exit(exitCode)
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"12.0.0", "12.0.0", 0},
+		{"13.0.0", "12.0.0", 1},
+		{"11.9.9", "12.0.0", -1},
+		{"12.0.0-beta.1", "12.0.0", 0},
+		{"12.1", "12.0.9", 1},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); (got < 0) != (tt.want < 0) || (got > 0) != (tt.want > 0) || (got == 0) != (tt.want == 0) {
+			t.Errorf("compareVersions(%q, %q) = %d, want sign of %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCheckDependencyVersionBelowMinimum(t *testing.T) {
+	old := execCommand
+	execCommand = fakeExecCommand("firebase-tools 11.0.0\n", 0)
+	defer func() { execCommand = old }()
+
+	dep := Dependency{
+		Name:         "Firebase CLI",
+		Command:      "firebase",
+		VersionCmd:   []string{"--version"},
+		VersionRegex: `(\d+\.\d+\.\d+)`,
+		MinVersion:   "12.0.0",
+	}
+
+	err := CheckDependencyVersion(dep)
+	if err == nil {
+		t.Fatal("expected an error for a version below MinVersion, got nil")
+	}
+}
+
+func TestCheckDependencyVersionMeetsMinimum(t *testing.T) {
+	old := execCommand
+	execCommand = fakeExecCommand("firebase-tools 13.5.0\n", 0)
+	defer func() { execCommand = old }()
+
+	dep := Dependency{
+		Name:         "Firebase CLI",
+		Command:      "firebase",
+		VersionCmd:   []string{"--version"},
+		VersionRegex: `(\d+\.\d+\.\d+)`,
+		MinVersion:   "12.0.0",
+	}
+
+	if err := CheckDependencyVersion(dep); err != nil {
+		t.Errorf("expected no error for a version meeting MinVersion, got: %v", err)
+	}
+}
+
+func TestCheckDependencyVersionSkipsUnversionedDependencies(t *testing.T) {
+	dep := Dependency{Name: "CocoaPods", Command: "pod"}
+
+	if err := CheckDependencyVersion(dep); err != nil {
+		t.Errorf("expected no version check for a dependency with no VersionCmd, got: %v", err)
+	}
+}
+
+// fakeInstaller records every dependency AutoInstall asks it to install,
+// instead of shelling out to a real package manager.
+type fakeInstaller struct {
+	installed []string
+	fail      map[string]bool
+}
+
+func (f *fakeInstaller) Install(dep Dependency) error {
+	f.installed = append(f.installed, dep.Name)
+	if f.fail[dep.Name] {
+		return fmt.Errorf("simulated install failure for %s", dep.Name)
+	}
+	return nil
+}
+
+func TestAutoInstall(t *testing.T) {
+	installer := &fakeInstaller{}
+	missing := []Dependency{
+		{Name: "Firebase CLI", InstallCmd: "npm install -g firebase-tools"},
+		{Name: "CocoaPods", InstallCmd: "sudo gem install cocoapods"},
+	}
+
+	if err := AutoInstall(installer, missing); err != nil {
+		t.Fatalf("AutoInstall() error = %v", err)
+	}
+
+	if len(installer.installed) != 2 {
+		t.Errorf("expected 2 installs, got %v", installer.installed)
+	}
+}
+
+func TestAutoInstallReportsFailures(t *testing.T) {
+	installer := &fakeInstaller{fail: map[string]bool{"CocoaPods": true}}
+	missing := []Dependency{
+		{Name: "Firebase CLI", InstallCmd: "npm install -g firebase-tools"},
+		{Name: "CocoaPods", InstallCmd: "sudo gem install cocoapods"},
+	}
+
+	if err := AutoInstall(installer, missing); err == nil {
+		t.Fatal("expected AutoInstall() to return an error when an install fails")
+	}
+}
+
+func TestAutoInstallSkipsDependenciesWithNoInstallCmd(t *testing.T) {
+	installer := &fakeInstaller{}
+	missing := []Dependency{{Name: "Gradle", InstallURL: "https://gradle.org/install/"}}
+
+	if err := AutoInstall(installer, missing); err != nil {
+		t.Fatalf("AutoInstall() error = %v", err)
+	}
+	if len(installer.installed) != 0 {
+		t.Errorf("expected no installs for a dependency with no InstallCmd, got %v", installer.installed)
+	}
+}
+
+func TestShellInstallerInstallRunsInstallCmd(t *testing.T) {
+	old := execCommand
+	execCommand = fakeExecCommand("", 0)
+	defer func() { execCommand = old }()
+
+	installer := ShellInstaller{}
+	if err := installer.Install(Dependency{Name: "Firebase CLI", InstallCmd: "npm install -g firebase-tools"}); err != nil {
+		t.Errorf("Install() error = %v", err)
+	}
+}
+
+func TestShellInstallerInstallRequiresInstallCmd(t *testing.T) {
+	installer := ShellInstaller{}
+	if err := installer.Install(Dependency{Name: "Mystery Tool"}); err == nil {
+		t.Error("expected an error when Dependency has no InstallCmd")
+	}
+}
+
+func TestParseCodesignIdentitiesExtractsNames(t *testing.T) {
+	output := `Policy: X.509 Basic
+  1) ABCDEF0123456789ABCDEF0123456789ABCDEF01 "Apple Development: Jane Doe (ABCDE12345)"
+  2) FEDCBA9876543210FEDCBA9876543210FEDCBA98 "Apple Distribution: Example Inc (ZYXWV98765)"
+     2 valid identities found
+`
+	identities := parseCodesignIdentities(output)
+	if len(identities) != 2 {
+		t.Fatalf("expected 2 identities, got %d: %v", len(identities), identities)
+	}
+	if identities[0] != "Apple Development: Jane Doe (ABCDE12345)" {
+		t.Errorf("identities[0] = %q", identities[0])
+	}
+	if identities[1] != "Apple Distribution: Example Inc (ZYXWV98765)" {
+		t.Errorf("identities[1] = %q", identities[1])
+	}
+}
+
+func TestParseCodesignIdentitiesReturnsNilWhenNoneFound(t *testing.T) {
+	if identities := parseCodesignIdentities("0 valid identities found\n"); identities != nil {
+		t.Errorf("expected nil identities, got %v", identities)
+	}
+}
+
+func TestWarnIfNoCodesignIdentitySkipsSimulator(t *testing.T) {
+	old := execCommand
+	execCommand = func(string, ...string) *exec.Cmd {
+		t.Fatal("expected security not to be invoked for a platform that doesn't need signing")
+		return nil
+	}
+	defer func() { execCommand = old }()
+
+	WarnIfNoCodesignIdentity("iOS Simulator")
+}
+
+func TestWarnIfNoCodesignIdentityRecognizesMacCatalyst(t *testing.T) {
+	if !codesignRequiredPlatforms["maccatalyst"] {
+		t.Error(`expected "Mac Catalyst" (lowercased, spaces stripped) to require a codesigning identity`)
+	}
+}
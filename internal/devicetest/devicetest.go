@@ -0,0 +1,197 @@
+// Package devicetest runs the post-configure smoke test `nativefire test`
+// offers: build and run the project's test target on a booted iOS
+// simulator, a paired physical iOS device, or a connected Android device,
+// confirming the config file and initialization code `nativefire configure`
+// wrote actually build and register with Firebase.
+package devicetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// execCommand is exec.Command, indirected so tests can substitute a fake
+// subprocess, the same pattern internal/dependencies uses.
+var execCommand = exec.Command
+
+// Simulator is one entry from `xcrun simctl list -j devices`.
+type Simulator struct {
+	UDID  string
+	Name  string
+	OS    string
+	State string
+}
+
+type simctlDevice struct {
+	UDID  string `json:"udid"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+type simctlList struct {
+	Devices map[string][]simctlDevice `json:"devices"`
+}
+
+// iosRuntimePrefix is the part of a simctl runtime identifier preceding its
+// OS version, e.g. "com.apple.CoreSimulator.SimRuntime.iOS-17-4".
+const iosRuntimePrefix = ".iOS-"
+
+// ListSimulators runs `xcrun simctl list -j devices` and flattens its
+// per-runtime device map into Simulators, deriving OS from each runtime key.
+func ListSimulators() ([]Simulator, error) {
+	output, err := execCommand("xcrun", "simctl", "list", "-j", "devices").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list simulators: %w", err)
+	}
+
+	var parsed simctlList
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse simctl output: %w", err)
+	}
+
+	var sims []Simulator
+	for runtime, devices := range parsed.Devices {
+		osVersion := runtimeOSVersion(runtime)
+		if osVersion == "" {
+			continue
+		}
+		for _, d := range devices {
+			sims = append(sims, Simulator{UDID: d.UDID, Name: d.Name, OS: osVersion, State: d.State})
+		}
+	}
+	return sims, nil
+}
+
+// runtimeOSVersion extracts "17.4" out of a runtime identifier ending in
+// "...iOS-17-4", and returns "" for a non-iOS runtime (tvOS, watchOS).
+func runtimeOSVersion(runtime string) string {
+	i := strings.Index(runtime, iosRuntimePrefix)
+	if i == -1 {
+		return ""
+	}
+	return strings.ReplaceAll(runtime[i+len(iosRuntimePrefix):], "-", ".")
+}
+
+// SelectSimulator picks the simulator `nativefire test` should run against:
+// any currently Booted simulator matching the filters first (xcodebuild can
+// attach to it directly, without a cold boot), otherwise the newest
+// simulator matching osFilter and/or a deviceFilter substring match against
+// Name. osFilter/deviceFilter of "" match everything.
+func SelectSimulator(sims []Simulator, osFilter, deviceFilter string) (Simulator, error) {
+	var candidates []Simulator
+	for _, s := range sims {
+		if deviceFilter != "" && !strings.Contains(strings.ToLower(s.Name), strings.ToLower(deviceFilter)) {
+			continue
+		}
+		if osFilter != "" && s.OS != osFilter {
+			continue
+		}
+		candidates = append(candidates, s)
+	}
+	if len(candidates) == 0 {
+		return Simulator{}, fmt.Errorf("no simulator found matching the given --os/--device filters")
+	}
+
+	for _, s := range candidates {
+		if s.State == "Booted" {
+			return s, nil
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].OS > candidates[j].OS })
+	return candidates[0], nil
+}
+
+// RunIOSSimulatorTests runs scheme's test action against sim via xcodebuild,
+// streaming its output straight to this process's stdout/stderr.
+func RunIOSSimulatorTests(scheme string, sim Simulator) error {
+	destination := fmt.Sprintf("platform=iOS Simulator,id=%s", sim.UDID)
+	return runStreaming(execCommand("xcodebuild", "-scheme", scheme, "-destination", destination, "test"))
+}
+
+// RunIOSDeviceTests runs scheme's test action against a real device named or
+// identified by device, preferring `xcrun devicectl` (Xcode 15+'s device
+// bridge) and falling back to ios-deploy for older toolchains. ios-deploy
+// can't resolve a signing identity from the project the way xcodebuild
+// does, so it reads one out of band from GOIOS_DEV_ID/GOIOS_APP_ID/
+// GOIOS_TEAM_ID instead.
+func RunIOSDeviceTests(scheme, device string) error {
+	if _, err := execCommand("xcrun", "devicectl", "list", "devices").CombinedOutput(); err == nil {
+		destination := fmt.Sprintf("platform=iOS,name=%s", device)
+		return runStreaming(execCommand("xcodebuild", "-scheme", scheme, "-destination", destination, "test"))
+	}
+
+	args := []string{"--id", os.Getenv("GOIOS_DEV_ID"), "--bundle_id", os.Getenv("GOIOS_APP_ID")}
+	if teamID := os.Getenv("GOIOS_TEAM_ID"); teamID != "" {
+		args = append(args, "--team-id", teamID)
+	}
+	args = append(args, "--test", "--justlaunch")
+	return runStreaming(execCommand("ios-deploy", args...))
+}
+
+// RunAndroidTests runs Gradle's connectedAndroidTest task against deviceID
+// (or every attached device if deviceID is empty), after confirming via
+// `adb devices` that at least one device is actually connected.
+func RunAndroidTests(deviceID string) error {
+	devices, err := listAndroidDevices()
+	if err != nil {
+		return err
+	}
+	if len(devices) == 0 {
+		return fmt.Errorf("no connected Android devices found; connect a device or start an emulator first")
+	}
+	if deviceID != "" && !containsString(devices, deviceID) {
+		return fmt.Errorf("device %q not found among connected devices: %s", deviceID, strings.Join(devices, ", "))
+	}
+
+	if _, err := os.Stat("./gradlew"); err != nil {
+		return fmt.Errorf("gradlew not found in the current directory: %w", err)
+	}
+
+	args := []string{"connectedAndroidTest"}
+	if deviceID != "" {
+		args = append(args, "-PANDROID_SERIAL="+deviceID)
+	}
+	return runStreaming(execCommand("./gradlew", args...))
+}
+
+// listAndroidDevices parses `adb devices`, which prints a header line
+// followed by one "<serial>\tdevice" line per attached device/emulator.
+func listAndroidDevices() ([]string, error) {
+	output, err := execCommand("adb", "devices").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Android devices: %w", err)
+	}
+
+	var devices []string
+	lines := strings.Split(string(output), "\n")
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == "device" {
+			devices = append(devices, fields[0])
+		}
+	}
+	return devices, nil
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
+
+// runStreaming runs cmd with its output connected to this process's own
+// stdout/stderr, so xcodebuild/gradlew/ios-deploy's own progress output
+// reaches the terminal live instead of being buffered and replayed.
+func runStreaming(cmd *exec.Cmd) error {
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,145 @@
+package devicetest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+// fakeExecCommand builds an *exec.Cmd that re-invokes this test binary as a
+// helper process instead of running command for real - the same pattern
+// internal/dependencies uses for execCommand.
+func fakeExecCommand(stdout string, exitCode int) func(string, ...string) *exec.Cmd {
+	return func(command string, args ...string) *exec.Cmd {
+		cs := []string{"-test.run=TestHelperProcess", "--", command}
+		cs = append(cs, args...)
+		cmd := exec.Command(os.Args[0], cs...)
+		cmd.Env = []string{
+			"GO_WANT_HELPER_PROCESS=1",
+			fmt.Sprintf("HELPER_PROCESS_STDOUT=%s", stdout),
+			fmt.Sprintf("HELPER_PROCESS_EXIT_CODE=%d", exitCode),
+		}
+		return cmd
+	}
+}
+
+// TestHelperProcess isn't a real test: it's the subprocess fakeExecCommand
+// re-execs, printing HELPER_PROCESS_STDOUT and exiting with
+// HELPER_PROCESS_EXIT_CODE.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	fmt.Fprint(os.Stdout, os.Getenv("HELPER_PROCESS_STDOUT"))
+	exitCode := 0
+	fmt.Sscanf(os.Getenv("HELPER_PROCESS_EXIT_CODE"), "%d", &exitCode)
+	os.Exit(exitCode)
+}
+
+const sampleSimctlOutput = `{
+  "devices": {
+    "com.apple.CoreSimulator.SimRuntime.iOS-17-4": [
+      {"udid": "AAAA-1111", "name": "iPhone 15", "state": "Shutdown"},
+      {"udid": "BBBB-2222", "name": "iPhone 15 Pro", "state": "Booted"}
+    ],
+    "com.apple.CoreSimulator.SimRuntime.iOS-16-4": [
+      {"udid": "CCCC-3333", "name": "iPhone 14", "state": "Shutdown"}
+    ],
+    "com.apple.CoreSimulator.SimRuntime.tvOS-17-4": [
+      {"udid": "DDDD-4444", "name": "Apple TV", "state": "Shutdown"}
+    ]
+  }
+}
+`
+
+func TestListSimulatorsSkipsNonIOSRuntimes(t *testing.T) {
+	old := execCommand
+	execCommand = fakeExecCommand(sampleSimctlOutput, 0)
+	defer func() { execCommand = old }()
+
+	sims, err := ListSimulators()
+	if err != nil {
+		t.Fatalf("ListSimulators() error = %v", err)
+	}
+	if len(sims) != 3 {
+		t.Fatalf("expected 3 iOS simulators (tvOS excluded), got %d: %+v", len(sims), sims)
+	}
+}
+
+func TestSelectSimulatorPrefersBooted(t *testing.T) {
+	old := execCommand
+	execCommand = fakeExecCommand(sampleSimctlOutput, 0)
+	defer func() { execCommand = old }()
+
+	sims, err := ListSimulators()
+	if err != nil {
+		t.Fatalf("ListSimulators() error = %v", err)
+	}
+
+	selected, err := SelectSimulator(sims, "", "")
+	if err != nil {
+		t.Fatalf("SelectSimulator() error = %v", err)
+	}
+	if selected.UDID != "BBBB-2222" {
+		t.Errorf("expected the Booted simulator BBBB-2222, got %+v", selected)
+	}
+}
+
+func TestSelectSimulatorFallsBackToNewestMatchingOS(t *testing.T) {
+	sims := []Simulator{
+		{UDID: "AAAA-1111", Name: "iPhone 15", OS: "17.4", State: "Shutdown"},
+		{UDID: "CCCC-3333", Name: "iPhone 14", OS: "16.4", State: "Shutdown"},
+	}
+
+	selected, err := SelectSimulator(sims, "", "")
+	if err != nil {
+		t.Fatalf("SelectSimulator() error = %v", err)
+	}
+	if selected.UDID != "AAAA-1111" {
+		t.Errorf("expected the newest simulator AAAA-1111 (OS 17.4), got %+v", selected)
+	}
+}
+
+func TestSelectSimulatorAppliesDeviceFilter(t *testing.T) {
+	sims := []Simulator{
+		{UDID: "AAAA-1111", Name: "iPhone 15", OS: "17.4", State: "Shutdown"},
+		{UDID: "CCCC-3333", Name: "iPad Pro", OS: "17.4", State: "Shutdown"},
+	}
+
+	selected, err := SelectSimulator(sims, "", "ipad")
+	if err != nil {
+		t.Fatalf("SelectSimulator() error = %v", err)
+	}
+	if selected.UDID != "CCCC-3333" {
+		t.Errorf("expected the iPad simulator CCCC-3333, got %+v", selected)
+	}
+}
+
+func TestSelectSimulatorErrorsWhenNothingMatches(t *testing.T) {
+	sims := []Simulator{{UDID: "AAAA-1111", Name: "iPhone 15", OS: "17.4", State: "Shutdown"}}
+
+	if _, err := SelectSimulator(sims, "99.0", ""); err == nil {
+		t.Fatal("expected an error when no simulator matches --os")
+	}
+}
+
+func TestRunAndroidTestsRequiresConnectedDevice(t *testing.T) {
+	old := execCommand
+	execCommand = fakeExecCommand("List of devices attached\n", 0)
+	defer func() { execCommand = old }()
+
+	if err := RunAndroidTests(""); err == nil {
+		t.Fatal("expected an error when adb devices reports none connected")
+	}
+}
+
+func TestRunAndroidTestsRejectsUnknownDeviceID(t *testing.T) {
+	old := execCommand
+	execCommand = fakeExecCommand("List of devices attached\nemulator-5554\tdevice\n", 0)
+	defer func() { execCommand = old }()
+
+	if err := RunAndroidTests("emulator-9999"); err == nil {
+		t.Fatal("expected an error for a --device not present in adb devices")
+	}
+}
@@ -0,0 +1,270 @@
+// Package doctor runs a battery of environment checks - CLI tools, config
+// files, and detected platforms - and reports which ones pass, so `nativefire
+// configure` failures can be diagnosed without re-running the whole flow.
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/clix-so/nativefire/internal/apple"
+	"github.com/clix-so/nativefire/internal/dependencies"
+	"github.com/clix-so/nativefire/internal/platform"
+	"github.com/clix-so/nativefire/internal/projectscan"
+)
+
+// execCommand is exec.Command, indirected so tests can substitute a fake
+// process instead of actually shelling out to firebase/xcodebuild/swift.
+var execCommand = exec.Command
+
+// Check is the result of one doctor diagnostic. Critical checks make Run's
+// caller (see cmd/doctor.go) exit non-zero; non-critical ones are only
+// surfaced in the report.
+type Check struct {
+	Name     string
+	OK       bool
+	Critical bool
+	Hint     string
+}
+
+func passed(name string) Check {
+	return Check{Name: name, OK: true}
+}
+
+func failed(name string, critical bool, hint string) Check {
+	return Check{Name: name, OK: false, Critical: critical, Hint: hint}
+}
+
+// Run executes every applicable check for dir (the project root to inspect)
+// and returns them in a fixed, stable order: toolchain checks first, then
+// config-file checks, then detected platforms.
+func Run(dir string) []Check {
+	platforms, err := platform.DetectPlatforms(dir)
+	if err != nil {
+		platforms = nil
+	}
+
+	var checks []Check
+	checks = append(checks, checkFirebaseCLI())
+
+	isIOS, isAndroid := false, false
+	for _, p := range platforms {
+		switch p.(type) {
+		case *platform.IOSPlatform:
+			isIOS = true
+		case *platform.AndroidPlatform:
+			isAndroid = true
+		}
+	}
+
+	if isIOS {
+		checks = append(checks, checkXcode(), checkSwiftToolchain(), checkCocoaPods(dir), checkGoogleServiceInfoPlist(dir))
+	}
+	if isAndroid {
+		checks = append(checks, checkAndroidConfig(dir))
+	}
+
+	checks = append(checks, checkDetectedPlatforms(platforms, err))
+
+	return checks
+}
+
+// AnyCritical reports whether any check in checks is a failed critical one,
+// so a caller can decide to exit non-zero.
+func AnyCritical(checks []Check) bool {
+	for _, c := range checks {
+		if !c.OK && c.Critical {
+			return true
+		}
+	}
+	return false
+}
+
+// checkFirebaseCLI confirms the firebase CLI is installed and that the user
+// is logged in (firebase login:list fails with no authenticated accounts).
+func checkFirebaseCLI() Check {
+	const name = "Firebase CLI"
+
+	if err := dependencies.CheckDependency("firebase"); err != nil {
+		return failed(name, true, "install the Firebase CLI: npm install -g firebase-tools")
+	}
+
+	output, err := execCommand("firebase", "login:list").CombinedOutput()
+	if err != nil {
+		return failed(name, true, fmt.Sprintf("firebase login:list failed, run `firebase login`: %s", string(output)))
+	}
+
+	return passed(name)
+}
+
+// checkXcode confirms Xcode's command line tools are installed and usable.
+func checkXcode() Check {
+	const name = "Xcode"
+
+	if _, err := execCommand("xcodebuild", "-version").CombinedOutput(); err != nil {
+		return failed(name, true, "install Xcode and its command line tools, then run `xcode-select --install`")
+	}
+
+	return passed(name)
+}
+
+// checkSwiftToolchain confirms a Swift compiler is on PATH, needed for any
+// project that uses Swift Package Manager or has Swift source files.
+func checkSwiftToolchain() Check {
+	const name = "Swift toolchain"
+
+	if _, err := execCommand("swift", "--version").CombinedOutput(); err != nil {
+		return failed(name, false, "install Xcode's command line tools to get the swift compiler")
+	}
+
+	return passed(name)
+}
+
+// checkCocoaPods confirms CocoaPods is installed and, if a Podfile exists
+// under dir, that it's non-empty and declares at least one target.
+func checkCocoaPods(dir string) Check {
+	const name = "CocoaPods"
+
+	podfile := findExisting(dir, []string{"Podfile", "ios/Podfile"})
+	if podfile == "" {
+		return passed(name) // no Podfile - project uses SPM or hasn't set up pods
+	}
+
+	if err := dependencies.CheckDependency("pod"); err != nil {
+		return failed(name, false, "install CocoaPods: sudo gem install cocoapods")
+	}
+
+	content, err := os.ReadFile(podfile)
+	if err != nil || len(content) == 0 {
+		return failed(name, false, fmt.Sprintf("%s is missing or empty", podfile))
+	}
+	if !strings.Contains(string(content), "target ") && !strings.Contains(string(content), "target(") {
+		return failed(name, false, fmt.Sprintf("%s doesn't declare a target block", podfile))
+	}
+
+	return passed(name)
+}
+
+// checkGoogleServiceInfoPlist confirms GoogleService-Info.plist exists, is
+// a valid plist, and its BUNDLE_ID matches the Xcode project's own
+// PRODUCT_BUNDLE_IDENTIFIER.
+func checkGoogleServiceInfoPlist(dir string) Check {
+	const name = "GoogleService-Info.plist"
+
+	plistPath := findExisting(dir, []string{"GoogleService-Info.plist", "ios/GoogleService-Info.plist"})
+	if plistPath == "" {
+		return failed(name, true, "run `nativefire configure` to download GoogleService-Info.plist")
+	}
+
+	plist, err := apple.DecodePlist(plistPath)
+	if err != nil {
+		return failed(name, true, fmt.Sprintf("%s is not a valid plist: %v", plistPath, err))
+	}
+
+	configuredID, _ := plist["BUNDLE_ID"].(string)
+	if configuredID == "" {
+		return failed(name, true, fmt.Sprintf("%s has no BUNDLE_ID key", plistPath))
+	}
+
+	iosProject, err := projectscan.ScanIOS(dir)
+	if err != nil {
+		// No Xcode project to compare against - the plist itself is valid.
+		return passed(name)
+	}
+
+	if iosProject.BundleID != configuredID {
+		return failed(name, true, fmt.Sprintf(
+			"%s's BUNDLE_ID (%s) doesn't match the Xcode project's PRODUCT_BUNDLE_IDENTIFIER (%s)",
+			plistPath, configuredID, iosProject.BundleID))
+	}
+
+	return passed(name)
+}
+
+// googleServicesJSON is the handful of google-services.json fields doctor
+// needs to cross-check against build.gradle - not a full schema.
+type googleServicesJSON struct {
+	Client []struct {
+		ClientInfo struct {
+			AndroidClientInfo struct {
+				PackageName string `json:"package_name"`
+			} `json:"android_client_info"`
+		} `json:"client_info"`
+	} `json:"client"`
+}
+
+// checkAndroidConfig confirms google-services.json exists, parses as JSON,
+// and its package_name matches the app module's applicationId.
+func checkAndroidConfig(dir string) Check {
+	const name = "google-services.json"
+
+	configPath := findExisting(dir, []string{
+		"google-services.json", "app/google-services.json", "android/app/google-services.json",
+	})
+	if configPath == "" {
+		return failed(name, true, "run `nativefire configure` to download google-services.json")
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return failed(name, true, fmt.Sprintf("failed to read %s: %v", configPath, err))
+	}
+
+	var config googleServicesJSON
+	if err := json.Unmarshal(data, &config); err != nil {
+		return failed(name, true, fmt.Sprintf("%s is not valid JSON: %v", configPath, err))
+	}
+	if len(config.Client) == 0 || config.Client[0].ClientInfo.AndroidClientInfo.PackageName == "" {
+		return failed(name, true, fmt.Sprintf("%s has no client[].client_info.android_client_info.package_name", configPath))
+	}
+	configuredPackage := config.Client[0].ClientInfo.AndroidClientInfo.PackageName
+
+	androidProject, err := projectscan.ScanAndroid(dir)
+	if err != nil {
+		// No build.gradle/AndroidManifest.xml to compare against.
+		return passed(name)
+	}
+
+	if androidProject.ApplicationID != "" && androidProject.ApplicationID != configuredPackage {
+		return failed(name, true, fmt.Sprintf(
+			"%s's package_name (%s) doesn't match the Gradle module's applicationId (%s)",
+			configPath, configuredPackage, androidProject.ApplicationID))
+	}
+
+	return passed(name)
+}
+
+// checkDetectedPlatforms is informational: it is never critical. It passes
+// reporting which platforms nativefire found, so a doctor run can confirm
+// it's even looking at the project the user expects, and fails
+// (non-critically) when no platform is detected.
+func checkDetectedPlatforms(platforms []platform.Platform, detectErr error) Check {
+	const name = "Detected platforms"
+
+	if detectErr != nil || len(platforms) == 0 {
+		return failed(name, false, "no supported platform detected in this directory")
+	}
+
+	names := make([]string, len(platforms))
+	for i, p := range platforms {
+		names[i] = p.Name()
+	}
+
+	check := passed(name)
+	check.Hint = fmt.Sprintf("found: %v", names)
+	return check
+}
+
+func findExisting(dir string, candidates []string) string {
+	for _, candidate := range candidates {
+		path := filepath.Join(dir, candidate)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
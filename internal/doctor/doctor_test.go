@@ -0,0 +1,131 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// fakeExecCommand builds an *exec.Cmd that re-invokes this test binary as a
+// helper process instead of running command for real - the same pattern
+// internal/devicetest and internal/dependencies use for execCommand.
+func fakeExecCommand(stdout string, exitCode int) func(string, ...string) *exec.Cmd {
+	return func(command string, args ...string) *exec.Cmd {
+		cs := []string{"-test.run=TestHelperProcess", "--", command}
+		cs = append(cs, args...)
+		cmd := exec.Command(os.Args[0], cs...)
+		cmd.Env = []string{
+			"GO_WANT_HELPER_PROCESS=1",
+			fmt.Sprintf("HELPER_PROCESS_STDOUT=%s", stdout),
+			fmt.Sprintf("HELPER_PROCESS_EXIT_CODE=%d", exitCode),
+		}
+		return cmd
+	}
+}
+
+// TestHelperProcess isn't a real test: it's the subprocess fakeExecCommand
+// re-execs, printing HELPER_PROCESS_STDOUT and exiting with
+// HELPER_PROCESS_EXIT_CODE.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	fmt.Fprint(os.Stdout, os.Getenv("HELPER_PROCESS_STDOUT"))
+	exitCode := 0
+	fmt.Sscanf(os.Getenv("HELPER_PROCESS_EXIT_CODE"), "%d", &exitCode)
+	os.Exit(exitCode)
+}
+
+// TestCheckFirebaseCLIFailsWithoutLoginOrInstall covers both ways
+// checkFirebaseCLI can fail in this environment: dependencies.CheckDependency
+// rejecting a missing `firebase` binary (not stubbable, since it always
+// calls the real exec.LookPath), or - if firebase happens to be installed -
+// the stubbed login:list failing. Either way the check must report a
+// critical failure.
+func TestCheckFirebaseCLIFailsWithoutLoginOrInstall(t *testing.T) {
+	old := execCommand
+	execCommand = fakeExecCommand("Error: not authenticated", 1)
+	defer func() { execCommand = old }()
+
+	check := checkFirebaseCLI()
+	if check.OK {
+		t.Fatal("expected checkFirebaseCLI to fail without Firebase CLI installed and logged in")
+	}
+	if !check.Critical {
+		t.Error("expected checkFirebaseCLI to be critical")
+	}
+}
+
+func TestCheckAndroidConfigDetectsPackageNameMismatch(t *testing.T) {
+	dir := t.TempDir()
+
+	gradle := "android {\n    defaultConfig {\n        applicationId \"com.example.real\"\n    }\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "build.gradle"), []byte(gradle), 0o644); err != nil {
+		t.Fatalf("failed to write build.gradle: %v", err)
+	}
+
+	config := `{"client":[{"client_info":{"android_client_info":{"package_name":"com.example.other"}}}]}`
+	if err := os.WriteFile(filepath.Join(dir, "google-services.json"), []byte(config), 0o644); err != nil {
+		t.Fatalf("failed to write google-services.json: %v", err)
+	}
+
+	check := checkAndroidConfig(dir)
+	if check.OK {
+		t.Fatal("expected checkAndroidConfig to fail on a package name mismatch")
+	}
+	if !check.Critical {
+		t.Error("expected checkAndroidConfig to be critical")
+	}
+}
+
+func TestCheckAndroidConfigPassesOnMatch(t *testing.T) {
+	dir := t.TempDir()
+
+	gradle := "android {\n    defaultConfig {\n        applicationId \"com.example.real\"\n    }\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "build.gradle"), []byte(gradle), 0o644); err != nil {
+		t.Fatalf("failed to write build.gradle: %v", err)
+	}
+
+	config := `{"client":[{"client_info":{"android_client_info":{"package_name":"com.example.real"}}}]}`
+	if err := os.WriteFile(filepath.Join(dir, "google-services.json"), []byte(config), 0o644); err != nil {
+		t.Fatalf("failed to write google-services.json: %v", err)
+	}
+
+	check := checkAndroidConfig(dir)
+	if !check.OK {
+		t.Errorf("expected checkAndroidConfig to pass, got hint: %s", check.Hint)
+	}
+}
+
+func TestCheckAndroidConfigFailsWhenConfigMissing(t *testing.T) {
+	dir := t.TempDir()
+
+	check := checkAndroidConfig(dir)
+	if check.OK {
+		t.Fatal("expected checkAndroidConfig to fail when google-services.json is missing")
+	}
+}
+
+func TestCheckCocoaPodsPassesWithoutPodfile(t *testing.T) {
+	check := checkCocoaPods(t.TempDir())
+	if !check.OK {
+		t.Errorf("expected checkCocoaPods to pass when there's no Podfile, got hint: %s", check.Hint)
+	}
+}
+
+func TestAnyCriticalDetectsFailedCriticalCheck(t *testing.T) {
+	checks := []Check{
+		{Name: "a", OK: true},
+		{Name: "b", OK: false, Critical: false},
+	}
+	if AnyCritical(checks) {
+		t.Fatal("expected AnyCritical to be false with no failed critical checks")
+	}
+
+	checks = append(checks, Check{Name: "c", OK: false, Critical: true})
+	if !AnyCritical(checks) {
+		t.Fatal("expected AnyCritical to be true once a failed critical check is present")
+	}
+}
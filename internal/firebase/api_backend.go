@@ -0,0 +1,553 @@
+package firebase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/clix-so/nativefire/internal/logger"
+	"github.com/clix-so/nativefire/internal/ui"
+)
+
+const firebaseManagementAPI = "https://firebase.googleapis.com/v1beta1"
+
+// firebaseManagementScope is the OAuth scope the Firebase Management API
+// accepts for project/app management calls.
+const firebaseManagementScope = "https://www.googleapis.com/auth/firebase"
+
+// APIBackend talks to the Firebase Management API directly over HTTPS,
+// authenticated with Application Default Credentials, instead of shelling
+// out to the `firebase` CLI. This lets RegisterApp/DownloadConfig/
+// ListProjects/ListApps run in CI environments that have a service account
+// but no interactive `firebase login` session.
+type APIBackend struct {
+	verbose    bool
+	log        *logger.Logger
+	httpClient *http.Client
+}
+
+// newAPIBackend builds an APIBackend from, in order: Application Default
+// Credentials (GOOGLE_APPLICATION_CREDENTIALS service account JSON, then
+// gcloud's own `application-default login` cache, then the environment's
+// attached service account), falling back to an interactive device-code
+// OAuth flow (see deviceCodeCredentials) when none of those are available.
+// It returns an error rather than a partially-usable backend when every
+// source fails, so callers can fall back to the CLI backend instead.
+func newAPIBackend(verbose bool, log *logger.Logger) (*APIBackend, error) {
+	ctx := context.Background()
+
+	creds, err := google.FindDefaultCredentials(ctx, firebaseManagementScope)
+	if err != nil {
+		if verbose {
+			ui.InfoMsg("No Application Default Credentials found, falling back to interactive sign-in")
+		}
+		creds, err = deviceCodeCredentials(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("no Application Default Credentials found and interactive sign-in failed: %w", err)
+		}
+	}
+
+	return &APIBackend{
+		verbose:    verbose,
+		log:        log.With(logger.F("backend", "api")),
+		httpClient: oauth2HTTPClient(ctx, creds),
+	}, nil
+}
+
+// defaultOAuthClientID identifies nativefire itself to Google's OAuth
+// servers for the device-code flow below, the same way gcloud and other
+// Google command-line tools embed an installed-app client ID - it names the
+// application, not the user, so it's safe to ship in source. Set
+// NATIVEFIRE_OAUTH_CLIENT_ID/_SECRET to use a different OAuth client (e.g.
+// one registered for a fork).
+const defaultOAuthClientID = "nativefire-cli.apps.googleusercontent.com"
+
+func deviceOAuthConfig() oauth2.Config {
+	return oauth2.Config{
+		ClientID:     firstNonEmpty(os.Getenv("NATIVEFIRE_OAUTH_CLIENT_ID"), defaultOAuthClientID),
+		ClientSecret: os.Getenv("NATIVEFIRE_OAUTH_CLIENT_SECRET"),
+		Endpoint:     google.Endpoint,
+		Scopes:       []string{firebaseManagementScope},
+	}
+}
+
+// deviceCodeCredentials runs RFC 8628's device authorization flow: it prints
+// a verification URL and short code for the user to approve in a browser on
+// any device, then polls the token endpoint until they do. It's the
+// fallback newAPIBackend reaches for when no GOOGLE_APPLICATION_CREDENTIALS
+// service account and no cached `gcloud auth application-default login`
+// credentials are available, so a developer with neither can still use the
+// API backend.
+func deviceCodeCredentials(ctx context.Context) (*google.Credentials, error) {
+	config := deviceOAuthConfig()
+
+	response, err := config.DeviceAuth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start device authorization: %w", err)
+	}
+
+	ui.InfoMsg(fmt.Sprintf("To sign in, visit %s and enter code %s", response.VerificationURI, response.UserCode))
+
+	token, err := config.DeviceAccessToken(ctx, response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete device authorization: %w", err)
+	}
+
+	return &google.Credentials{TokenSource: config.TokenSource(ctx, token)}, nil
+}
+
+func oauth2HTTPClient(ctx context.Context, creds *google.Credentials) *http.Client {
+	return &http.Client{
+		Transport: &oauth2Transport{base: http.DefaultTransport, source: creds.TokenSource},
+		Timeout:   30 * time.Second,
+	}
+}
+
+// oauth2Transport attaches a bearer token from an oauth2.TokenSource to
+// every outgoing request, so APIBackend's call sites read like plain HTTP
+// calls instead of threading a token through each of them.
+type oauth2Transport struct {
+	base   http.RoundTripper
+	source oauth2.TokenSource
+}
+
+func (t *oauth2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh access token: %w", err)
+	}
+	reqCopy := req.Clone(req.Context())
+	reqCopy.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
+	return t.base.RoundTrip(reqCopy)
+}
+
+func (b *APIBackend) do(ctx context.Context, method, url string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		b.log.Error("firebase API request failed", logger.F("url", url), logger.F("error", err.Error()))
+		return nil, fmt.Errorf("request to %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", url, err)
+	}
+
+	fields := []logger.Field{
+		logger.F("url", url),
+		logger.F("method", method),
+		logger.F("status", resp.StatusCode),
+		logger.F("duration_ms", time.Since(start).Milliseconds()),
+	}
+	if resp.StatusCode >= 400 {
+		b.log.Error("firebase API request failed", append(fields, logger.F("body", string(respBody)))...)
+		return nil, fmt.Errorf("firebase API returned %s: %s", resp.Status, string(respBody))
+	}
+	b.log.Debug("firebase API request succeeded", fields...)
+
+	return respBody, nil
+}
+
+func (b *APIBackend) ListProjects() ([]Project, error) {
+	body, err := b.do(context.Background(), http.MethodGet, firebaseManagementAPI+"/projects", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Firebase projects: %w", err)
+	}
+
+	var parsed struct {
+		Results []struct {
+			ProjectID     string         `json:"projectId"`
+			ProjectNumber string         `json:"projectNumber"`
+			DisplayName   string         `json:"displayName"`
+			Name          string         `json:"name"`
+			Resources     map[string]any `json:"resources"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse projects response: %w", err)
+	}
+
+	projects := make([]Project, 0, len(parsed.Results))
+	for _, p := range parsed.Results {
+		projects = append(projects, Project{
+			ProjectID:     p.ProjectID,
+			ProjectNumber: p.ProjectNumber,
+			DisplayName:   p.DisplayName,
+			Name:          p.Name,
+			Resources:     p.Resources,
+			State:         activeState, // the Management API only lists projects you can access, which are active
+		})
+	}
+
+	return projects, nil
+}
+
+// appsListEndpoints maps a Firebase app platform onto its Management API
+// collection name, e.g. https://.../projects/{p}/androidApps.
+var appsListEndpoints = map[string]string{
+	androidPlatform: "androidApps",
+	iosPlatform:     "iosApps",
+	"web":           "webApps",
+}
+
+func (b *APIBackend) ListApps(projectID string) ([]App, error) {
+	var apps []App
+
+	for platform, collection := range appsListEndpoints {
+		url := fmt.Sprintf("%s/projects/%s/%s", firebaseManagementAPI, projectID, collection)
+		body, err := b.do(context.Background(), http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s apps: %w", platform, err)
+		}
+
+		var parsed struct {
+			Apps []struct {
+				AppID       string `json:"appId"`
+				DisplayName string `json:"displayName"`
+				ProjectID   string `json:"projectId"`
+				PackageName string `json:"packageName"`
+				BundleID    string `json:"bundleId"`
+			} `json:"apps"`
+		}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return nil, fmt.Errorf("failed to parse %s apps response: %w", platform, err)
+		}
+
+		for _, a := range parsed.Apps {
+			apps = append(apps, App{
+				AppID:       a.AppID,
+				DisplayName: a.DisplayName,
+				ProjectID:   a.ProjectID,
+				Platform:    platform,
+				BundleID:    a.BundleID,
+				PackageName: a.PackageName,
+				Namespace:   firstNonEmpty(a.BundleID, a.PackageName),
+			})
+		}
+	}
+
+	return apps, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func (b *APIBackend) RegisterApp(config *Config) error {
+	if config.AppID != "" {
+		if b.verbose {
+			ui.InfoMsg(fmt.Sprintf("Using existing app ID: %s", config.AppID))
+		}
+		return nil
+	}
+
+	existingApp, err := b.findExistingApp(config)
+	if err != nil {
+		if b.verbose {
+			ui.WarningMsg(fmt.Sprintf("Could not check for existing apps: %v", err))
+		}
+	} else if existingApp != nil {
+		config.AppID = existingApp.AppID
+		ui.SuccessMsg(fmt.Sprintf("Using existing %s app: %s (%s)",
+			existingApp.Platform, existingApp.DisplayName, existingApp.AppID))
+		return nil
+	}
+
+	platformName := normalizePlatformName(config.Platform.Name())
+	collection, ok := appsListEndpoints[b.getPlatformFlag(platformName)]
+	if !ok {
+		return fmt.Errorf("platform %s does not support automatic app registration", platformName)
+	}
+
+	payload := map[string]string{"displayName": b.generateAppName(platformName)}
+	switch b.getPlatformFlag(platformName) {
+	case androidPlatform:
+		packageName := config.PackageName
+		if packageName == "" {
+			packageName = detectAndroidPackageName()
+		}
+		if packageName == "" {
+			packageName = generateDefaultPackageName(config.ProjectID)
+		}
+		payload["packageName"] = packageName
+	case iosPlatform:
+		bundleID := config.BundleID
+		if bundleID == "" {
+			bundleID = detectIOSBundleID()
+		}
+		if bundleID == "" {
+			bundleID = generateDefaultBundleID(config.ProjectID)
+		}
+		payload["bundleId"] = bundleID
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to build app registration request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/projects/%s/%s", firebaseManagementAPI, config.ProjectID, collection)
+	respBody, err := b.do(context.Background(), http.MethodPost, url, strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf("failed to create Firebase app: %w", err)
+	}
+
+	appID, err := b.resolveCreatedAppID(context.Background(), respBody)
+	if err != nil {
+		return fmt.Errorf("failed to create Firebase app: %w", err)
+	}
+
+	config.AppID = appID
+	if b.verbose {
+		ui.SuccessMsg(fmt.Sprintf("Created Firebase app with ID: %s", appID))
+	}
+
+	return nil
+}
+
+// resolveCreatedAppID extracts the app ID from an apps:create response. The
+// Management API sometimes returns the created app directly (appId set) and
+// sometimes returns a long-running Operation (name like
+// "operations/..." and done=false) that must be polled until it completes.
+func (b *APIBackend) resolveCreatedAppID(ctx context.Context, respBody []byte) (string, error) {
+	var created struct {
+		AppID string `json:"appId"`
+		Name  string `json:"name"`
+	}
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return "", fmt.Errorf("failed to parse Firebase API response: %w", err)
+	}
+
+	if created.AppID != "" {
+		return created.AppID, nil
+	}
+
+	if !strings.HasPrefix(created.Name, "operations/") {
+		return "", fmt.Errorf("failed to extract app ID from Firebase API response")
+	}
+
+	if b.verbose {
+		ui.InfoMsg(fmt.Sprintf("Waiting for Firebase app creation to complete: %s", created.Name))
+	}
+
+	opResponse, err := b.pollOperation(ctx, created.Name)
+	if err != nil {
+		return "", err
+	}
+
+	var app struct {
+		AppID string `json:"appId"`
+	}
+	if err := json.Unmarshal(opResponse, &app); err != nil || app.AppID == "" {
+		return "", fmt.Errorf("failed to extract app ID from completed operation %s", created.Name)
+	}
+
+	return app.AppID, nil
+}
+
+// operationPollInterval/operationPollTimeout bound pollOperation: frequent
+// enough that app creation (usually a few seconds) doesn't feel stalled,
+// bounded so a wedged operation doesn't hang RegisterApp forever.
+const (
+	operationPollInterval = 2 * time.Second
+	operationPollTimeout  = 2 * time.Minute
+)
+
+// pollOperation polls a google.longrunning.Operation resource (returned by
+// apps:create) until it reports done, returning its response payload, or an
+// error if the operation itself failed or operationPollTimeout elapses.
+func (b *APIBackend) pollOperation(ctx context.Context, name string) ([]byte, error) {
+	deadline := time.Now().Add(operationPollTimeout)
+	url := fmt.Sprintf("%s/%s", firebaseManagementAPI, name)
+
+	for {
+		body, err := b.do(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to poll operation %s: %w", name, err)
+		}
+
+		var op struct {
+			Done     bool            `json:"done"`
+			Response json.RawMessage `json:"response"`
+			Error    *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(body, &op); err != nil {
+			return nil, fmt.Errorf("failed to parse operation %s: %w", name, err)
+		}
+		if op.Error != nil {
+			return nil, fmt.Errorf("operation %s failed: %s", name, op.Error.Message)
+		}
+		if op.Done {
+			return op.Response, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for operation %s to complete", name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(operationPollInterval):
+		}
+	}
+}
+
+// DeleteApp removes an app via the Management API's :remove action, used
+// to undo a RegisterApp step when a later configure step fails. The
+// Management API soft-deletes apps (they can be restored from the
+// Firebase console for a time), so this is safer to call automatically
+// than a hard delete would be.
+func (b *APIBackend) DeleteApp(projectID, platformName, appID string) error {
+	collection, ok := appsListEndpoints[b.getPlatformFlag(platformName)]
+	if !ok {
+		return fmt.Errorf("platform %s does not support app removal", platformName)
+	}
+
+	url := fmt.Sprintf("%s/projects/%s/%s/%s:remove", firebaseManagementAPI, projectID, collection, appID)
+	_, err := b.do(context.Background(), http.MethodPost, url, strings.NewReader("{}"))
+	return err
+}
+
+func (b *APIBackend) getPlatformFlag(platformName string) string {
+	switch normalizePlatformName(platformName) {
+	case androidPlatform:
+		return androidPlatform
+	case iosPlatform, macosPlatform:
+		return iosPlatform
+	case "web":
+		return "web"
+	default:
+		return androidPlatform
+	}
+}
+
+func (b *APIBackend) generateAppName(platformName string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Sprintf("My %s App", platformName)
+	}
+	return fmt.Sprintf("%s %s", filepath.Base(cwd), platformName)
+}
+
+func (b *APIBackend) findExistingApp(config *Config) (*App, error) {
+	apps, err := b.ListApps(config.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list apps: %w", err)
+	}
+
+	platformName := normalizePlatformName(config.Platform.Name())
+	expectedIdentifier := config.BundleID
+	if platformName == androidPlatform {
+		expectedIdentifier = config.PackageName
+	}
+	if expectedIdentifier == "" {
+		if platformName == iosPlatform || platformName == macosPlatform {
+			expectedIdentifier = detectIOSBundleID()
+			if expectedIdentifier == "" {
+				expectedIdentifier = generateDefaultBundleID(config.ProjectID)
+			}
+		} else if platformName == androidPlatform {
+			expectedIdentifier = detectAndroidPackageName()
+			if expectedIdentifier == "" {
+				expectedIdentifier = generateDefaultPackageName(config.ProjectID)
+			}
+		}
+	}
+
+	for _, app := range apps {
+		if strings.ToLower(app.Platform) != platformName {
+			continue
+		}
+		if app.Namespace == expectedIdentifier {
+			return &app, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// DownloadConfig fetches the app's config file via the Management API's
+// :getConfig endpoint, which returns the file contents base64-encoded
+// inside a small JSON envelope, and writes it to config.SourcePath - the
+// same field the CLI backend populates, so platform implementations don't
+// need to know which backend produced the file.
+func (b *APIBackend) DownloadConfig(config *Config) error {
+	if config.AppID == "" {
+		return fmt.Errorf("app ID is required to download configuration")
+	}
+
+	platformName := normalizePlatformName(config.Platform.Name())
+	collection, ok := appsListEndpoints[b.getPlatformFlag(platformName)]
+	if !ok {
+		return fmt.Errorf("platform %s does not support automatic config download", platformName)
+	}
+
+	configExt := filepath.Ext(config.Platform.ConfigFileName())
+
+	tempFile, err := os.CreateTemp("", fmt.Sprintf("nativefire_%s_*%s", collection, configExt))
+	if err != nil {
+		return fmt.Errorf("failed to generate temp filename: %w", err)
+	}
+	configFile := tempFile.Name()
+	tempFile.Close()
+
+	url := fmt.Sprintf("%s/projects/%s/%s/%s/config", firebaseManagementAPI, config.ProjectID, collection, config.AppID)
+	body, err := b.do(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		os.Remove(configFile)
+		return fmt.Errorf("failed to download config: %w", err)
+	}
+
+	var parsed struct {
+		ConfigFileContents string `json:"configFileContents"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		os.Remove(configFile)
+		return fmt.Errorf("failed to parse config response: %w", err)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(parsed.ConfigFileContents)
+	if err != nil {
+		os.Remove(configFile)
+		return fmt.Errorf("failed to decode config file contents: %w", err)
+	}
+
+	if err := os.WriteFile(configFile, decoded, 0o644); err != nil {
+		os.Remove(configFile)
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	config.SourcePath = configFile
+	if b.verbose {
+		ui.SuccessMsg(fmt.Sprintf("Configuration downloaded to: %s", configFile))
+	}
+
+	return nil
+}
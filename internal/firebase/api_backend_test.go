@@ -0,0 +1,40 @@
+package firebase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/clix-so/nativefire/internal/logger"
+)
+
+func newTestAPIBackend() *APIBackend {
+	return &APIBackend{log: logger.Default()}
+}
+
+func TestResolveCreatedAppIDReturnsAppIDDirectly(t *testing.T) {
+	b := newTestAPIBackend()
+
+	appID, err := b.resolveCreatedAppID(context.Background(), []byte(`{"appId":"123"}`))
+	if err != nil {
+		t.Fatalf("resolveCreatedAppID() error = %v", err)
+	}
+	if appID != "123" {
+		t.Errorf("resolveCreatedAppID() = %q, want %q", appID, "123")
+	}
+}
+
+func TestResolveCreatedAppIDErrorsWithoutAppIDOrOperation(t *testing.T) {
+	b := newTestAPIBackend()
+
+	if _, err := b.resolveCreatedAppID(context.Background(), []byte(`{}`)); err == nil {
+		t.Fatal("expected an error for a response with neither appId nor an operation name")
+	}
+}
+
+func TestResolveCreatedAppIDErrorsOnMalformedResponse(t *testing.T) {
+	b := newTestAPIBackend()
+
+	if _, err := b.resolveCreatedAppID(context.Background(), []byte(`not json`)); err == nil {
+		t.Fatal("expected an error for a malformed response")
+	}
+}
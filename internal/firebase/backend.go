@@ -0,0 +1,45 @@
+package firebase
+
+import "os"
+
+// Backend is how a Client talks to Firebase. CLIBackend (the original and
+// default behavior) shells out to the `firebase` CLI; APIBackend instead
+// calls the Firebase Management REST API directly with a service account or
+// Application Default Credentials, so RegisterApp, DownloadConfig, and
+// ListProjects work headlessly in CI without a `firebase login` session.
+// Everything above this interface is backend-agnostic.
+type Backend interface {
+	RegisterApp(config *Config) error
+	DownloadConfig(config *Config) error
+	ListProjects() ([]Project, error)
+	ListApps(projectID string) ([]App, error)
+}
+
+// CLIBackend names Client's own behavior: its methods already satisfy
+// Backend by shelling out to the firebase CLI, so this alias just gives that
+// existing behavior a name alongside APIBackend rather than introducing a
+// second type to keep in sync.
+type CLIBackend = Client
+
+var (
+	_ Backend = (*Client)(nil)
+	_ Backend = (*APIBackend)(nil)
+)
+
+// BackendName resolves which Backend NewClientWithBackend should use. An
+// explicit name (from --backend or the NATIVEFIRE_BACKEND env var) always
+// wins; otherwise the presence of GOOGLE_APPLICATION_CREDENTIALS selects
+// "api", the CI-friendly headless default, and anything else falls back to
+// "cli" to preserve this tool's original behavior.
+func BackendName(explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if name := os.Getenv("NATIVEFIRE_BACKEND"); name != "" {
+		return name
+	}
+	if os.Getenv("GOOGLE_APPLICATION_CREDENTIALS") != "" {
+		return "api"
+	}
+	return "cli"
+}
@@ -0,0 +1,65 @@
+package firebase
+
+import "testing"
+
+func TestBackendNameResolution(t *testing.T) {
+	t.Setenv("NATIVEFIRE_BACKEND", "")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+
+	if got := BackendName("cli"); got != "cli" {
+		t.Errorf("explicit name should win, got %q", got)
+	}
+
+	t.Setenv("NATIVEFIRE_BACKEND", "api")
+	if got := BackendName(""); got != "api" {
+		t.Errorf("expected NATIVEFIRE_BACKEND to select %q, got %q", "api", got)
+	}
+
+	t.Setenv("NATIVEFIRE_BACKEND", "")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "/tmp/creds.json")
+	if got := BackendName(""); got != "api" {
+		t.Errorf("expected a service account to select %q, got %q", "api", got)
+	}
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	if got := BackendName(""); got != "cli" {
+		t.Errorf("expected the default to be %q, got %q", "cli", got)
+	}
+}
+
+func TestClientSatisfiesBackendByDefault(t *testing.T) {
+	client := NewClient(false)
+	var _ Backend = client
+}
+
+func TestNewClientWithBackendFallsBackWithoutCredentials(t *testing.T) {
+	t.Setenv("NATIVEFIRE_BACKEND", "")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+
+	client := NewClientWithBackend(false, "api")
+	if client == nil {
+		t.Fatal("NewClientWithBackend returned nil")
+	}
+	if client.backend != nil {
+		t.Error("expected a fallback to the CLI backend when no credentials are available")
+	}
+}
+
+func TestNewClientWithNoOptionsUsesCLIBackend(t *testing.T) {
+	client := NewClient(false)
+	if client.backend != nil {
+		t.Error("expected NewClient with no options to leave the CLI backend in place")
+	}
+}
+
+func TestNewClientWithBackendOptionMatchesNewClientWithBackend(t *testing.T) {
+	t.Setenv("NATIVEFIRE_BACKEND", "")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+
+	viaOption := NewClient(false, WithBackend("api"))
+	viaHelper := NewClientWithBackend(false, "api")
+
+	if (viaOption.backend != nil) != (viaHelper.backend != nil) {
+		t.Error("expected NewClient(verbose, WithBackend(name)) and NewClientWithBackend(verbose, name) to behave the same")
+	}
+}
@@ -1,14 +1,19 @@
 package firebase
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/clix-so/nativefire/internal/logger"
+	"github.com/clix-so/nativefire/internal/projectscan"
 	"github.com/clix-so/nativefire/internal/ui"
 )
 
@@ -21,13 +26,63 @@ const (
 	activeState     = "ACTIVE"
 )
 
+// normalizePlatformName maps a Platform's display name onto the platform
+// identifier Firebase's CLI understands. iOS Simulator and Mac Catalyst are
+// distinct local Xcode build targets, not distinct Firebase platforms:
+// Firebase only knows about "ios", so a simulator build registers and
+// downloads config the same way a device build does, and a Catalyst build
+// the same way a macOS build does.
+func normalizePlatformName(name string) string {
+	switch strings.ToLower(name) {
+	case "ios simulator":
+		return iosPlatform
+	case "mac catalyst":
+		return macosPlatform
+	default:
+		return strings.ToLower(name)
+	}
+}
+
+// catalystBundleIDSuffix distinguishes a Mac Catalyst app's Firebase
+// registration from the plain macOS app it's built alongside: both share
+// the same Xcode project and macOS Firebase platform, but ship as separate
+// binaries, so a Catalyst app conventionally gets its own
+// "<bundle-id>.maccatalyst" identifier instead of colliding with the macOS
+// app's.
+const catalystBundleIDSuffix = ".maccatalyst"
+
+// isMacCatalyst reports whether config targets Mac Catalyst specifically,
+// as opposed to the plain macOS platform it normalizes to for Firebase's
+// benefit.
+func isMacCatalyst(config *Config) bool {
+	return strings.EqualFold(config.Platform.Name(), "Mac Catalyst")
+}
+
 type Config struct {
-	ProjectID   string
-	AppID       string
-	Platform    PlatformInterface
-	BundleID    string // For iOS/macOS apps
-	PackageName string // For Android apps
-	ConfigFile  string // Path to downloaded config file
+	ProjectID     string
+	AppID         string
+	Platform      PlatformInterface
+	BundleID      string   // For iOS/macOS apps
+	PackageName   string   // For Android apps
+	SourcePath    string   // Path to the downloaded config file, unique per run so concurrent `configure` runs don't race
+	StorageBucket string   // GCS bucket used by Storage-backed products
+	APIKey        string   // Web API key, used by desktop AppOptions templates
+	Modules       []string // Firebase product modules to bootstrap (auth, firestore, messaging, ...)
+	DryRun        bool     // Print mutations (e.g. pbxproj edits) instead of writing them
+	Env           string   // Build environment (dev/staging/prod) selecting a per-environment config file, e.g. for IOSPlatform
+	// Variants, when non-empty, switches RegisterApp to register one Firebase
+	// app per named build variant (Android product flavor/build type, iOS
+	// XCBuildConfiguration) instead of a single app for BundleID/PackageName.
+	// ["all"] registers every variant the project declares. See variants.go.
+	Variants []string
+	// PackageManager overrides IOSPlatform's Podfile-vs-SPM heuristic:
+	// "cocoapods" or "spm". "" (the default) lets IOSPlatform decide from
+	// what's already in the project (a Podfile, Package.swift, or neither).
+	PackageManager string
+	// FirebaseSDKVersion overrides the minimum Firebase iOS SDK version
+	// registered for a new Swift Package Manager dependency. "" uses
+	// IOSPlatform's own default.
+	FirebaseSDKVersion string
 }
 
 type PlatformInterface interface {
@@ -38,6 +93,13 @@ type PlatformInterface interface {
 
 type Client struct {
 	verbose bool
+	log     *logger.Logger
+
+	// backend is non-nil only when NewClientWithBackend routed this Client to
+	// an alternative Backend (e.g. APIBackend). When nil, the methods below
+	// run their own CLI logic directly, making Client double as Backend's
+	// default "cli" implementation - see CLIBackend in backend.go.
+	backend Backend
 }
 
 type App struct {
@@ -70,9 +132,88 @@ type AppsListResponse struct {
 	Result []App  `json:"result"`
 }
 
-func NewClient(verbose bool) *Client {
-	return &Client{
+// Option configures a Client built by NewClient. WithBackend is currently
+// the only one; new options can be added without another constructor or
+// breaking existing NewClient(verbose) call sites, since opts is variadic.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	backendName string
+}
+
+// WithBackend selects which Backend NewClient routes through ("cli" or
+// "api"; "" defers to BackendName's auto-detection rules).
+func WithBackend(name string) Option {
+	return func(o *clientOptions) { o.backendName = name }
+}
+
+// NewClient builds a Client, applying any Options. With no WithBackend
+// option (or BackendName resolving to anything but "api") it shells out to
+// the `firebase` CLI directly, matching this package's original behavior.
+// With the API backend selected, if it can't be constructed - most commonly
+// because no credentials of any kind are available - NewClient logs a
+// warning and falls back to the CLI backend rather than failing outright,
+// since "api" is usually an auto-detected default rather than an explicit
+// user request.
+func NewClient(verbose bool, opts ...Option) *Client {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	client := &Client{
 		verbose: verbose,
+		log:     logger.Default().With(logger.F("component", "firebase")),
+	}
+
+	if BackendName(options.backendName) != "api" {
+		return client
+	}
+
+	apiBackend, err := newAPIBackend(verbose, client.log)
+	if err != nil {
+		client.log.Warn("falling back to the CLI backend", logger.F("reason", err.Error()))
+		if verbose {
+			ui.WarningMsg(fmt.Sprintf("Could not use the Firebase Management API backend, falling back to the CLI: %v", err))
+		}
+		return client
+	}
+
+	client.backend = apiBackend
+	return client
+}
+
+// NewClientWithBackend is NewClient(verbose, WithBackend(backendName)) under
+// a name that reads better at its call sites (cmd/*.go's --backend flag
+// handling), kept so those don't need to spell out WithBackend themselves.
+func NewClientWithBackend(verbose bool, backendName string) *Client {
+	return NewClient(verbose, WithBackend(backendName))
+}
+
+func (c *Client) logCommand(cmd *exec.Cmd, duration time.Duration, stderr []byte, err error) {
+	exitCode := 0
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		} else {
+			exitCode = -1
+		}
+	}
+
+	fields := []logger.Field{
+		logger.F("command", c.formatCommand(cmd.Args)),
+		logger.F("duration_ms", duration.Milliseconds()),
+		logger.F("exit_code", exitCode),
+	}
+	if trimmed := strings.TrimSpace(string(stderr)); trimmed != "" {
+		fields = append(fields, logger.F("stderr", trimmed))
+	}
+
+	if err != nil {
+		c.log.Error("firebase CLI command failed", fields...)
+	} else {
+		c.log.Debug("firebase CLI command succeeded", fields...)
 	}
 }
 
@@ -85,10 +226,9 @@ func (c *Client) checkFirebaseCLI() error {
 }
 
 func (c *Client) checkAuthentication() error {
-	cmd := exec.Command("firebase", "projects:list")
-	output, err := cmd.CombinedOutput()
+	stdout, stderr, err := c.runCLI(context.Background(), []string{"projects:list"}, runOptions{MaxRetries: 2})
 	if err != nil {
-		if strings.Contains(string(output), "not authenticated") {
+		if strings.Contains(stdout+stderr, "not authenticated") {
 			return fmt.Errorf("not authenticated with Firebase. Please run: firebase login")
 		}
 		return fmt.Errorf("failed to check authentication: %w", err)
@@ -97,6 +237,14 @@ func (c *Client) checkAuthentication() error {
 }
 
 func (c *Client) RegisterApp(config *Config) error {
+	if c.backend != nil {
+		return c.backend.RegisterApp(config)
+	}
+
+	if len(config.Variants) > 0 {
+		return c.registerAppVariants(config)
+	}
+
 	if err := c.checkFirebaseCLI(); err != nil {
 		return err
 	}
@@ -130,22 +278,23 @@ func (c *Client) RegisterApp(config *Config) error {
 		return nil
 	}
 
+	if config.DryRun {
+		c.suggestManualCreation(config)
+		return nil
+	}
+
 	platformFlag := c.getPlatformFlag(config.Platform.Name())
 	appName := c.generateAppName(config.Platform.Name())
 
-	// Build the command with platform-specific identifiers
-	cmd := c.buildCreateAppCommand(platformFlag, appName, config)
-
-	if c.verbose {
-		fmt.Printf("%s %s\n", ui.Dim.Sprint("Running:"), ui.Code(c.formatCommand(cmd.Args)))
-	}
+	// Build the command args with platform-specific identifiers
+	args := c.buildCreateAppArgs(platformFlag, appName, config)
 
-	output, err := cmd.CombinedOutput()
+	stdout, stderr, err := c.runCLI(context.Background(), args, runOptions{MaxRetries: 2})
 	if err != nil {
-		return c.handleAppCreationError(config, string(output))
+		return c.handleAppCreationError(config, stdout+stderr)
 	}
 
-	appID := c.extractAppIDFromOutput(string(output))
+	appID := c.extractAppIDFromOutput(stdout)
 	if appID == "" {
 		return fmt.Errorf("failed to extract app ID from Firebase CLI output")
 	}
@@ -160,6 +309,10 @@ func (c *Client) RegisterApp(config *Config) error {
 }
 
 func (c *Client) DownloadConfig(config *Config) error {
+	if c.backend != nil {
+		return c.backend.DownloadConfig(config)
+	}
+
 	if config.AppID == "" {
 		return fmt.Errorf("app ID is required to download configuration")
 	}
@@ -179,31 +332,27 @@ func (c *Client) DownloadConfig(config *Config) error {
 	os.Remove(configFile) // Remove the file so Firebase CLI can create it fresh
 
 	// Store the temp file path in config for platform implementations to use
-	config.ConfigFile = configFile
+	config.SourcePath = configFile
 
-	var cmd *exec.Cmd
-	platformName := strings.ToLower(config.Platform.Name())
+	var args []string
+	platformName := normalizePlatformName(config.Platform.Name())
 
 	switch platformName {
 	case androidPlatform:
-		cmd = exec.Command("firebase", "apps:sdkconfig", androidPlatform, config.AppID,
-			"--project", config.ProjectID, "--out", configFile)
+		args = []string{"apps:sdkconfig", androidPlatform, config.AppID,
+			"--project", config.ProjectID, "--out", configFile}
 	case iosPlatform, macosPlatform:
-		cmd = exec.Command("firebase", "apps:sdkconfig", iosPlatform, config.AppID,
-			"--project", config.ProjectID, "--out", configFile)
+		args = []string{"apps:sdkconfig", iosPlatform, config.AppID,
+			"--project", config.ProjectID, "--out", configFile}
 	default:
 		return fmt.Errorf("platform %s does not support automatic config download", platformName)
 	}
 
-	if c.verbose {
-		fmt.Printf("%s %s\n", ui.Dim.Sprint("Running:"), ui.Code(c.formatCommand(cmd.Args)))
-	}
-
-	output, err := cmd.CombinedOutput()
+	stdout, stderr, err := c.runCLI(context.Background(), args, runOptions{MaxRetries: 2})
 	if err != nil {
 		// Clean up temp file if download fails
 		os.Remove(configFile)
-		return fmt.Errorf("failed to download config: %s", string(output))
+		return fmt.Errorf("failed to download config: %s", stdout+stderr)
 	}
 
 	if c.verbose {
@@ -214,7 +363,7 @@ func (c *Client) DownloadConfig(config *Config) error {
 }
 
 func (c *Client) getPlatformFlag(platformName string) string {
-	switch strings.ToLower(platformName) {
+	switch normalizePlatformName(platformName) {
 	case androidPlatform:
 		return androidPlatform
 	case iosPlatform, macosPlatform:
@@ -253,15 +402,15 @@ func (c *Client) extractAppIDFromOutput(output string) string {
 	return ""
 }
 
-func (c *Client) buildCreateAppCommand(platformFlag, appName string, config *Config) *exec.Cmd {
+func (c *Client) buildCreateAppArgs(platformFlag, appName string, config *Config) []string {
 	args := []string{"apps:create", platformFlag, appName, "--project", config.ProjectID}
 
 	// Add platform-specific identifiers
-	switch strings.ToLower(config.Platform.Name()) {
+	switch normalizePlatformName(config.Platform.Name()) {
 	case androidPlatform:
 		packageName := config.PackageName
 		if packageName == "" {
-			packageName = c.detectAndroidPackageName()
+			packageName = detectAndroidPackageName()
 		}
 		if packageName == "" {
 			// Use a default package name based on project
@@ -272,175 +421,67 @@ func (c *Client) buildCreateAppCommand(platformFlag, appName string, config *Con
 			ui.InfoMsg(fmt.Sprintf("Using Android package name: %s", packageName))
 		}
 	case iosPlatform, macosPlatform:
-		bundleID := config.BundleID
-		if bundleID == "" {
-			bundleID = c.detectIOSBundleID()
-		}
-		if bundleID == "" {
-			// Use a default bundle ID based on project
-			bundleID = c.generateDefaultBundleID(config.ProjectID)
-		}
+		bundleID := c.resolveIOSBundleID(config)
 		args = append(args, "--bundle-id", bundleID)
 		if c.verbose {
 			ui.InfoMsg(fmt.Sprintf("Using iOS bundle ID: %s", bundleID))
 		}
 	}
 
-	return exec.Command("firebase", args...)
+	return args
 }
 
-func (c *Client) detectAndroidPackageName() string {
-	// Try to find package name in build.gradle files
-	buildGradleFiles := []string{
-		"app/build.gradle",
-		"android/app/build.gradle",
-		"build.gradle",
-	}
-
-	for _, file := range buildGradleFiles {
-		if packageName := c.extractPackageNameFromBuildGradle(file); packageName != "" {
-			return packageName
-		}
-	}
-
-	// Try to find package name in AndroidManifest.xml
-	manifestFiles := []string{
-		"app/src/main/AndroidManifest.xml",
-		"android/app/src/main/AndroidManifest.xml",
-		"src/main/AndroidManifest.xml",
-	}
-
-	for _, file := range manifestFiles {
-		if packageName := c.extractPackageNameFromManifest(file); packageName != "" {
-			return packageName
-		}
-	}
-
-	return ""
-}
-
-func (c *Client) detectIOSBundleID() string {
-	// Try to find bundle ID in Info.plist files
-	infoPlistFiles := []string{
-		"ios/Runner/Info.plist",
-		"Info.plist",
-		"Runner/Info.plist",
-	}
-
-	for _, file := range infoPlistFiles {
-		if bundleID := c.extractBundleIDFromInfoPlist(file); bundleID != "" {
-			return bundleID
-		}
-	}
-
-	// Try to find in project.pbxproj files
-	pbxprojFiles, _ := filepath.Glob("*.xcodeproj/project.pbxproj")
-	for _, file := range pbxprojFiles {
-		if bundleID := c.extractBundleIDFromPbxproj(file); bundleID != "" {
-			return bundleID
-		}
-	}
-
-	// Try iOS subdirectory
-	iosPbxprojFiles, _ := filepath.Glob("ios/*.xcodeproj/project.pbxproj")
-	for _, file := range iosPbxprojFiles {
-		if bundleID := c.extractBundleIDFromPbxproj(file); bundleID != "" {
-			return bundleID
-		}
-	}
-
-	return ""
-}
-
-func (c *Client) extractPackageNameFromBuildGradle(filename string) string {
-	content, err := os.ReadFile(filename)
+// detectAndroidPackageName and detectIOSBundleID back the manual-creation
+// guidance in suggestManualCreation and the default-identifier fallbacks
+// below, for whichever platform the caller didn't pass an explicit
+// identifier for. Both delegate to projectscan, which parses
+// build.gradle(.kts)/AndroidManifest.xml and pbxproj/xcconfig into typed
+// models instead of scanning them line-by-line for a single field.
+func detectAndroidPackageName() string {
+	project, err := projectscan.ScanAndroid(".")
 	if err != nil {
 		return ""
 	}
-
-	// Look for applicationId in build.gradle
-	lines := strings.Split(string(content), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.Contains(line, "applicationId") {
-			// Extract applicationId "com.example.app"
-			if idx := strings.Index(line, "\""); idx != -1 {
-				remaining := line[idx+1:]
-				if idx2 := strings.Index(remaining, "\""); idx2 != -1 {
-					return remaining[:idx2]
-				}
-			}
-		}
-	}
-	return ""
+	return project.ApplicationID
 }
 
-func (c *Client) extractPackageNameFromManifest(filename string) string {
-	content, err := os.ReadFile(filename)
+func detectIOSBundleID() string {
+	project, err := projectscan.ScanIOS(".")
 	if err != nil {
 		return ""
 	}
-
-	// Look for package attribute in AndroidManifest.xml
-	contentStr := string(content)
-	if idx := strings.Index(contentStr, "package=\""); idx != -1 {
-		start := idx + len("package=\"")
-		if end := strings.Index(contentStr[start:], "\""); end != -1 {
-			return contentStr[start : start+end]
-		}
-	}
-	return ""
+	return project.BundleID
 }
 
-func (c *Client) extractBundleIDFromInfoPlist(filename string) string {
-	content, err := os.ReadFile(filename)
-	if err != nil {
-		return ""
+// resolveIOSBundleID applies the bundle ID fallback chain shared by
+// buildCreateAppArgs, checkIOSAppMatch, resolveExpectedIdentifier, and
+// suggestManualCreation: an explicit config.BundleID first, then one
+// detected from the local Xcode project, then a project-ID-derived
+// default. When config targets Mac Catalyst specifically and the ID came
+// from detection or the default (not an explicit override),
+// catalystBundleIDSuffix is appended so the suggestion doesn't collide
+// with the plain macOS app's bundle ID.
+func (c *Client) resolveIOSBundleID(config *Config) string {
+	if config.BundleID != "" {
+		return config.BundleID
 	}
 
-	// Look for CFBundleIdentifier in Info.plist
-	lines := strings.Split(string(content), "\n")
-	for i, line := range lines {
-		if strings.Contains(line, "CFBundleIdentifier") && i+1 < len(lines) {
-			nextLine := strings.TrimSpace(lines[i+1])
-			// Extract from <string>com.example.app</string>
-			if strings.HasPrefix(nextLine, "<string>") && strings.HasSuffix(nextLine, "</string>") {
-				bundleID := nextLine[8 : len(nextLine)-9] // Remove <string> and </string>
-				if bundleID != "$(PRODUCT_BUNDLE_IDENTIFIER)" {
-					return bundleID
-				}
-			}
-		}
+	bundleID := detectIOSBundleID()
+	if bundleID == "" {
+		bundleID = c.generateDefaultBundleID(config.ProjectID)
 	}
-	return ""
-}
 
-func (c *Client) extractBundleIDFromPbxproj(filename string) string {
-	content, err := os.ReadFile(filename)
-	if err != nil {
-		return ""
+	if isMacCatalyst(config) {
+		bundleID += catalystBundleIDSuffix
 	}
 
-	// Look for PRODUCT_BUNDLE_IDENTIFIER in project.pbxproj
-	lines := strings.Split(string(content), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.Contains(line, "PRODUCT_BUNDLE_IDENTIFIER") {
-			// Extract PRODUCT_BUNDLE_IDENTIFIER = com.example.app;
-			if idx := strings.Index(line, "="); idx != -1 {
-				remaining := strings.TrimSpace(line[idx+1:])
-				remaining = strings.TrimSuffix(remaining, ";")
-				remaining = strings.Trim(remaining, "\"")
-				if remaining != "" && !strings.Contains(remaining, "$") {
-					return remaining
-				}
-			}
-		}
-	}
-	return ""
+	return bundleID
 }
 
-func (c *Client) generateDefaultPackageName(projectID string) string {
+// generateDefaultPackageName and generateDefaultBundleID are also shared:
+// both backends fall back to a project-ID-derived identifier when neither an
+// explicit flag nor local detection found one.
+func generateDefaultPackageName(projectID string) string {
 	// Generate a valid Android package name from project ID
 	// Replace hyphens with dots and ensure it starts with a domain-like structure
 	sanitized := strings.ReplaceAll(projectID, "-", ".")
@@ -450,7 +491,7 @@ func (c *Client) generateDefaultPackageName(projectID string) string {
 	return fmt.Sprintf("com.%s", sanitized)
 }
 
-func (c *Client) generateDefaultBundleID(projectID string) string {
+func generateDefaultBundleID(projectID string) string {
 	// Generate a valid iOS bundle ID from project ID
 	// Replace hyphens with dots and ensure it starts with a domain-like structure
 	sanitized := strings.ReplaceAll(projectID, "-", ".")
@@ -460,7 +501,22 @@ func (c *Client) generateDefaultBundleID(projectID string) string {
 	return fmt.Sprintf("com.%s", sanitized)
 }
 
+// Client method wrappers below keep the existing API surface (and the tests
+// built against it) intact while the underlying logic is shared with
+// APIBackend.
+func (c *Client) generateDefaultPackageName(projectID string) string {
+	return generateDefaultPackageName(projectID)
+}
+
+func (c *Client) generateDefaultBundleID(projectID string) string {
+	return generateDefaultBundleID(projectID)
+}
+
 func (c *Client) ListProjects() ([]Project, error) {
+	if c.backend != nil {
+		return c.backend.ListProjects()
+	}
+
 	if err := c.checkFirebaseCLI(); err != nil {
 		return nil, err
 	}
@@ -469,19 +525,13 @@ func (c *Client) ListProjects() ([]Project, error) {
 		return nil, err
 	}
 
-	cmd := exec.Command("firebase", "projects:list", "--json")
-
-	if c.verbose {
-		fmt.Printf("%s %s\n", ui.Dim.Sprint("Running:"), ui.Code(c.formatCommand(cmd.Args)))
-	}
-
-	output, err := cmd.Output() // Only capture stdout, ignore stderr
+	stdout, _, err := c.runCLI(context.Background(), []string{"projects:list", "--json"}, runOptions{MaxRetries: 2})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list Firebase projects: %w", err)
 	}
 
 	var response ProjectsListResponse
-	if err := json.Unmarshal(output, &response); err != nil {
+	if err := json.Unmarshal([]byte(stdout), &response); err != nil {
 		return nil, fmt.Errorf("failed to parse projects response: %w", err)
 	}
 
@@ -516,6 +566,10 @@ func (c *Client) ValidateProject(projectID string) error {
 }
 
 func (c *Client) ListApps(projectID string) ([]App, error) {
+	if c.backend != nil {
+		return c.backend.ListApps(projectID)
+	}
+
 	if err := c.checkFirebaseCLI(); err != nil {
 		return nil, err
 	}
@@ -524,19 +578,13 @@ func (c *Client) ListApps(projectID string) ([]App, error) {
 		return nil, err
 	}
 
-	cmd := exec.Command("firebase", "apps:list", "--json", "--project", projectID)
-
-	if c.verbose {
-		fmt.Printf("%s %s\n", ui.Dim.Sprint("Running:"), ui.Code(c.formatCommand(cmd.Args)))
-	}
-
-	output, err := cmd.Output() // Only capture stdout, ignore stderr
+	stdout, _, err := c.runCLI(context.Background(), []string{"apps:list", "--json", "--project", projectID}, runOptions{MaxRetries: 2})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list Firebase apps: %w", err)
 	}
 
 	var response AppsListResponse
-	if err := json.Unmarshal(output, &response); err != nil {
+	if err := json.Unmarshal([]byte(stdout), &response); err != nil {
 		return nil, fmt.Errorf("failed to parse apps response: %w", err)
 	}
 
@@ -547,13 +595,28 @@ func (c *Client) ListApps(projectID string) ([]App, error) {
 	return response.Result, nil
 }
 
+// DeleteApp removes a Firebase app, used to roll back a RegisterApp step
+// that created a new app when a later configure step fails. The `firebase`
+// CLI has no app-deletion command, so this only works when Client is
+// routed through the API backend (see NewClientWithBackend); otherwise it
+// returns an error so callers can fall back to telling the user to clean
+// up the app manually.
+func (c *Client) DeleteApp(projectID, platformName, appID string) error {
+	apiBackend, ok := c.backend.(*APIBackend)
+	if !ok {
+		return fmt.Errorf("the CLI backend cannot delete Firebase apps automatically; " +
+			"remove the app manually in the Firebase console")
+	}
+	return apiBackend.DeleteApp(projectID, platformName, appID)
+}
+
 func (c *Client) FindExistingApp(config *Config) (*App, error) {
 	apps, err := c.ListApps(config.ProjectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list apps: %w", err)
 	}
 
-	platformName := strings.ToLower(config.Platform.Name())
+	platformName := normalizePlatformName(config.Platform.Name())
 
 	// Search through apps for matching platform and identifier
 	for _, app := range apps {
@@ -584,13 +647,7 @@ func (c *Client) checkAppMatch(app App, config *Config, platformName string) boo
 
 // checkIOSAppMatch checks if an iOS/macOS app matches the expected bundle ID
 func (c *Client) checkIOSAppMatch(app App, config *Config) bool {
-	expectedBundleID := config.BundleID
-	if expectedBundleID == "" {
-		expectedBundleID = c.detectIOSBundleID()
-	}
-	if expectedBundleID == "" {
-		expectedBundleID = c.generateDefaultBundleID(config.ProjectID)
-	}
+	expectedBundleID := c.resolveIOSBundleID(config)
 
 	// Check both bundleId field and namespace field
 	bundleIDToCheck := app.BundleID
@@ -612,7 +669,7 @@ func (c *Client) checkIOSAppMatch(app App, config *Config) bool {
 func (c *Client) checkAndroidAppMatch(app App, config *Config) bool {
 	expectedPackageName := config.PackageName
 	if expectedPackageName == "" {
-		expectedPackageName = c.detectAndroidPackageName()
+		expectedPackageName = detectAndroidPackageName()
 	}
 	if expectedPackageName == "" {
 		expectedPackageName = c.generateDefaultPackageName(config.ProjectID)
@@ -674,20 +731,13 @@ func (c *Client) isDuplicateAppError(output string) bool {
 // resolveExpectedIdentifier gets the expected identifier for the platform
 func (c *Client) resolveExpectedIdentifier(config *Config, platformName string) string {
 	if platformName == iosPlatform || platformName == macosPlatform {
-		expectedIdentifier := config.BundleID
-		if expectedIdentifier == "" {
-			expectedIdentifier = c.detectIOSBundleID()
-		}
-		if expectedIdentifier == "" {
-			expectedIdentifier = c.generateDefaultBundleID(config.ProjectID)
-		}
-		return expectedIdentifier
+		return c.resolveIOSBundleID(config)
 	}
 
 	if platformName == androidPlatform {
 		expectedIdentifier := config.PackageName
 		if expectedIdentifier == "" {
-			expectedIdentifier = c.detectAndroidPackageName()
+			expectedIdentifier = detectAndroidPackageName()
 		}
 		if expectedIdentifier == "" {
 			expectedIdentifier = c.generateDefaultPackageName(config.ProjectID)
@@ -698,8 +748,11 @@ func (c *Client) resolveExpectedIdentifier(config *Config, platformName string)
 	return ""
 }
 
-// filterAppsByPlatform filters apps by platform name
-func (c *Client) filterAppsByPlatform(apps []App, platformName string) []App {
+// FilterAppsByPlatform filters apps by platform name. It's exported
+// (unlike most of Client's helpers) so callers outside this package - e.g.
+// the project picker in cmd/projects.go - can build a per-platform app list without
+// re-fetching or re-filtering themselves.
+func FilterAppsByPlatform(apps []App, platformName string) []App {
 	var platformApps []App
 	for _, app := range apps {
 		if strings.ToLower(app.Platform) == platformName {
@@ -709,6 +762,12 @@ func (c *Client) filterAppsByPlatform(apps []App, platformName string) []App {
 	return platformApps
 }
 
+// filterAppsByPlatform keeps Client's existing method surface (and the
+// tests built against it) intact while delegating to FilterAppsByPlatform.
+func (c *Client) filterAppsByPlatform(apps []App, platformName string) []App {
+	return FilterAppsByPlatform(apps, platformName)
+}
+
 // logPlatformApps logs platform apps for debugging
 func (c *Client) logPlatformApps(platformApps []App, platformName string) {
 	ui.InfoMsg(fmt.Sprintf("Found %d %s apps in project:", len(platformApps), platformName))
@@ -785,7 +844,7 @@ func (c *Client) findExistingAppByIdentifier(config *Config) (*App, error) {
 		return nil, err
 	}
 
-	platformName := strings.ToLower(config.Platform.Name())
+	platformName := normalizePlatformName(config.Platform.Name())
 
 	// Get the expected identifier (bundle ID or package name)
 	expectedIdentifier := c.resolveExpectedIdentifier(config, platformName)
@@ -815,20 +874,17 @@ func (c *Client) findExistingAppByIdentifier(config *Config) (*App, error) {
 	return nil, nil
 }
 
-// suggestManualCreation provides helpful guidance when automatic app creation fails
+// suggestManualCreation prints the `firebase apps:create` command(s) for a
+// user to run themselves instead of RegisterApp running them automatically -
+// either because automatic creation just failed, or because config.DryRun
+// asked RegisterApp not to create anything in the first place.
 func (c *Client) suggestManualCreation(config *Config) {
-	platformName := strings.ToLower(config.Platform.Name())
+	platformName := normalizePlatformName(config.Platform.Name())
 
 	ui.InfoMsg("Manual creation options:")
 
 	if platformName == iosPlatform || platformName == macosPlatform {
-		expectedBundleID := config.BundleID
-		if expectedBundleID == "" {
-			expectedBundleID = c.detectIOSBundleID()
-		}
-		if expectedBundleID == "" {
-			expectedBundleID = c.generateDefaultBundleID(config.ProjectID)
-		}
+		expectedBundleID := c.resolveIOSBundleID(config)
 
 		fmt.Printf("  1. Create an app manually in Firebase Console with Bundle ID: %s\n",
 			ui.Secondary.Sprint(expectedBundleID))
@@ -838,7 +894,7 @@ func (c *Client) suggestManualCreation(config *Config) {
 	} else if platformName == androidPlatform {
 		expectedPackageName := config.PackageName
 		if expectedPackageName == "" {
-			expectedPackageName = c.detectAndroidPackageName()
+			expectedPackageName = detectAndroidPackageName()
 		}
 		if expectedPackageName == "" {
 			expectedPackageName = c.generateDefaultPackageName(config.ProjectID)
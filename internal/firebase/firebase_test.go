@@ -128,6 +128,8 @@ func TestGetPlatformFlag(t *testing.T) {
 		{"ios", "ios"},
 		{"macOS", "ios"},
 		{"macos", "ios"},
+		{"iOS Simulator", "ios"},
+		{"Mac Catalyst", "ios"},
 		{"web", "web"},
 		{"unknown", "android"}, // default fallback
 	}
@@ -142,6 +144,29 @@ func TestGetPlatformFlag(t *testing.T) {
 	}
 }
 
+func TestNormalizePlatformName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Android", "android"},
+		{"iOS", "ios"},
+		{"macOS", "macos"},
+		{"iOS Simulator", "ios"},
+		{"Mac Catalyst", "macos"},
+		{"Windows", "windows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := normalizePlatformName(tt.name)
+			if result != tt.expected {
+				t.Errorf("Expected normalized platform '%s' for '%s', got '%s'", tt.expected, tt.name, result)
+			}
+		})
+	}
+}
+
 func TestExtractAppIDFromOutput(t *testing.T) {
 	client := NewClient(false)
 
@@ -229,6 +254,38 @@ func TestRegisterApp(t *testing.T) {
 			t.Errorf("Expected app ID to remain 'existing-app-id', got '%s'", config.AppID)
 		}
 	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		// Authentication checks are allowed through, but app creation is not -
+		// if DryRun actually shelled out to create the app, this would fail the test.
+		fakeFirebaseCLI(t, `#!/bin/sh
+if [ "$1" = "apps:create" ]; then
+  echo unexpected invocation >&2
+  exit 1
+fi
+echo '[]'
+`)
+
+		mockPlatform := &MockPlatform{
+			name:           "Android",
+			configFileName: "google-services.json",
+			configPath:     "/tmp",
+		}
+
+		config := &Config{
+			ProjectID: "test-project",
+			Platform:  mockPlatform,
+			DryRun:    true,
+		}
+
+		if err := client.RegisterApp(config); err != nil {
+			t.Errorf("RegisterApp with DryRun = true should not error, got: %v", err)
+		}
+
+		if config.AppID != "" {
+			t.Errorf("DryRun should not assign an app ID, got %q", config.AppID)
+		}
+	})
 }
 
 func TestDownloadConfig(t *testing.T) {
@@ -430,6 +487,38 @@ func TestGenerateDefaultBundleID(t *testing.T) {
 	}
 }
 
+func TestResolveIOSBundleIDPrefersExplicitConfig(t *testing.T) {
+	client := NewClient(false)
+	config := &Config{
+		ProjectID: "my-project",
+		Platform:  &MockPlatform{name: "Mac Catalyst"},
+		BundleID:  "com.example.explicit",
+	}
+
+	if got := client.resolveIOSBundleID(config); got != "com.example.explicit" {
+		t.Errorf("resolveIOSBundleID() = %q, want the explicit BundleID left untouched", got)
+	}
+}
+
+func TestResolveIOSBundleIDAppendsCatalystSuffixForGeneratedDefault(t *testing.T) {
+	client := NewClient(false)
+	config := &Config{ProjectID: "myproject", Platform: &MockPlatform{name: "Mac Catalyst"}}
+
+	want := "com.firebase.myproject" + catalystBundleIDSuffix
+	if got := client.resolveIOSBundleID(config); got != want {
+		t.Errorf("resolveIOSBundleID() = %q, want %q", got, want)
+	}
+}
+
+func TestResolveIOSBundleIDLeavesPlainIOSUnsuffixed(t *testing.T) {
+	client := NewClient(false)
+	config := &Config{ProjectID: "myproject", Platform: &MockPlatform{name: "iOS"}}
+
+	if got := client.resolveIOSBundleID(config); got != "com.firebase.myproject" {
+		t.Errorf("resolveIOSBundleID() = %q, want no Catalyst suffix for plain iOS", got)
+	}
+}
+
 func TestIsDuplicateAppError(t *testing.T) {
 	client := NewClient(false)
 
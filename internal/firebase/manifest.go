@@ -0,0 +1,136 @@
+package firebase
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestApp describes one Firebase app entry in a nativefire.yaml
+// registration manifest: the platform it targets, its bundle/package
+// identifier, and where its downloaded config file belongs.
+type ManifestApp struct {
+	Platform    string `yaml:"platform"`
+	BundleID    string `yaml:"bundle_id"`
+	PackageName string `yaml:"package_name"`
+	DisplayName string `yaml:"display_name"`
+	AppID       string `yaml:"app_id"`
+	ConfigPath  string `yaml:"config_path"`
+}
+
+// RegistrationManifest is the top-level shape of a nativefire.yaml
+// registration manifest: one Firebase project and the apps to register
+// under it. This is a distinct document from the nativefire.yaml package
+// manifest read by internal/packaging - both share the filename because
+// each is meant to sit alongside the project it describes, not because
+// they share a schema.
+type RegistrationManifest struct {
+	ProjectID string        `yaml:"project_id"`
+	Apps      []ManifestApp `yaml:"apps"`
+}
+
+// manifestPlatform satisfies PlatformInterface using a manifest entry's own
+// config_path, so LoadManifest doesn't need to import internal/platform
+// (which itself imports this package) just to register an app.
+type manifestPlatform struct {
+	name           string
+	configFileName string
+	configPath     string
+}
+
+func (p *manifestPlatform) Name() string           { return p.name }
+func (p *manifestPlatform) ConfigFileName() string { return p.configFileName }
+func (p *manifestPlatform) ConfigPath() string     { return p.configPath }
+
+// manifestConfigFileName returns the SDK config filename firebase CLI
+// downloads for platformName, mirroring the filenames the built-in
+// platform.Platform implementations use.
+func manifestConfigFileName(platformName string) string {
+	switch normalizePlatformName(platformName) {
+	case androidPlatform:
+		return "google-services.json"
+	case iosPlatform, macosPlatform:
+		return "GoogleService-Info.plist"
+	default:
+		return "firebase-config.json"
+	}
+}
+
+// LoadManifest reads a nativefire.yaml registration manifest and returns one
+// Config per app entry, with ProjectID inherited from the manifest's
+// top-level project_id and Platform populated well enough to register and
+// download a config (Name/ConfigFileName/ConfigPath only - installing the
+// downloaded file still goes through the real platform.Platform detected
+// for each target).
+func LoadManifest(path string) ([]*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var manifest RegistrationManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if manifest.ProjectID == "" {
+		return nil, fmt.Errorf("%s is missing a required 'project_id' field", path)
+	}
+	if len(manifest.Apps) == 0 {
+		return nil, fmt.Errorf("%s declares no apps", path)
+	}
+
+	configs := make([]*Config, 0, len(manifest.Apps))
+	for i, app := range manifest.Apps {
+		if app.Platform == "" {
+			return nil, fmt.Errorf("%s: apps[%d] is missing a required 'platform' field", path, i)
+		}
+
+		configs = append(configs, &Config{
+			ProjectID:   manifest.ProjectID,
+			AppID:       app.AppID,
+			BundleID:    app.BundleID,
+			PackageName: app.PackageName,
+			Platform: &manifestPlatform{
+				name:           app.Platform,
+				configFileName: manifestConfigFileName(app.Platform),
+				configPath:     app.ConfigPath,
+			},
+		})
+	}
+
+	return configs, nil
+}
+
+// manifestRegistrationWorkers bounds how many apps RegisterAppsFromManifest
+// registers with Firebase at once, so a large manifest doesn't spray
+// dozens of concurrent `firebase` CLI invocations at once.
+const manifestRegistrationWorkers = 4
+
+// RegisterAppsFromManifest registers every config concurrently, bounded to
+// manifestRegistrationWorkers at a time, and returns every failure joined
+// together rather than stopping at the first one - a typo'd bundle ID on
+// one app shouldn't block the rest of the manifest from registering.
+func (c *Client) RegisterAppsFromManifest(configs []*Config) error {
+	sem := make(chan struct{}, manifestRegistrationWorkers)
+	errs := make([]error, len(configs))
+
+	var wg sync.WaitGroup
+	for i, config := range configs {
+		wg.Add(1)
+		go func(i int, config *Config) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := c.RegisterApp(config); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", config.Platform.Name(), err)
+			}
+		}(i, config)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
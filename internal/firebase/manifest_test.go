@@ -0,0 +1,107 @@
+package firebase
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeManifest(t *testing.T, contents string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nativefire.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func TestLoadManifestParsesAppsIntoConfigs(t *testing.T) {
+	path := writeManifest(t, `
+project_id: my-project
+apps:
+  - platform: android
+    package_name: com.example.app
+    config_path: android/app/src/main
+  - platform: ios
+    bundle_id: com.example.app
+    app_id: "1:111:ios:abc"
+    config_path: ios/MyApp
+`)
+
+	configs, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	if len(configs) != 2 {
+		t.Fatalf("expected 2 configs, got %d", len(configs))
+	}
+
+	android := configs[0]
+	if android.ProjectID != "my-project" {
+		t.Errorf("expected project_id to propagate, got %q", android.ProjectID)
+	}
+	if android.PackageName != "com.example.app" {
+		t.Errorf("expected package_name to propagate, got %q", android.PackageName)
+	}
+	if android.Platform.Name() != "android" {
+		t.Errorf("expected platform name 'android', got %q", android.Platform.Name())
+	}
+	if android.Platform.ConfigFileName() != "google-services.json" {
+		t.Errorf("expected google-services.json, got %q", android.Platform.ConfigFileName())
+	}
+
+	ios := configs[1]
+	if ios.AppID != "1:111:ios:abc" {
+		t.Errorf("expected app_id to propagate, got %q", ios.AppID)
+	}
+	if ios.Platform.ConfigFileName() != "GoogleService-Info.plist" {
+		t.Errorf("expected GoogleService-Info.plist, got %q", ios.Platform.ConfigFileName())
+	}
+}
+
+func TestLoadManifestRequiresProjectID(t *testing.T) {
+	path := writeManifest(t, `
+apps:
+  - platform: android
+`)
+
+	_, err := LoadManifest(path)
+	if err == nil || !strings.Contains(err.Error(), "project_id") {
+		t.Fatalf("expected a missing project_id error, got %v", err)
+	}
+}
+
+func TestLoadManifestRequiresAtLeastOneApp(t *testing.T) {
+	path := writeManifest(t, `project_id: my-project`)
+
+	_, err := LoadManifest(path)
+	if err == nil || !strings.Contains(err.Error(), "no apps") {
+		t.Fatalf("expected a no-apps error, got %v", err)
+	}
+}
+
+func TestRegisterAppsFromManifestReportsEveryFailure(t *testing.T) {
+	client := NewClient(false)
+	configs := []*Config{
+		{
+			ProjectID: "test-project",
+			AppID:     "",
+			Platform:  &manifestPlatform{name: "android", configFileName: "google-services.json"},
+		},
+		{
+			ProjectID: "test-project",
+			AppID:     "existing-app-id",
+			Platform:  &manifestPlatform{name: "ios", configFileName: "GoogleService-Info.plist"},
+		},
+	}
+
+	err := client.RegisterAppsFromManifest(configs)
+	if err == nil {
+		t.Skip("no failure from RegisterApp to report - Firebase CLI may not be available")
+	}
+	if !strings.Contains(err.Error(), "android") {
+		t.Errorf("expected the android app's failure in the combined error, got: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package firebase
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/clix-so/nativefire/internal/logger"
+)
+
+// Action is one reversible step in a Plan. Forward performs the step;
+// Backward undoes it. Backward is only ever invoked once per Action, and
+// only for an Action whose Forward already succeeded, so it doesn't need
+// to guard against being called on a step that never ran.
+type Action struct {
+	Description string
+	Forward     func() error
+	Backward    func() error
+}
+
+// Plan is an ordered, reversible sequence of Actions, applied
+// Terraform-style: Execute runs each Forward in order, and if one fails,
+// it rewinds every previously-succeeded Action's Backward (in reverse
+// order) before returning the original error. That keeps a failed
+// `configure` run from leaving Firebase or the local repo half-applied -
+// e.g. an app registered but its config file never installed.
+type Plan struct {
+	actions []Action
+	log     *logger.Logger
+}
+
+// NewPlan returns an empty Plan ready for Add.
+func NewPlan() *Plan {
+	return &Plan{log: logger.Default().With(logger.F("component", "firebase.plan"))}
+}
+
+// Add appends action to the plan. Actions run in the order they're added.
+func (p *Plan) Add(action Action) {
+	p.actions = append(p.actions, action)
+}
+
+// Steps returns each Action's Description in order, for printing a dry
+// run (e.g. `nativefire configure --plan`) without executing anything.
+func (p *Plan) Steps() []string {
+	steps := make([]string, len(p.actions))
+	for i, action := range p.actions {
+		steps[i] = action.Description
+	}
+	return steps
+}
+
+// Execute runs every Action's Forward in order. If one fails, it rewinds
+// the Actions that already succeeded (most-recent first) via their
+// Backward, then returns an error describing which step failed - the
+// rewind runs best-effort, so a failure partway through it is logged
+// rather than masking the original error.
+func (p *Plan) Execute() error {
+	succeeded := make([]Action, 0, len(p.actions))
+	for _, action := range p.actions {
+		p.log.Debug("applying plan step", logger.F("step", action.Description))
+		if err := action.Forward(); err != nil {
+			p.rewind(succeeded)
+			return fmt.Errorf("step %q failed: %w", action.Description, err)
+		}
+		succeeded = append(succeeded, action)
+	}
+	return nil
+}
+
+func (p *Plan) rewind(succeeded []Action) {
+	for i := len(succeeded) - 1; i >= 0; i-- {
+		action := succeeded[i]
+		if action.Backward == nil {
+			continue
+		}
+		p.log.Debug("rewinding plan step", logger.F("step", action.Description))
+		if err := action.Backward(); err != nil {
+			p.log.Warn("failed to rewind plan step",
+				logger.F("step", action.Description), logger.F("error", err.Error()))
+		}
+	}
+}
+
+// backupSuffix marks a file nativefire backed up before overwriting it, so
+// BackupFile's Backward can tell its own backup apart from unrelated files.
+const backupSuffix = ".nativefire-bak"
+
+// BackupFile returns an Action that backs up any file already at path
+// before a later step overwrites it (e.g. a platform's InstallConfig
+// copying in google-services.json/GoogleService-Info.plist), and restores
+// it on rewind. If path didn't exist yet, rewind instead removes whatever
+// was written in its place, since there's nothing to restore.
+func BackupFile(path string) Action {
+	backupPath := path + backupSuffix
+
+	return Action{
+		Description: fmt.Sprintf("back up %s", path),
+		Forward: func() error {
+			data, err := os.ReadFile(path)
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to back up %s: %w", path, err)
+			}
+			return os.WriteFile(backupPath, data, 0o644)
+		},
+		Backward: func() error {
+			data, err := os.ReadFile(backupPath)
+			if errors.Is(err, os.ErrNotExist) {
+				if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+					return fmt.Errorf("failed to remove %s: %w", path, err)
+				}
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read backup of %s: %w", path, err)
+			}
+			defer os.Remove(backupPath)
+			return os.WriteFile(path, data, 0o644)
+		},
+	}
+}
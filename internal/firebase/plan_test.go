@@ -0,0 +1,124 @@
+package firebase
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlanExecuteRunsStepsInOrder(t *testing.T) {
+	var order []string
+	plan := NewPlan()
+	plan.Add(Action{
+		Description: "first",
+		Forward:     func() error { order = append(order, "first"); return nil },
+	})
+	plan.Add(Action{
+		Description: "second",
+		Forward:     func() error { order = append(order, "second"); return nil },
+	})
+
+	if err := plan.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("expected steps to run in order, got: %v", order)
+	}
+}
+
+func TestPlanExecuteRewindsOnFailure(t *testing.T) {
+	var rewound []string
+	plan := NewPlan()
+	plan.Add(Action{
+		Description: "create app",
+		Forward:     func() error { return nil },
+		Backward:    func() error { rewound = append(rewound, "create app"); return nil },
+	})
+	plan.Add(Action{
+		Description: "download config",
+		Forward:     func() error { return nil },
+		Backward:    func() error { rewound = append(rewound, "download config"); return nil },
+	})
+	plan.Add(Action{
+		Description: "install config",
+		Forward:     func() error { return errors.New("disk full") },
+	})
+
+	err := plan.Execute()
+	if err == nil {
+		t.Fatal("expected Execute() to return an error")
+	}
+
+	if len(rewound) != 2 || rewound[0] != "download config" || rewound[1] != "create app" {
+		t.Errorf("expected succeeded steps to rewind most-recent first, got: %v", rewound)
+	}
+}
+
+func TestPlanStepsListsDescriptionsWithoutRunning(t *testing.T) {
+	ran := false
+	plan := NewPlan()
+	plan.Add(Action{Description: "register app", Forward: func() error { ran = true; return nil }})
+
+	steps := plan.Steps()
+	if len(steps) != 1 || steps[0] != "register app" {
+		t.Errorf("expected Steps() to list descriptions, got: %v", steps)
+	}
+	if ran {
+		t.Error("Steps() should not execute any Forward func")
+	}
+}
+
+func TestBackupFileRestoresExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "google-services.json")
+	if err := os.WriteFile(path, []byte("original"), 0o644); err != nil {
+		t.Fatalf("failed to seed file: %v", err)
+	}
+
+	action := BackupFile(path)
+	if err := action.Forward(); err != nil {
+		t.Fatalf("Forward() error = %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("overwritten"), 0o644); err != nil {
+		t.Fatalf("failed to overwrite file: %v", err)
+	}
+
+	if err := action.Backward(); err != nil {
+		t.Fatalf("Backward() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read restored file: %v", err)
+	}
+	if string(data) != "original" {
+		t.Errorf("expected restored content %q, got %q", "original", string(data))
+	}
+	if _, err := os.Stat(path + backupSuffix); !errors.Is(err, os.ErrNotExist) {
+		t.Error("expected the backup file to be cleaned up after a successful restore")
+	}
+}
+
+func TestBackupFileRemovesNewlyWrittenFileWhenNoneExistedBefore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "GoogleService-Info.plist")
+
+	action := BackupFile(path)
+	if err := action.Forward(); err != nil {
+		t.Fatalf("Forward() error = %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("new"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if err := action.Backward(); err != nil {
+		t.Fatalf("Backward() error = %v", err)
+	}
+
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Error("expected the newly-written file to be removed since none existed before")
+	}
+}
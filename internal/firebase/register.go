@@ -0,0 +1,117 @@
+package firebase
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// BuildRegisterAndConfigurePlan assembles a Plan that registers config.Platform's
+// app with Firebase, downloads its generated SDK config file, and moves it into
+// targetPath, backed by the same Action/Plan rewind mechanism buildConfigurePlan
+// uses in cmd/configure.go. On failure, the completed steps are unwound in LIFO
+// order: whatever config file previously lived at targetPath is restored, the
+// downloaded temp file is removed, and the app is deleted if this call is the
+// one that created it.
+//
+// Gradle/Xcode file updates are not part of this plan; a caller can Add its
+// own Actions to the returned Plan before calling Execute.
+func (c *Client) BuildRegisterAndConfigurePlan(config *Config, targetPath string) (*Plan, error) {
+	existingApp, err := c.FindExistingApp(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for an existing app: %w", err)
+	}
+	createdNewApp := existingApp == nil && config.AppID == ""
+
+	plan := NewPlan()
+
+	plan.Add(Action{
+		Description: fmt.Sprintf("Register the %s app with Firebase", config.Platform.Name()),
+		Forward: func() error {
+			if err := c.RegisterApp(config); err != nil {
+				return fmt.Errorf("failed to register app with Firebase: %w", err)
+			}
+			return nil
+		},
+		Backward: func() error {
+			if !createdNewApp || config.AppID == "" {
+				return nil
+			}
+			return c.DeleteApp(config.ProjectID, config.Platform.Name(), config.AppID)
+		},
+	})
+
+	plan.Add(Action{
+		Description: "Download the app's configuration file from Firebase",
+		Forward: func() error {
+			if err := c.DownloadConfig(config); err != nil {
+				return fmt.Errorf("failed to download configuration: %w", err)
+			}
+			return nil
+		},
+		Backward: func() error {
+			if config.SourcePath == "" {
+				return nil
+			}
+			if err := os.Remove(config.SourcePath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return nil
+		},
+	})
+
+	plan.Add(BackupFile(targetPath))
+
+	plan.Add(Action{
+		Description: fmt.Sprintf("Move the downloaded configuration file into place at %s", targetPath),
+		Forward: func() error {
+			return moveConfigFile(config.SourcePath, targetPath)
+		},
+	})
+
+	return plan, nil
+}
+
+// RegisterAndConfigure runs BuildRegisterAndConfigurePlan's steps end to end,
+// rewinding on the first failure, with no --plan preview or confirmation
+// prompt; cmd/configure.go builds its own Plan for the wizard, which adds
+// those on top of the same Plan/Action mechanism.
+func (c *Client) RegisterAndConfigure(config *Config, targetPath string) error {
+	plan, err := c.BuildRegisterAndConfigurePlan(config, targetPath)
+	if err != nil {
+		return err
+	}
+	return plan.Execute()
+}
+
+// moveConfigFile moves src to dst, falling back to a copy-then-remove when the
+// rename fails - e.g. because src (under the OS temp directory) and dst are on
+// different filesystems.
+func moveConfigFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open downloaded configuration file: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create configuration file at destination: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("failed to copy configuration file into place: %w", err)
+	}
+
+	return os.Remove(src)
+}
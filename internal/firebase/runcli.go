@@ -0,0 +1,166 @@
+package firebase
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/clix-so/nativefire/internal/ui"
+)
+
+// defaultCLITimeout bounds a single `firebase` CLI invocation - long enough
+// for a slow apps:sdkconfig on a large project, short enough that a wedged
+// network call doesn't hang a `configure` run forever.
+const defaultCLITimeout = 2 * time.Minute
+
+// killGracePeriod is how long runCLI waits after sending SIGINT for the CLI
+// process to exit on its own before escalating to SIGKILL.
+const killGracePeriod = 5 * time.Second
+
+// transientCLIErrors lists known Firebase CLI error substrings that indicate
+// a flaky network call worth retrying, as opposed to an auth or
+// configuration failure that retrying won't fix.
+var transientCLIErrors = []string{
+	"ECONNRESET",
+	"ETIMEDOUT",
+	"ENOTFOUND",
+	"socket hang up",
+	"network timeout",
+	"getaddrinfo",
+	"fetch failed",
+}
+
+// runOptions configures a single runCLI call.
+type runOptions struct {
+	// Timeout bounds one attempt. Zero means defaultCLITimeout.
+	Timeout time.Duration
+	// MaxRetries is how many additional attempts runCLI makes after a
+	// transient error (see transientCLIErrors), with exponential backoff
+	// between attempts. Zero means no retries.
+	MaxRetries int
+}
+
+// runCLI runs `firebase` with args, streaming its stdout/stderr lines to
+// ui.InfoMsg (dimmed) as they arrive in verbose mode - so a slow
+// apps:sdkconfig or apps:create shows progress instead of going silent until
+// it exits - while still capturing every line for the caller to parse. It honors ctx cancellation by
+// sending SIGINT to the child process, then SIGKILL after killGracePeriod,
+// and retries (with backoff) when the captured output matches
+// transientCLIErrors.
+func (c *Client) runCLI(ctx context.Context, args []string, opts runOptions) (stdout, stderr string, err error) {
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultCLITimeout
+	}
+
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		stdout, stderr, err = c.runCLIOnce(ctx, args, opts.Timeout)
+		if err == nil || attempt >= opts.MaxRetries || !isTransientCLIError(stdout+stderr) {
+			return stdout, stderr, err
+		}
+
+		if c.verbose {
+			ui.WarningMsg(fmt.Sprintf("Transient Firebase CLI error, retrying in %s: %v", backoff, err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return stdout, stderr, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) runCLIOnce(ctx context.Context, args []string, timeout time.Duration) (stdout, stderr string, err error) {
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.Command("firebase", args...)
+
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to open stdout pipe: %w", err)
+	}
+	stderrPipe, err := cmd.StderrPipe()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to open stderr pipe: %w", err)
+	}
+
+	if c.verbose {
+		fmt.Printf("%s %s\n", ui.Dim.Sprint("Running:"), ui.Code(c.formatCommand(cmd.Args)))
+	}
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return "", "", fmt.Errorf("failed to start firebase CLI: %w", err)
+	}
+
+	var outBuf, errBuf strings.Builder
+	stdoutDone := make(chan struct{})
+	go func() {
+		c.streamLines(stdoutPipe, &outBuf)
+		close(stdoutDone)
+	}()
+	c.streamLines(stderrPipe, &errBuf)
+	<-stdoutDone
+
+	waitErr := waitWithGracePeriod(runCtx, cmd)
+	if waitErr == nil && runCtx.Err() != nil {
+		waitErr = runCtx.Err()
+	}
+	c.logCommand(cmd, time.Since(start), []byte(errBuf.String()), waitErr)
+
+	return outBuf.String(), errBuf.String(), waitErr
+}
+
+// streamLines copies r line by line into buf, forwarding each line to
+// ui.InfoMsg (dimmed) in verbose mode so long-running commands show progress
+// instead of going silent until they exit.
+func (c *Client) streamLines(r io.Reader, buf *strings.Builder) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+		if c.verbose {
+			ui.InfoMsg(ui.Dim.Sprint(line))
+		}
+	}
+}
+
+// waitWithGracePeriod waits for cmd to exit, escalating from SIGINT to
+// SIGKILL if runCtx is cancelled before the process exits on its own.
+func waitWithGracePeriod(runCtx context.Context, cmd *exec.Cmd) error {
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- cmd.Wait() }()
+
+	select {
+	case err := <-waitErr:
+		return err
+	case <-runCtx.Done():
+		_ = cmd.Process.Signal(syscall.SIGINT)
+		select {
+		case err := <-waitErr:
+			return err
+		case <-time.After(killGracePeriod):
+			_ = cmd.Process.Kill()
+			return <-waitErr
+		}
+	}
+}
+
+func isTransientCLIError(output string) bool {
+	for _, indicator := range transientCLIErrors {
+		if strings.Contains(output, indicator) {
+			return true
+		}
+	}
+	return false
+}
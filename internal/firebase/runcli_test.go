@@ -0,0 +1,153 @@
+package firebase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clix-so/nativefire/internal/logger"
+)
+
+// fakeFirebaseCLI points exec.Command("firebase", ...) at a shell script
+// masquerading as the `firebase` binary, by putting a directory containing
+// one on PATH for the duration of the test.
+func fakeFirebaseCLI(t *testing.T, script string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := dir + "/firebase"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatalf("failed to write fake firebase CLI: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestRunCLICapturesStdoutAndStderr(t *testing.T) {
+	fakeFirebaseCLI(t, "#!/bin/sh\necho out-line\necho err-line >&2\n")
+
+	client := NewClient(false)
+	stdout, stderr, err := client.runCLI(context.Background(), []string{"anything"}, runOptions{})
+	if err != nil {
+		t.Fatalf("runCLI() error = %v", err)
+	}
+	if !strings.Contains(stdout, "out-line") {
+		t.Errorf("stdout = %q, want it to contain out-line", stdout)
+	}
+	if !strings.Contains(stderr, "err-line") {
+		t.Errorf("stderr = %q, want it to contain err-line", stderr)
+	}
+}
+
+func TestRunCLILogsFailingCommand(t *testing.T) {
+	fakeFirebaseCLI(t, "#!/bin/sh\necho oops >&2\nexit 1\n")
+
+	var buf bytes.Buffer
+	client := NewClient(false)
+	client.log = logger.New(logger.LevelDebug, logger.NewJSONSink(&buf))
+
+	_, _, err := client.runCLI(context.Background(), []string{"anything"}, runOptions{})
+	if err == nil {
+		t.Fatal("expected runCLI() to return an error for a non-zero exit")
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a structured log event, got error: %v, line: %q", err, buf.String())
+	}
+	if record["level"] != "error" {
+		t.Errorf("expected level=error for a failed command, got: %v", record["level"])
+	}
+	if record["exit_code"].(float64) != 1 {
+		t.Errorf("expected exit_code 1, got %v", record["exit_code"])
+	}
+	if !strings.Contains(record["stderr"].(string), "oops") {
+		t.Errorf("expected captured stderr to contain %q, got %v", "oops", record["stderr"])
+	}
+}
+
+func TestRunCLIStreamsLinesToInfoMsgWhenVerbose(t *testing.T) {
+	fakeFirebaseCLI(t, "#!/bin/sh\necho streamed-line\n")
+
+	client := NewClient(true)
+	stdout, _, err := client.runCLI(context.Background(), []string{"anything"}, runOptions{})
+	if err != nil {
+		t.Fatalf("runCLI() error = %v", err)
+	}
+	if !strings.Contains(stdout, "streamed-line") {
+		t.Errorf("stdout = %q, want it to still capture streamed-line", stdout)
+	}
+}
+
+func TestRunCLIRetriesOnTransientError(t *testing.T) {
+	dir := t.TempDir()
+	counterFile := dir + "/attempts"
+	fakeFirebaseCLI(t, `#!/bin/sh
+count=$(cat `+counterFile+` 2>/dev/null || echo 0)
+count=$((count + 1))
+echo $count > `+counterFile+`
+if [ "$count" -lt 2 ]; then
+  echo "connect ECONNRESET" >&2
+  exit 1
+fi
+echo ok
+`)
+
+	client := NewClient(false)
+	stdout, _, err := client.runCLI(context.Background(), []string{"anything"}, runOptions{MaxRetries: 2})
+	if err != nil {
+		t.Fatalf("runCLI() error = %v, want a successful retry", err)
+	}
+	if !strings.Contains(stdout, "ok") {
+		t.Errorf("stdout = %q, want it to contain ok after retrying", stdout)
+	}
+}
+
+func TestRunCLIDoesNotRetryNonTransientError(t *testing.T) {
+	dir := t.TempDir()
+	counterFile := dir + "/attempts"
+	fakeFirebaseCLI(t, `#!/bin/sh
+count=$(cat `+counterFile+` 2>/dev/null || echo 0)
+count=$((count + 1))
+echo $count > `+counterFile+`
+echo "permission denied" >&2
+exit 1
+`)
+
+	client := NewClient(false)
+	if _, _, err := client.runCLI(context.Background(), []string{"anything"}, runOptions{MaxRetries: 2}); err == nil {
+		t.Fatal("expected an error for a non-transient failure")
+	}
+
+	attempts, err := os.ReadFile(counterFile)
+	if err != nil {
+		t.Fatalf("failed to read attempts counter: %v", err)
+	}
+	if strings.TrimSpace(string(attempts)) != "1" {
+		t.Errorf("expected exactly 1 attempt, got %q", attempts)
+	}
+}
+
+func TestRunCLIKillsProcessOnContextCancellation(t *testing.T) {
+	fakeFirebaseCLI(t, "#!/bin/sh\nsleep 10\n")
+
+	client := NewClient(false)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		client.runCLI(ctx, []string{"anything"}, runOptions{})
+		close(done)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected runCLI() to return promptly after context cancellation")
+	}
+}
@@ -0,0 +1,289 @@
+package firebase
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/clix-so/nativefire/internal/apple"
+	"github.com/clix-so/nativefire/internal/ui"
+)
+
+// VariantIdentifier names one build variant - an Android product
+// flavor/build type, or an iOS/macOS XCBuildConfiguration - and the
+// applicationId/bundle ID Firebase should register it under.
+type VariantIdentifier struct {
+	Name       string
+	Identifier string
+}
+
+// allVariantsSentinel lets Config.Variants == []string{"all"} mean "every
+// variant this project declares" without a second field.
+const allVariantsSentinel = "all"
+
+// VariantResult is the outcome of registering (or linking) one build
+// variant's Firebase app - printed as a summary row by registerAppVariants
+// once every variant has been processed.
+type VariantResult struct {
+	Variant    string
+	Identifier string
+	AppID      string
+	ConfigPath string
+	Err        error
+}
+
+// registerAppVariants implements the config.Variants != nil path of
+// RegisterApp: one `firebase apps:create` per resolved variant, downloading
+// each variant's config file into its own conventional per-flavor path
+// (app/src/<flavor>/google-services.json, ios/<Configuration>/
+// GoogleService-Info.plist) instead of overwriting a single shared one.
+// RegisterApp's existing manual-creation fallback (see suggestManualCreation)
+// already prints a `firebase apps:create` command per variant run with
+// config.DryRun, and in verbose mode per variant whose creation fails as a
+// duplicate that cannot then be found; this prints the resulting
+// variant -> app ID -> config file table once everything else is done.
+func (c *Client) registerAppVariants(config *Config) error {
+	variants, err := detectVariants(config.Platform.Name())
+	if err != nil {
+		return fmt.Errorf("failed to detect build variants: %w", err)
+	}
+
+	variants = filterVariants(variants, config.Variants)
+	if len(variants) == 0 {
+		return fmt.Errorf("no build variants matched %v", config.Variants)
+	}
+
+	results := make([]VariantResult, 0, len(variants))
+	var errs []error
+	for _, variant := range variants {
+		result := c.registerAndDownloadVariant(config, variant)
+		results = append(results, result)
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", variant.Name, result.Err))
+		}
+	}
+
+	printVariantTable(os.Stdout, results)
+	return errors.Join(errs...)
+}
+
+// registerAndDownloadVariant registers variant.Identifier as its own
+// Firebase app (via a shallow copy of config so the caller's BundleID/
+// PackageName/AppID are untouched) and moves its downloaded config file
+// into variant's conventional path.
+func (c *Client) registerAndDownloadVariant(config *Config, variant VariantIdentifier) VariantResult {
+	result := VariantResult{Variant: variant.Name, Identifier: variant.Identifier}
+
+	variantConfig := *config
+	variantConfig.Variants = nil
+	variantConfig.AppID = ""
+	switch normalizePlatformName(config.Platform.Name()) {
+	case androidPlatform:
+		variantConfig.PackageName = variant.Identifier
+	case iosPlatform, macosPlatform:
+		variantConfig.BundleID = variant.Identifier
+	}
+
+	if err := c.RegisterApp(&variantConfig); err != nil {
+		result.Err = fmt.Errorf("failed to register app: %w", err)
+		return result
+	}
+	result.AppID = variantConfig.AppID
+
+	if err := c.DownloadConfig(&variantConfig); err != nil {
+		result.Err = fmt.Errorf("failed to download configuration: %w", err)
+		return result
+	}
+
+	targetPath := variantConfigPath(config.Platform.Name(), variant.Name)
+	if err := moveConfigFile(variantConfig.SourcePath, targetPath); err != nil {
+		result.Err = fmt.Errorf("failed to install configuration at %s: %w", targetPath, err)
+		return result
+	}
+
+	result.ConfigPath = targetPath
+	return result
+}
+
+// printVariantTable renders one row per variant - app ID and config path
+// are "-" for a variant whose registration or download failed, since
+// result.Err is already surfaced through registerAppVariants' joined error.
+func printVariantTable(out io.Writer, results []VariantResult) {
+	ui.InfoMsg("Build variants:")
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "VARIANT\tIDENTIFIER\tAPP ID\tCONFIG FILE\n")
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.Variant, result.Identifier, orDash(result.AppID), orDash(result.ConfigPath))
+	}
+	w.Flush()
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// variantConfigPath returns where a variant's downloaded config file
+// belongs: Android's per-flavor source set convention, or a
+// per-configuration subdirectory for iOS/macOS.
+func variantConfigPath(platformName, variantName string) string {
+	if normalizePlatformName(platformName) == androidPlatform {
+		return filepath.Join("app", "src", variantName, "google-services.json")
+	}
+	return filepath.Join("ios", variantName, "GoogleService-Info.plist")
+}
+
+func filterVariants(variants []VariantIdentifier, wanted []string) []VariantIdentifier {
+	for _, w := range wanted {
+		if strings.EqualFold(w, allVariantsSentinel) {
+			return variants
+		}
+	}
+
+	var kept []VariantIdentifier
+	for _, v := range variants {
+		for _, w := range wanted {
+			if strings.EqualFold(v.Name, w) {
+				kept = append(kept, v)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+func detectVariants(platformName string) ([]VariantIdentifier, error) {
+	switch normalizePlatformName(platformName) {
+	case androidPlatform:
+		return detectAndroidVariants(), nil
+	case iosPlatform, macosPlatform:
+		return detectIOSVariants(".")
+	default:
+		return nil, fmt.Errorf("variant-aware registration is not supported for %s", platformName)
+	}
+}
+
+var (
+	androidApplicationIDRe = regexp.MustCompile(`applicationId\s*=?\s*["']([^"']+)["']`)
+	androidFlavorEntryRe   = regexp.MustCompile(`(?:create\(\s*"(\w+)"\s*\)|(\w+))\s*\{([^{}]*)\}`)
+	androidSuffixRe        = regexp.MustCompile(`applicationIdSuffix\s*=?\s*["']([^"']+)["']`)
+)
+
+// androidBuildGradleFiles mirrors detectAndroidPackageName's candidate
+// search paths, plus their Kotlin DSL equivalents.
+var androidBuildGradleFiles = []string{
+	"app/build.gradle", "android/app/build.gradle", "build.gradle",
+	"app/build.gradle.kts", "android/app/build.gradle.kts", "build.gradle.kts",
+}
+
+// detectAndroidVariants parses the first app-level build.gradle[.kts] it
+// finds for a top-level applicationId plus a productFlavors {} block,
+// returning one VariantIdentifier per flavor with its
+// applicationIdSuffix (if any) appended to the base applicationId.
+func detectAndroidVariants() []VariantIdentifier {
+	for _, file := range androidBuildGradleFiles {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		if variants := parseAndroidVariants(string(content)); len(variants) > 0 {
+			return variants
+		}
+	}
+	return nil
+}
+
+func parseAndroidVariants(content string) []VariantIdentifier {
+	match := androidApplicationIDRe.FindStringSubmatch(content)
+	if match == nil {
+		return nil
+	}
+	baseID := match[1]
+
+	flavorsBlock := extractBracedBlock(content, "productFlavors")
+	if flavorsBlock == "" {
+		return nil
+	}
+
+	var variants []VariantIdentifier
+	for _, entry := range androidFlavorEntryRe.FindAllStringSubmatch(flavorsBlock, -1) {
+		name, body := entry[1], entry[3]
+		if name == "" {
+			name = entry[2]
+		}
+		identifier := baseID
+		if suffix := androidSuffixRe.FindStringSubmatch(body); suffix != nil {
+			identifier += suffix[1]
+		}
+		variants = append(variants, VariantIdentifier{Name: name, Identifier: identifier})
+	}
+	return variants
+}
+
+// extractBracedBlock returns the contents between the first "{" after name
+// and its matching "}", tracking brace depth so a nested flavor body (e.g.
+// each flavor's own { ... }) doesn't truncate the block early.
+func extractBracedBlock(content, name string) string {
+	idx := strings.Index(content, name)
+	if idx == -1 {
+		return ""
+	}
+
+	start := strings.Index(content[idx:], "{")
+	if start == -1 {
+		return ""
+	}
+	start += idx
+
+	depth := 0
+	for i := start; i < len(content); i++ {
+		switch content[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return content[start+1 : i]
+			}
+		}
+	}
+	return ""
+}
+
+// detectIOSVariants resolves every XCBuildConfiguration in the .xcodeproj
+// under projectDir (or its ios/ subdirectory) to a VariantIdentifier,
+// skipping any configuration whose PRODUCT_BUNDLE_IDENTIFIER doesn't fully
+// resolve (e.g. no xcconfig provides the referenced variable).
+func detectIOSVariants(projectDir string) ([]VariantIdentifier, error) {
+	pbxprojPath, err := apple.FindPbxproj(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := apple.ParseBuildConfigurations(pbxprojPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var variants []VariantIdentifier
+	for name, settings := range configs {
+		raw, ok := settings["PRODUCT_BUNDLE_IDENTIFIER"]
+		if !ok {
+			continue
+		}
+		resolved := apple.ResolveVariable(raw, settings)
+		if resolved == "" || strings.Contains(resolved, "$(") || strings.Contains(resolved, "${") {
+			continue
+		}
+		variants = append(variants, VariantIdentifier{Name: name, Identifier: resolved})
+	}
+	return variants, nil
+}
@@ -0,0 +1,191 @@
+package firebase
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExtractBracedBlockFindsMatchingClosingBrace(t *testing.T) {
+	content := `
+productFlavors {
+    dev {
+        applicationId "com.example.dev"
+    }
+    prod {
+        applicationId "com.example.prod"
+    }
+}
+`
+	block := extractBracedBlock(content, "productFlavors")
+	if block == "" {
+		t.Fatal("expected a non-empty block")
+	}
+	if !strings.Contains(block, `dev {`) || !strings.Contains(block, `prod {`) {
+		t.Errorf("block missing expected flavor entries: %q", block)
+	}
+}
+
+func TestExtractBracedBlockReturnsEmptyWhenNameMissing(t *testing.T) {
+	if block := extractBracedBlock("android { }", "productFlavors"); block != "" {
+		t.Errorf("expected empty block, got %q", block)
+	}
+}
+
+func TestParseAndroidVariantsAppliesSuffixes(t *testing.T) {
+	content := `
+android {
+    defaultConfig {
+        applicationId "com.example.app"
+    }
+    productFlavors {
+        dev {
+            applicationIdSuffix ".dev"
+        }
+        prod {
+        }
+    }
+}
+`
+	variants := parseAndroidVariants(content)
+	if len(variants) != 2 {
+		t.Fatalf("expected 2 variants, got %d: %+v", len(variants), variants)
+	}
+
+	byName := map[string]string{}
+	for _, v := range variants {
+		byName[v.Name] = v.Identifier
+	}
+	if byName["dev"] != "com.example.app.dev" {
+		t.Errorf("dev identifier = %q, want com.example.app.dev", byName["dev"])
+	}
+	if byName["prod"] != "com.example.app" {
+		t.Errorf("prod identifier = %q, want com.example.app", byName["prod"])
+	}
+}
+
+func TestParseAndroidVariantsReturnsNilWithoutFlavors(t *testing.T) {
+	content := `
+android {
+    defaultConfig {
+        applicationId "com.example.app"
+    }
+}
+`
+	if variants := parseAndroidVariants(content); variants != nil {
+		t.Errorf("expected nil variants, got %+v", variants)
+	}
+}
+
+func TestFilterVariantsAllSentinelReturnsEverything(t *testing.T) {
+	variants := []VariantIdentifier{{Name: "dev"}, {Name: "prod"}}
+	if kept := filterVariants(variants, []string{"all"}); len(kept) != 2 {
+		t.Errorf("expected all 2 variants kept, got %d", len(kept))
+	}
+}
+
+func TestFilterVariantsKeepsOnlyNamedVariants(t *testing.T) {
+	variants := []VariantIdentifier{{Name: "dev"}, {Name: "staging"}, {Name: "prod"}}
+	kept := filterVariants(variants, []string{"Prod"})
+	if len(kept) != 1 || kept[0].Name != "prod" {
+		t.Errorf("expected only prod kept, got %+v", kept)
+	}
+}
+
+func TestVariantConfigPathUsesAndroidSourceSetConvention(t *testing.T) {
+	path := variantConfigPath("android", "dev")
+	want := filepath.Join("app", "src", "dev", "google-services.json")
+	if path != want {
+		t.Errorf("variantConfigPath() = %q, want %q", path, want)
+	}
+}
+
+func TestVariantConfigPathUsesIOSConfigurationConvention(t *testing.T) {
+	path := variantConfigPath("ios", "Release")
+	want := filepath.Join("ios", "Release", "GoogleService-Info.plist")
+	if path != want {
+		t.Errorf("variantConfigPath() = %q, want %q", path, want)
+	}
+}
+
+func TestDetectIOSVariantsSkipsUnresolvedIdentifiers(t *testing.T) {
+	dir := t.TempDir()
+	xcodeprojDir := filepath.Join(dir, "Runner.xcodeproj")
+	if err := os.MkdirAll(xcodeprojDir, 0o755); err != nil {
+		t.Fatalf("failed to create .xcodeproj dir: %v", err)
+	}
+	pbxproj := `// !$*UTF8*$!
+{
+	archiveVersion = 1;
+	objects = {
+		/* Begin XCBuildConfiguration section */
+		1111111111111111111111AA /* Debug */ = {
+			isa = XCBuildConfiguration;
+			buildSettings = {
+				PRODUCT_BUNDLE_IDENTIFIER = "$(UNRESOLVED_VAR)";
+			};
+			name = Debug;
+		};
+		3333333333333333333333CC /* Release */ = {
+			isa = XCBuildConfiguration;
+			buildSettings = {
+				PRODUCT_BUNDLE_IDENTIFIER = "com.example.release";
+			};
+			name = Release;
+		};
+		/* End XCBuildConfiguration section */
+	};
+}
+`
+	if err := os.WriteFile(filepath.Join(xcodeprojDir, "project.pbxproj"), []byte(pbxproj), 0o644); err != nil {
+		t.Fatalf("failed to write pbxproj: %v", err)
+	}
+
+	variants, err := detectIOSVariants(dir)
+	if err != nil {
+		t.Fatalf("detectIOSVariants() error = %v", err)
+	}
+	if len(variants) != 1 || variants[0].Name != "Release" || variants[0].Identifier != "com.example.release" {
+		t.Errorf("detectIOSVariants() = %+v, want only a resolved Release variant", variants)
+	}
+}
+
+func TestDetectVariantsErrorsForUnsupportedPlatform(t *testing.T) {
+	if _, err := detectVariants("windows"); err == nil {
+		t.Fatal("expected an error for a platform without variant support")
+	}
+}
+
+func TestPrintVariantTableShowsDashForFailedVariants(t *testing.T) {
+	var buf bytes.Buffer
+	printVariantTable(&buf, []VariantResult{
+		{Variant: "dev", Identifier: "com.example.dev", AppID: "1:abc:android:dev", ConfigPath: "app/src/dev/google-services.json"},
+		{Variant: "prod", Identifier: "com.example.prod", Err: errors.New("registration failed")},
+	})
+
+	output := buf.String()
+	if !strings.Contains(output, "com.example.dev") || !strings.Contains(output, "1:abc:android:dev") {
+		t.Errorf("expected a row for the dev variant, got: %q", output)
+	}
+	var prodRow []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "com.example.prod") {
+			prodRow = strings.Fields(line)
+		}
+	}
+	if strings.Join(prodRow, " ") != "prod com.example.prod - -" {
+		t.Errorf("expected the failed prod variant's app ID/config path rendered as -, got: %q", output)
+	}
+}
+
+func TestOrDashReturnsPlaceholderForEmptyString(t *testing.T) {
+	if got := orDash(""); got != "-" {
+		t.Errorf("orDash(\"\") = %q, want -", got)
+	}
+	if got := orDash("com.example.app"); got != "com.example.app" {
+		t.Errorf("orDash(%q) = %q, want it unchanged", "com.example.app", got)
+	}
+}
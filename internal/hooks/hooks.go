@@ -0,0 +1,126 @@
+// Package hooks loads the pre/post command hooks a project declares in
+// .nativefire.yaml and runs them around `nativefire configure`'s
+// InstallConfig/AddInitializationCode stages. The shape mirrors Helm's
+// PlatformHooks: each event resolves to a list of OS/arch-qualified
+// candidate commands, with an unqualified entry acting as the fallback —
+// so a single manifest can declare `pod install` for darwin and
+// `./gradlew processDebugGoogleServices` for linux/windows without the CLI
+// needing platform-specific flags.
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/clix-so/nativefire/internal/ui"
+	"gopkg.in/yaml.v3"
+)
+
+// Event names for the hook points runConfigure wires up, one pair bracketing
+// each of the two platform steps that can benefit from a follow-up command.
+const (
+	PreInstallConfig  = "pre_install_config"
+	PostInstallConfig = "post_install_config"
+	PreAddInit        = "pre_add_init"
+	PostAddInit       = "post_add_init"
+)
+
+// ManifestFileName is the hooks manifest runConfigure looks for next to the
+// project root.
+const ManifestFileName = ".nativefire.yaml"
+
+// Command is a single platform-specific (or fallback, when OS is empty)
+// candidate command for a hook.
+type Command struct {
+	OS      string   `yaml:"os,omitempty"`
+	Arch    string   `yaml:"arch,omitempty"`
+	Command []string `yaml:"command"`
+}
+
+// Manifest is the set of hooks declared in .nativefire.yaml, keyed by event
+// name.
+type Manifest struct {
+	Hooks map[string][]Command `yaml:"hooks"`
+}
+
+// Load reads and parses a hooks manifest at path. A missing file is not an
+// error, since hooks are opt-in: it returns a nil Manifest so Run becomes a
+// no-op.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return &manifest, nil
+}
+
+// Run resolves and executes the command registered for event on the current
+// OS/arch, if any. It no-ops if manifest is nil or declares no candidates
+// for event.
+func (m *Manifest) Run(event string) error {
+	if m == nil {
+		return nil
+	}
+
+	command, err := PrepareCommands(m.Hooks[event])
+	if err != nil {
+		return fmt.Errorf("%s hook: %w", event, err)
+	}
+	if len(command) == 0 {
+		return nil
+	}
+
+	ui.InfoMsg(fmt.Sprintf("Running %s hook: %s", event, strings.Join(command, " ")))
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s hook failed: %w", event, err)
+	}
+
+	return nil
+}
+
+// PrepareCommands resolves candidates to the argv that should run on the
+// current OS/arch, following Helm's PlatformCommand fallback rule: the
+// first candidate whose OS (and Arch, if set) matches runtime.GOOS/GOARCH
+// wins; otherwise the first candidate with no OS set is used as the
+// platform-independent default. It returns nil if no candidate matches and
+// none is unqualified.
+func PrepareCommands(candidates []Command) ([]string, error) {
+	var fallback []string
+
+	for _, c := range candidates {
+		if len(c.Command) == 0 {
+			continue
+		}
+		if c.OS == "" {
+			if fallback == nil {
+				fallback = c.Command
+			}
+			continue
+		}
+		if c.OS != runtime.GOOS {
+			continue
+		}
+		if c.Arch != "" && c.Arch != runtime.GOARCH {
+			continue
+		}
+		return c.Command, nil
+	}
+
+	return fallback, nil
+}
@@ -0,0 +1,129 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLoadMissingFile(t *testing.T) {
+	manifest, err := Load(filepath.Join(t.TempDir(), "does-not-exist.yaml"))
+	if err != nil {
+		t.Fatalf("Unexpected error for missing file: %v", err)
+	}
+	if manifest != nil {
+		t.Errorf("Expected nil manifest for missing file, got %+v", manifest)
+	}
+}
+
+func TestLoadValidManifest(t *testing.T) {
+	content := `
+hooks:
+  post_install_config:
+    - os: darwin
+      command: [pod, install]
+    - os: linux
+      command: [./gradlew, processDebugGoogleServices]
+    - command: [echo, done]
+`
+	path := filepath.Join(t.TempDir(), ".nativefire.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test manifest: %v", err)
+	}
+
+	manifest, err := Load(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if manifest == nil {
+		t.Fatal("Expected a manifest, got nil")
+	}
+
+	candidates := manifest.Hooks[PostInstallConfig]
+	if len(candidates) != 3 {
+		t.Fatalf("Expected 3 candidates, got %d", len(candidates))
+	}
+}
+
+func TestPrepareCommands(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []Command
+		expected   []string
+	}{
+		{
+			name: "matches current OS",
+			candidates: []Command{
+				{OS: "never-matches-anything", Command: []string{"skip"}},
+				{OS: runtime.GOOS, Command: []string{"echo", "matched"}},
+			},
+			expected: []string{"echo", "matched"},
+		},
+		{
+			name: "falls back to unqualified command",
+			candidates: []Command{
+				{OS: "never-matches-anything", Command: []string{"skip"}},
+				{Command: []string{"echo", "fallback"}},
+			},
+			expected: []string{"echo", "fallback"},
+		},
+		{
+			name: "arch mismatch is skipped",
+			candidates: []Command{
+				{OS: runtime.GOOS, Arch: "never-matches-anything", Command: []string{"skip"}},
+				{Command: []string{"echo", "fallback"}},
+			},
+			expected: []string{"echo", "fallback"},
+		},
+		{
+			name:       "no candidates",
+			candidates: nil,
+			expected:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PrepareCommands(tt.candidates)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if strings.Join(result, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestManifestRunNoOp(t *testing.T) {
+	var manifest *Manifest
+	if err := manifest.Run(PreInstallConfig); err != nil {
+		t.Errorf("Expected nil manifest Run to no-op, got %v", err)
+	}
+
+	manifest = &Manifest{}
+	if err := manifest.Run(PreInstallConfig); err != nil {
+		t.Errorf("Expected Run with no matching hook to no-op, got %v", err)
+	}
+}
+
+func TestManifestRunExecutesCommand(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "ran")
+	manifest := &Manifest{
+		Hooks: map[string][]Command{
+			PostAddInit: {
+				{Command: []string{"touch", marker}},
+			},
+		},
+	}
+
+	if err := manifest.Run(PostAddInit); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("Expected hook command to have run and created %s: %v", marker, err)
+	}
+}
@@ -1,58 +1,162 @@
+// Package logger provides a small leveled, structured logger with pluggable
+// sinks. It replaces bare fmt.Println calls with something that can render
+// human-readable lines to a TTY and JSON records to a file or CI log at the
+// same time, so a failed run can be replayed from its structured events
+// instead of scrollback.
 package logger
 
 import (
 	"fmt"
 	"os"
+	"strings"
+	"sync"
+	"time"
 )
 
-type Logger struct {
-	verbose bool
+// Level is a log severity, ordered so a Logger can filter out anything below
+// its configured threshold.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the lowercase name used in both --log-level values and JSON
+// output.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
 }
 
-func New(verbose bool) *Logger {
-	return &Logger{verbose: verbose}
+// ParseLevel parses a --log-level flag value (case-insensitive).
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return LevelDebug, nil
+	case "info", "":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level %q (want debug, info, warn, or error)", s)
+	}
 }
 
-func (l *Logger) Info(msg string) {
-	fmt.Println(msg)
+// Field is a single piece of structured context attached to a log event,
+// e.g. logger.F("duration_ms", 42).
+type Field struct {
+	Key   string
+	Value any
 }
 
-func (l *Logger) Infof(format string, args ...interface{}) {
-	fmt.Printf(format+"\n", args...)
+// F builds a Field. Short name because call sites tend to pass several.
+func F(key string, value any) Field {
+	return Field{Key: key, Value: value}
 }
 
-func (l *Logger) Debug(msg string) {
-	if l.verbose {
-		fmt.Printf("[DEBUG] %s\n", msg)
-	}
+// Event is one emitted log record, handed to every configured Sink.
+type Event struct {
+	Time    time.Time
+	Level   Level
+	Kind    string // "debug", "info", "warn", "error", or "success" (Info severity, checkmark presentation)
+	Message string
+	Fields  []Field
 }
 
-func (l *Logger) Debugf(format string, args ...interface{}) {
-	if l.verbose {
-		fmt.Printf("[DEBUG] "+format+"\n", args...)
-	}
+// Sink renders or stores an Event. Implementations must be safe for
+// concurrent use, since a Logger may be shared across goroutines via With.
+type Sink interface {
+	Write(event Event) error
 }
 
-func (l *Logger) Warn(msg string) {
-	fmt.Printf("⚠️  %s\n", msg)
+// Logger is a leveled logger that fans each event out to its sinks. Fields
+// attached via With are carried into every subsequent call, so a caller can
+// build up structured context (e.g. component, request ID) without threading
+// it through every log line.
+type Logger struct {
+	level  Level
+	sinks  []Sink
+	fields []Field
 }
 
-func (l *Logger) Warnf(format string, args ...interface{}) {
-	fmt.Printf("⚠️  "+format+"\n", args...)
+// New builds a Logger at the given level. With no sinks it defaults to a
+// human-readable console sink on stderr, matching this package's previous
+// behavior.
+func New(level Level, sinks ...Sink) *Logger {
+	if len(sinks) == 0 {
+		sinks = []Sink{NewConsoleSink(os.Stderr)}
+	}
+	return &Logger{level: level, sinks: sinks}
 }
 
-func (l *Logger) Error(msg string) {
-	fmt.Fprintf(os.Stderr, "❌ %s\n", msg)
+// With returns a copy of the Logger carrying the given fields in addition to
+// any it already has.
+func (l *Logger) With(fields ...Field) *Logger {
+	merged := make([]Field, 0, len(l.fields)+len(fields))
+	merged = append(merged, l.fields...)
+	merged = append(merged, fields...)
+	return &Logger{level: l.level, sinks: l.sinks, fields: merged}
 }
 
-func (l *Logger) Errorf(format string, args ...interface{}) {
-	fmt.Fprintf(os.Stderr, "❌ "+format+"\n", args...)
+func (l *Logger) emit(level Level, kind, msg string, fields ...Field) {
+	if level < l.level {
+		return
+	}
+	merged := make([]Field, 0, len(l.fields)+len(fields))
+	merged = append(merged, l.fields...)
+	merged = append(merged, fields...)
+	event := Event{
+		Time:    time.Now(),
+		Level:   level,
+		Kind:    kind,
+		Message: msg,
+		Fields:  merged,
+	}
+	for _, sink := range l.sinks {
+		if err := sink.Write(event); err != nil {
+			fmt.Fprintf(os.Stderr, "logger: sink write failed: %v\n", err)
+		}
+	}
 }
 
-func (l *Logger) Success(msg string) {
-	fmt.Printf("✅ %s\n", msg)
+func (l *Logger) Debug(msg string, fields ...Field)   { l.emit(LevelDebug, "debug", msg, fields...) }
+func (l *Logger) Info(msg string, fields ...Field)    { l.emit(LevelInfo, "info", msg, fields...) }
+func (l *Logger) Warn(msg string, fields ...Field)    { l.emit(LevelWarn, "warn", msg, fields...) }
+func (l *Logger) Error(msg string, fields ...Field)   { l.emit(LevelError, "error", msg, fields...) }
+func (l *Logger) Success(msg string, fields ...Field) { l.emit(LevelInfo, "success", msg, fields...) }
+
+var (
+	defaultMu     sync.RWMutex
+	defaultLogger = New(LevelInfo)
+)
+
+// Default returns the process-wide Logger. cmd configures this once at
+// startup from --log-level/--log-file/--log-format; everything else should
+// just call logger.Default() (or With it) rather than building its own.
+func Default() *Logger {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultLogger
 }
 
-func (l *Logger) Successf(format string, args ...interface{}) {
-	fmt.Printf("✅ "+format+"\n", args...)
+// SetDefault replaces the process-wide Logger returned by Default.
+func SetDefault(l *Logger) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultLogger = l
 }
@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Level
+		wantErr bool
+	}{
+		{"debug", LevelDebug, false},
+		{"info", LevelInfo, false},
+		{"", LevelInfo, false},
+		{"warn", LevelWarn, false},
+		{"warning", LevelWarn, false},
+		{"error", LevelError, false},
+		{"verbose", LevelInfo, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLevel(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLevel(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLoggerFiltersBelowLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LevelWarn, NewConsoleSink(&buf))
+
+	l.Debug("debug message")
+	l.Info("info message")
+	l.Warn("warn message")
+	l.Error("error message")
+
+	out := buf.String()
+	if strings.Contains(out, "debug message") || strings.Contains(out, "info message") {
+		t.Errorf("expected debug/info to be filtered out at Warn level, got: %q", out)
+	}
+	if !strings.Contains(out, "warn message") || !strings.Contains(out, "error message") {
+		t.Errorf("expected warn/error to be logged at Warn level, got: %q", out)
+	}
+}
+
+func TestLoggerWithMergesFields(t *testing.T) {
+	var buf bytes.Buffer
+	base := New(LevelInfo, NewJSONSink(&buf))
+	scoped := base.With(F("component", "firebase"))
+	scoped.Info("hello", F("app_id", "123"))
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("failed to parse JSON log line: %v", err)
+	}
+	if record["component"] != "firebase" {
+		t.Errorf("expected With() field to carry through, got: %v", record)
+	}
+	if record["app_id"] != "123" {
+		t.Errorf("expected per-call field to be present, got: %v", record)
+	}
+	if record["message"] != "hello" {
+		t.Errorf("expected message field, got: %v", record)
+	}
+}
+
+func TestConsoleSinkFormatsFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LevelInfo, NewConsoleSink(&buf))
+	l.Success("done", F("duration_ms", 42))
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "✅") {
+		t.Errorf("expected success message to start with a checkmark, got: %q", out)
+	}
+	if !strings.Contains(out, "duration_ms=42") {
+		t.Errorf("expected field to be rendered inline, got: %q", out)
+	}
+}
+
+func TestJSONSinkProducesValidJSON(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LevelInfo, NewJSONSink(&buf))
+	l.Error("boom", F("exit_code", 1))
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected valid JSON, got error: %v, line: %q", err, buf.String())
+	}
+	if record["level"] != "error" {
+		t.Errorf("expected level=error, got: %v", record["level"])
+	}
+}
+
+func TestRotatingFileSinkRotatesOnSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nativefire.log")
+
+	sink, err := NewRotatingFileSink(path, 64)
+	if err != nil {
+		t.Fatalf("NewRotatingFileSink() error = %v", err)
+	}
+	defer sink.Close()
+
+	l := New(LevelInfo, sink)
+	for i := 0; i < 10; i++ {
+		l.Info("a reasonably long log line to force rotation", F("i", i))
+	}
+
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Errorf("expected rotated file %s.1 to exist, got error: %v", path, err)
+	}
+	// Every record here is longer than the 64-byte limit, so each write
+	// rotates and the current file holds only the last record.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read current log file: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 1 {
+		t.Errorf("expected current log file to hold one record after rotation, got %d: %q", lines, data)
+	}
+}
+
+func TestDefaultLoggerRoundTrip(t *testing.T) {
+	original := Default()
+	defer SetDefault(original)
+
+	var buf bytes.Buffer
+	SetDefault(New(LevelInfo, NewConsoleSink(&buf)))
+	Default().Info("from default")
+
+	if !strings.Contains(buf.String(), "from default") {
+		t.Errorf("expected SetDefault/Default round trip to log through the new logger, got: %q", buf.String())
+	}
+}
@@ -0,0 +1,166 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// eventRecord flattens an Event into a JSON-friendly map, used by both
+// JSONSink and RotatingFileSink so the two stay byte-for-byte consistent.
+func eventRecord(event Event) map[string]any {
+	record := map[string]any{
+		"time":    event.Time.Format("2006-01-02T15:04:05.000Z07:00"),
+		"level":   event.Level.String(),
+		"kind":    event.Kind,
+		"message": event.Message,
+	}
+	for _, field := range event.Fields {
+		record[field.Key] = field.Value
+	}
+	return record
+}
+
+// consoleGlyph picks the emoji/prefix the previous ad-hoc logger used,
+// keeping the human-readable output familiar.
+func consoleGlyph(kind string) string {
+	switch kind {
+	case "success":
+		return "✅"
+	case "warn":
+		return "⚠️ "
+	case "error":
+		return "❌"
+	case "debug":
+		return "[DEBUG]"
+	default:
+		return "💡"
+	}
+}
+
+// ConsoleSink renders events as a single human-readable line, the form meant
+// for an interactive TTY.
+type ConsoleSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewConsoleSink builds a ConsoleSink writing to w.
+func NewConsoleSink(w io.Writer) *ConsoleSink {
+	return &ConsoleSink{w: w}
+}
+
+func (s *ConsoleSink) Write(event Event) error {
+	line := fmt.Sprintf("%s %s", consoleGlyph(event.Kind), event.Message)
+	for _, field := range event.Fields {
+		line += fmt.Sprintf(" %s=%v", field.Key, field.Value)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := fmt.Fprintln(s.w, line)
+	return err
+}
+
+// JSONSink renders events as newline-delimited JSON, the form meant for CI
+// logs where stdout is captured verbatim.
+type JSONSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONSink builds a JSONSink writing to w.
+func NewJSONSink(w io.Writer) *JSONSink {
+	return &JSONSink{w: w}
+}
+
+func (s *JSONSink) Write(event Event) error {
+	data, err := json.Marshal(eventRecord(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal log event: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = fmt.Fprintln(s.w, string(data))
+	return err
+}
+
+// defaultMaxFileBytes is the rotation threshold used when NewRotatingFileSink
+// is given maxBytes <= 0.
+const defaultMaxFileBytes = 10 * 1024 * 1024 // 10 MiB
+
+// RotatingFileSink writes newline-delimited JSON to a file, rotating it to
+// path+".1" (overwriting any previous rotation) once it grows past maxBytes.
+// This keeps a long-running CI job's log file bounded without needing an
+// external log rotation tool.
+type RotatingFileSink struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	file     *os.File
+	size     int64
+}
+
+// NewRotatingFileSink opens (creating if needed) a rotating JSON log file at
+// path.
+func NewRotatingFileSink(path string, maxBytes int64) (*RotatingFileSink, error) {
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxFileBytes
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file %s: %w", path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat log file %s: %w", path, err)
+	}
+	return &RotatingFileSink{path: path, maxBytes: maxBytes, file: file, size: info.Size()}, nil
+}
+
+func (s *RotatingFileSink) Write(event Event) error {
+	data, err := json.Marshal(eventRecord(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal log event: %w", err)
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size+int64(len(data)) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(data)
+	s.size += int64(n)
+	return err
+}
+
+// rotate must be called with s.mu held.
+func (s *RotatingFileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file %s for rotation: %w", s.path, err)
+	}
+	rotatedPath := s.path + ".1"
+	if err := os.Rename(s.path, rotatedPath); err != nil {
+		return fmt.Errorf("failed to rotate log file %s: %w", s.path, err)
+	}
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to reopen log file %s after rotation: %w", s.path, err)
+	}
+	s.file = file
+	s.size = 0
+	return nil
+}
+
+// Close closes the underlying log file.
+func (s *RotatingFileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
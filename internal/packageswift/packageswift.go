@@ -0,0 +1,198 @@
+// Package packageswift does targeted, idempotent edits to a Swift Package
+// Manager manifest (Package.swift), mirroring the approach internal/xcodeproj
+// and internal/sourceedit take with project.pbxproj and AppDelegate sources:
+// not a full Swift parser, just enough structural awareness — matching
+// parens/brackets while skipping string literals — to locate the manifest's
+// `dependencies: [...]` array and a named target's `.target(...)` call and
+// extend them in place.
+package packageswift
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EnsurePackageDependency inserts a `.package(url: repoURL, from: version)`
+// entry into the manifest's top-level dependencies: array, unless repoURL is
+// already registered. Returns the updated content and whether a change was
+// made.
+func EnsurePackageDependency(content, repoURL, version string) (string, bool, error) {
+	if strings.Contains(content, fmt.Sprintf(`url: "%s"`, repoURL)) {
+		return content, false, nil
+	}
+
+	insertAt, err := dependenciesArrayOpen(content, 0)
+	if err != nil {
+		return content, false, err
+	}
+
+	entry := fmt.Sprintf("\n        .package(url: \"%s\", from: \"%s\"),", repoURL, version)
+	return content[:insertAt] + entry + content[insertAt:], true, nil
+}
+
+// EnsureTargetProductDependencies inserts a
+// `.product(name: product, package: packageName)` entry for each product in
+// products into the named target's dependencies: array, skipping ones
+// already present. It creates the dependencies: array if the target doesn't
+// have one yet. Returns the updated content and whether a change was made.
+func EnsureTargetProductDependencies(content, targetName, packageName string, products []string) (string, bool, error) {
+	targetOpen, targetClose, err := findTarget(content, targetName)
+	if err != nil {
+		return content, false, err
+	}
+
+	block := content[targetOpen:targetClose]
+	var missing []string
+	for _, product := range products {
+		entry := fmt.Sprintf(`.product(name: "%s", package: "%s")`, product, packageName)
+		if !strings.Contains(block, entry) {
+			missing = append(missing, product)
+		}
+	}
+	if len(missing) == 0 {
+		return content, false, nil
+	}
+
+	entries := renderProductEntries(missing, packageName)
+
+	if relIdx := strings.Index(block, "dependencies:"); relIdx != -1 {
+		insertAt, err := dependenciesArrayOpen(content, targetOpen+relIdx)
+		if err != nil {
+			return content, false, err
+		}
+		return content[:insertAt] + "\n" + entries + content[insertAt:], true, nil
+	}
+
+	insertAt := targetOpen + 1
+	block = fmt.Sprintf("\n            dependencies: [\n%s\n            ],", entries)
+	return content[:insertAt] + block + content[insertAt:], true, nil
+}
+
+// FirstTargetName returns the name of the manifest's first `.target(...)`
+// or `.executableTarget(...)` declaration — the app target for a
+// single-target SPM-based app.
+func FirstTargetName(content string) (string, error) {
+	for _, marker := range []string{".target(", ".executableTarget("} {
+		idx := strings.Index(content, marker)
+		if idx == -1 {
+			continue
+		}
+		nameIdx := strings.Index(content[idx:], "name:")
+		if nameIdx == -1 {
+			continue
+		}
+		rest := content[idx+nameIdx+len("name:"):]
+		start := strings.Index(rest, `"`)
+		if start == -1 {
+			continue
+		}
+		end := strings.Index(rest[start+1:], `"`)
+		if end == -1 {
+			continue
+		}
+		return rest[start+1 : start+1+end], nil
+	}
+	return "", fmt.Errorf("could not find a .target(...) or .executableTarget(...) declaration in Package.swift")
+}
+
+// findTarget locates the named target's `.target(...)`/`.executableTarget(...)`
+// call — not just any `name: "targetName"` occurrence, since the manifest's
+// top-level Package(name:) and products: entries can share the same string
+// — and returns the byte offsets of its opening and matching closing
+// parenthesis.
+func findTarget(content, targetName string) (int, int, error) {
+	marker := fmt.Sprintf(`name: "%s"`, targetName)
+	searchFrom := 0
+
+	for {
+		relIdx := strings.Index(content[searchFrom:], marker)
+		if relIdx == -1 {
+			return 0, 0, fmt.Errorf("could not find a target named %q in Package.swift", targetName)
+		}
+		nameIdx := searchFrom + relIdx
+		searchFrom = nameIdx + len(marker)
+
+		openParen := strings.LastIndex(content[:nameIdx], "(")
+		if openParen == -1 {
+			continue
+		}
+		before := strings.TrimRight(content[:openParen], " \t\n")
+		if !strings.HasSuffix(before, ".target") && !strings.HasSuffix(before, ".executableTarget") {
+			continue
+		}
+
+		closeParen, err := matchingBracket(content, openParen)
+		if err != nil {
+			return 0, 0, fmt.Errorf("could not find the closing ) for target %q: %w", targetName, err)
+		}
+		return openParen, closeParen, nil
+	}
+}
+
+// dependenciesArrayOpen returns the byte offset just inside the opening "["
+// of the first `dependencies:` array literal at or after from.
+func dependenciesArrayOpen(content string, from int) (int, error) {
+	relIdx := strings.Index(content[from:], "dependencies:")
+	if relIdx == -1 {
+		return 0, fmt.Errorf("could not find a dependencies: array in Package.swift")
+	}
+	idx := from + relIdx
+
+	bracketIdx := strings.Index(content[idx:], "[")
+	if bracketIdx == -1 {
+		return 0, fmt.Errorf("could not find the dependencies: array's opening bracket")
+	}
+	return idx + bracketIdx + 1, nil
+}
+
+// renderProductEntries formats products as `.product(name:package:)` lines
+// indented to match a target's dependencies: array.
+func renderProductEntries(products []string, packageName string) string {
+	var b strings.Builder
+	for _, product := range products {
+		b.WriteString(fmt.Sprintf("            .product(name: \"%s\", package: \"%s\"),\n", product, packageName))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// matchingBracket returns the offset of the bracket that closes the one at
+// openIdx — "(" with ")" or "[" with "]" — skipping bracket characters that
+// appear inside string literals.
+func matchingBracket(content string, openIdx int) (int, error) {
+	open := content[openIdx]
+	var closeCh byte
+	switch open {
+	case '(':
+		closeCh = ')'
+	case '[':
+		closeCh = ']'
+	default:
+		return 0, fmt.Errorf("byte at %d is not an opening bracket", openIdx)
+	}
+
+	depth := 0
+	i, n := openIdx, len(content)
+	for i < n {
+		switch {
+		case content[i] == '"':
+			i++
+			for i < n && content[i] != '"' {
+				if content[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i++
+			continue
+		case content[i] == open:
+			depth++
+		case content[i] == closeCh:
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+		i++
+	}
+	return 0, fmt.Errorf("unbalanced brackets starting at offset %d", openIdx)
+}
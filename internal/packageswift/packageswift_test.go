@@ -0,0 +1,127 @@
+package packageswift
+
+import (
+	"strings"
+	"testing"
+)
+
+const samplePackageSwift = `// swift-tools-version:5.7
+import PackageDescription
+
+let package = Package(
+    name: "MyApp",
+    platforms: [
+        .iOS(.v13)
+    ],
+    products: [
+        .library(name: "MyApp", targets: ["MyApp"])
+    ],
+    dependencies: [
+    ],
+    targets: [
+        .target(
+            name: "MyApp",
+            dependencies: []
+        )
+    ]
+)
+`
+
+func TestEnsurePackageDependency(t *testing.T) {
+	updated, changed, err := EnsurePackageDependency(samplePackageSwift, "https://github.com/firebase/firebase-ios-sdk", "10.24.0")
+	if err != nil {
+		t.Fatalf("EnsurePackageDependency() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected EnsurePackageDependency() to report a change")
+	}
+	if !strings.Contains(updated, `.package(url: "https://github.com/firebase/firebase-ios-sdk", from: "10.24.0")`) {
+		t.Errorf("expected package dependency to be inserted, got:\n%s", updated)
+	}
+}
+
+func TestEnsurePackageDependencyIsIdempotent(t *testing.T) {
+	once, _, err := EnsurePackageDependency(samplePackageSwift, "https://github.com/firebase/firebase-ios-sdk", "10.24.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	twice, changed, err := EnsurePackageDependency(once, "https://github.com/firebase/firebase-ios-sdk", "10.24.0")
+	if err != nil {
+		t.Fatalf("second EnsurePackageDependency() error = %v", err)
+	}
+	if changed {
+		t.Error("expected second EnsurePackageDependency() to report no change")
+	}
+	if once != twice {
+		t.Error("expected second EnsurePackageDependency() to leave content untouched")
+	}
+}
+
+func TestEnsureTargetProductDependencies(t *testing.T) {
+	updated, changed, err := EnsureTargetProductDependencies(samplePackageSwift, "MyApp", "firebase-ios-sdk", []string{"FirebaseCore", "FirebaseMessaging"})
+	if err != nil {
+		t.Fatalf("EnsureTargetProductDependencies() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected EnsureTargetProductDependencies() to report a change")
+	}
+	for _, want := range []string{
+		`.product(name: "FirebaseCore", package: "firebase-ios-sdk")`,
+		`.product(name: "FirebaseMessaging", package: "firebase-ios-sdk")`,
+	} {
+		if !strings.Contains(updated, want) {
+			t.Errorf("expected %q in updated manifest, got:\n%s", want, updated)
+		}
+	}
+}
+
+func TestEnsureTargetProductDependenciesIsIdempotent(t *testing.T) {
+	once, _, err := EnsureTargetProductDependencies(samplePackageSwift, "MyApp", "firebase-ios-sdk", []string{"FirebaseCore"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	twice, changed, err := EnsureTargetProductDependencies(once, "MyApp", "firebase-ios-sdk", []string{"FirebaseCore"})
+	if err != nil {
+		t.Fatalf("second EnsureTargetProductDependencies() error = %v", err)
+	}
+	if changed {
+		t.Error("expected second EnsureTargetProductDependencies() to report no change")
+	}
+	if once != twice {
+		t.Error("expected second EnsureTargetProductDependencies() to leave content untouched")
+	}
+}
+
+func TestEnsureTargetProductDependenciesMissingTarget(t *testing.T) {
+	if _, _, err := EnsureTargetProductDependencies(samplePackageSwift, "DoesNotExist", "firebase-ios-sdk", []string{"FirebaseCore"}); err == nil {
+		t.Error("expected an error for a target that doesn't exist")
+	}
+}
+
+func TestEnsureTargetProductDependenciesIgnoresPackageLevelNameCollision(t *testing.T) {
+	// samplePackageSwift's package name and products: entry are also
+	// "MyApp" — make sure we edit the .target(...) block, not the
+	// enclosing Package(...) call or the products: array.
+	updated, _, err := EnsureTargetProductDependencies(samplePackageSwift, "MyApp", "firebase-ios-sdk", []string{"FirebaseCore"})
+	if err != nil {
+		t.Fatalf("EnsureTargetProductDependencies() error = %v", err)
+	}
+
+	targetIdx := strings.Index(updated, ".target(")
+	productIdx := strings.Index(updated, `.product(name: "FirebaseCore"`)
+	if targetIdx == -1 || productIdx == -1 || productIdx < targetIdx {
+		t.Errorf("expected the Firebase product to be inserted inside .target(...), got:\n%s", updated)
+	}
+}
+
+func TestFirstTargetName(t *testing.T) {
+	name, err := FirstTargetName(samplePackageSwift)
+	if err != nil {
+		t.Fatalf("FirstTargetName() error = %v", err)
+	}
+	if name != "MyApp" {
+		t.Errorf("expected target name MyApp, got %s", name)
+	}
+}
@@ -0,0 +1,138 @@
+// Package packaging builds distributable OS packages (deb, rpm, apk, Arch)
+// that carry the Firebase config file a `nativefire configure` run already
+// installed, via nfpm. Only those four nfpm packagers are registered here:
+// DefaultFormats returns msi for Windows and pkg for macOS, and Build fails
+// with an unsupported-format error for both.
+package packaging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	_ "github.com/goreleaser/nfpm/v2/apk"
+	_ "github.com/goreleaser/nfpm/v2/arch"
+	_ "github.com/goreleaser/nfpm/v2/deb"
+	_ "github.com/goreleaser/nfpm/v2/rpm"
+
+	"github.com/clix-so/nativefire/internal/platform"
+	"github.com/goreleaser/nfpm/v2"
+	"github.com/goreleaser/nfpm/v2/files"
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest describes the package metadata read from nativefire.yaml.
+type Manifest struct {
+	Name         string   `yaml:"name"`
+	Version      string   `yaml:"version"`
+	Maintainer   string   `yaml:"maintainer"`
+	Description  string   `yaml:"description"`
+	Dependencies []string `yaml:"dependencies"`
+	Formats      []string `yaml:"formats"`
+}
+
+// LoadManifest reads and parses a nativefire.yaml package manifest.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if manifest.Name == "" {
+		return nil, fmt.Errorf("%s is missing a required 'name' field", path)
+	}
+	if manifest.Version == "" {
+		manifest.Version = "0.0.0"
+	}
+
+	return &manifest, nil
+}
+
+// DefaultFormats returns the package formats nativefire builds for a
+// platform when --formats is not specified on the CLI or in the manifest.
+func DefaultFormats(p platform.Platform) []string {
+	switch p.Type() {
+	case platform.Linux:
+		return []string{"deb", "rpm", "apk", "archlinux"}
+	case platform.Windows:
+		return []string{"msi"}
+	case platform.MacOS:
+		return []string{"pkg"}
+	default:
+		return nil
+	}
+}
+
+// Build packages the Firebase config `configure` already installed for p
+// into a single package of the given format, dropping it at
+// p.PackagePaths() inside the package and registering a postinstall hook
+// that refreshes it via the Firebase CLI.
+func Build(p platform.Platform, manifest *Manifest, format string) (string, error) {
+	destination := p.PackagePaths(manifest.Name)
+	if destination == "" {
+		return "", fmt.Errorf("%s apps are not distributed as OS packages", p.Name())
+	}
+
+	postInstall, err := writePostInstallScript(manifest, destination)
+	if err != nil {
+		return "", err
+	}
+
+	info := &nfpm.Info{
+		Name:        manifest.Name,
+		Version:     manifest.Version,
+		Maintainer:  manifest.Maintainer,
+		Description: manifest.Description,
+		Overridables: nfpm.Overridables{
+			Depends: manifest.Dependencies,
+			Contents: files.Contents{
+				&files.Content{
+					Source:      filepath.Join(p.ConfigPath(), p.ConfigFileName()),
+					Destination: destination,
+					FileInfo:    &files.ContentFileInfo{Mode: 0644},
+				},
+			},
+			Scripts: nfpm.Scripts{
+				PostInstall: postInstall,
+			},
+		},
+	}
+
+	packager, err := nfpm.Get(format)
+	if err != nil {
+		return "", fmt.Errorf("unsupported package format %q: %w", format, err)
+	}
+
+	info = nfpm.WithDefaults(info)
+	outPath := packager.ConventionalFileName(info)
+
+	out, err := os.Create(outPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", outPath, err)
+	}
+	defer out.Close()
+
+	if err := packager.Package(info, out); err != nil {
+		return "", fmt.Errorf("failed to build %s package: %w", format, err)
+	}
+
+	return outPath, nil
+}
+
+// writePostInstallScript writes a postinstall hook that refreshes the
+// Firebase config at destination via the Firebase CLI after the package is
+// installed, returning the script's path for nfpm to embed.
+func writePostInstallScript(manifest *Manifest, destination string) (string, error) {
+	script := fmt.Sprintf("#!/bin/sh\nset -e\nfirebase apps:sdkconfig > %q || true\n", destination)
+
+	scriptPath := filepath.Join(os.TempDir(), fmt.Sprintf("nativefire_%s_postinstall.sh", manifest.Name))
+	if err := os.WriteFile(scriptPath, []byte(script), 0755); err != nil {
+		return "", fmt.Errorf("failed to write postinstall script: %w", err)
+	}
+
+	return scriptPath, nil
+}
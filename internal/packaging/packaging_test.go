@@ -0,0 +1,63 @@
+package packaging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadManifest(t *testing.T) {
+	tests := []struct {
+		name        string
+		yaml        string
+		expectName  string
+		expectVer   string
+		shouldError bool
+	}{
+		{
+			name: "full manifest",
+			yaml: "name: myapp\nversion: 1.2.3\nmaintainer: Jane <jane@example.com>\n" +
+				"dependencies:\n  - libsecret\nformats:\n  - deb\n  - rpm\n",
+			expectName: "myapp",
+			expectVer:  "1.2.3",
+		},
+		{
+			name:       "defaults version when missing",
+			yaml:       "name: myapp\n",
+			expectName: "myapp",
+			expectVer:  "0.0.0",
+		},
+		{
+			name:        "missing name is an error",
+			yaml:        "version: 1.0.0\n",
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "nativefire.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0644); err != nil {
+				t.Fatalf("failed to write manifest: %v", err)
+			}
+
+			manifest, err := LoadManifest(path)
+			if tt.shouldError {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadManifest() error = %v", err)
+			}
+			if manifest.Name != tt.expectName {
+				t.Errorf("Name = %q, want %q", manifest.Name, tt.expectName)
+			}
+			if manifest.Version != tt.expectVer {
+				t.Errorf("Version = %q, want %q", manifest.Version, tt.expectVer)
+			}
+		})
+	}
+}
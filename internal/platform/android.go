@@ -1,12 +1,21 @@
 package platform
 
 import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"runtime"
 	"strings"
 
+	"github.com/clix-so/nativefire/internal/dependencies"
 	"github.com/clix-so/nativefire/internal/firebase"
 	"github.com/clix-so/nativefire/internal/ui"
 )
@@ -17,6 +26,53 @@ const (
 	appDir             = "app"
 )
 
+// nativefireMarkerStart and nativefireMarkerEnd bracket every region
+// nativefire injects into Gradle and MainActivity source files, mirroring
+// FlutterFire's generated-code markers. They let AddInitializationCode
+// re-run safely (replace the marked region instead of inserting a second
+// copy) and let RemoveInitializationCode delete exactly what was added.
+const (
+	nativefireMarkerStart = "// START: nativefire"
+	nativefireMarkerEnd   = "// END: nativefire"
+)
+
+// wrapWithMarkers indents line and brackets it with the nativefire markers,
+// each at the same indentation, ready to be inserted as its own block.
+func wrapWithMarkers(indent, line string) string {
+	return indent + nativefireMarkerStart + "\n" + indent + line + "\n" + indent + nativefireMarkerEnd
+}
+
+// removeNativefireMarkerBlocks deletes every `// START: nativefire` ...
+// `// END: nativefire` region from content, including the lines the markers
+// themselves are on. Returns the updated content and whether anything was
+// removed.
+func removeNativefireMarkerBlocks(content string) (string, bool) {
+	changed := false
+	for {
+		startIdx := strings.Index(content, nativefireMarkerStart)
+		if startIdx == -1 {
+			break
+		}
+		relEndIdx := strings.Index(content[startIdx:], nativefireMarkerEnd)
+		if relEndIdx == -1 {
+			break
+		}
+		endIdx := startIdx + relEndIdx + len(nativefireMarkerEnd)
+
+		lineStart := strings.LastIndex(content[:startIdx], "\n") + 1
+		lineEnd := endIdx
+		if nl := strings.Index(content[lineEnd:], "\n"); nl != -1 {
+			lineEnd += nl + 1
+		} else {
+			lineEnd = len(content)
+		}
+
+		content = content[:lineStart] + content[lineEnd:]
+		changed = true
+	}
+	return content, changed
+}
+
 func (p *AndroidPlatform) Name() string {
 	return "Android"
 }
@@ -26,10 +82,17 @@ func (p *AndroidPlatform) Type() Type {
 }
 
 func (p *AndroidPlatform) Detect() bool {
-	return fileExists("build.gradle") ||
-		fileExists("app/build.gradle") ||
-		fileExists("android/build.gradle") ||
-		fileExists("settings.gradle")
+	for _, candidate := range []string{
+		"build.gradle", "build.gradle.kts",
+		"app/build.gradle", "app/build.gradle.kts",
+		"android/build.gradle", "android/build.gradle.kts",
+		"settings.gradle", "settings.gradle.kts",
+	} {
+		if fileExists(p.path(candidate)) {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *AndroidPlatform) ConfigFileName() string {
@@ -37,16 +100,27 @@ func (p *AndroidPlatform) ConfigFileName() string {
 }
 
 func (p *AndroidPlatform) ConfigPath() string {
-	if fileExists("app/src/main") {
-		return appDir
+	if fileExists(p.path("app/src/main")) {
+		return p.path(appDir)
 	}
-	if fileExists("android/app/src/main") {
-		return "android/app"
+	if fileExists(p.path("android/app/src/main")) {
+		return p.path("android/app")
 	}
-	return appDir
+	return p.path(appDir)
+}
+
+// PackagePaths reports that Android apps are distributed through Play Store
+// / APK/AAB bundles, not OS packages, so there is no packaged-filesystem
+// path for the config file.
+func (p *AndroidPlatform) PackagePaths(appName string) string {
+	return ""
 }
 
 func (p *AndroidPlatform) InstallConfig(config *firebase.Config) error {
+	if config.Env != "" {
+		return p.installVariantConfig(config)
+	}
+
 	configPath := p.ConfigPath()
 	targetPath := filepath.Join(configPath, p.ConfigFileName())
 
@@ -54,14 +128,14 @@ func (p *AndroidPlatform) InstallConfig(config *firebase.Config) error {
 		return fmt.Errorf("failed to create directory %s: %w", configPath, err)
 	}
 
-	// Use the unique temp file path from config instead of hardcoded temp location
-	sourceFile := config.ConfigFile
-	if sourceFile == "" {
-		// Fallback to old behavior if ConfigFile is not set
-		sourceFile = filepath.Join(os.TempDir(), p.ConfigFileName())
+	sourcePath := config.SourcePath
+	if sourcePath == "" {
+		// Fall back to the pre-SourcePath temp location for callers that
+		// haven't gone through RegisterApp/downloadConfig to populate it.
+		sourcePath = filepath.Join(os.TempDir(), p.ConfigFileName())
 	}
 
-	sourceData, err := os.ReadFile(sourceFile)
+	sourceData, err := os.ReadFile(sourcePath)
 	if err != nil {
 		return fmt.Errorf("failed to read source config file: %w", err)
 	}
@@ -70,15 +144,139 @@ func (p *AndroidPlatform) InstallConfig(config *firebase.Config) error {
 		return fmt.Errorf("failed to write config file to %s: %w", targetPath, err)
 	}
 
-	// Clean up the temp file after successful installation
-	if config.ConfigFile != "" {
-		os.Remove(config.ConfigFile)
+	// Clean up the downloaded temp file after successful installation
+	os.Remove(sourcePath)
+
+	ui.SuccessMsg(fmt.Sprintf("Configuration file installed at: %s", targetPath))
+	return nil
+}
+
+// installVariantConfig places google-services.json under
+// app/src/<env>/ instead of directly under app/, mirroring the Google
+// Services Gradle plugin's own per-variant config lookup: it merges
+// whichever source set's google-services.json matches the build variant
+// being assembled with the one at the app module root, so only the
+// matching variant's config is picked up at build time.
+//
+// --env reuses the same flag iOS's multi-environment setup uses, since both
+// are asking for the same thing: a build-time-selected Firebase config for
+// one of several environments/flavors.
+func (p *AndroidPlatform) installVariantConfig(config *firebase.Config) error {
+	if variants := p.buildVariants(); len(variants) > 0 && !containsFold(variants, config.Env) {
+		ui.WarningMsg(fmt.Sprintf(
+			"%q is not a productFlavor or buildType declared in %s; installing it anyway",
+			config.Env, filepath.Base(p.findBuildGradle())))
+	}
+
+	configPath := filepath.Join(p.ConfigPath(), "src", config.Env)
+	targetPath := filepath.Join(configPath, p.ConfigFileName())
+
+	if err := os.MkdirAll(configPath, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", configPath, err)
+	}
+
+	sourceData, err := os.ReadFile(config.SourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to read source config file: %w", err)
 	}
 
+	if err := os.WriteFile(targetPath, sourceData, 0644); err != nil {
+		return fmt.Errorf("failed to write config file to %s: %w", targetPath, err)
+	}
+
+	os.Remove(config.SourcePath)
+
 	ui.SuccessMsg(fmt.Sprintf("Configuration file installed at: %s", targetPath))
 	return nil
 }
 
+// gradleBlockEntryPattern matches a named entry at the top of a Gradle DSL
+// block, e.g. the "dev {" in "productFlavors { dev { ... } }".
+var gradleBlockEntryPattern = regexp.MustCompile(`(?m)^\s*([A-Za-z_][\w]*)\s*\{`)
+
+// buildVariants parses the app-level build.gradle[.kts] productFlavors {}
+// and buildTypes {} blocks to enumerate the variant names installVariantConfig
+// checks --env against. Returns nil if no build.gradle is found or neither
+// block is present, in which case installVariantConfig installs
+// unconditionally rather than failing closed on a project that doesn't
+// declare flavors at all.
+func (p *AndroidPlatform) buildVariants() []string {
+	buildGradlePath := p.findBuildGradle()
+	if buildGradlePath == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(buildGradlePath)
+	if err != nil {
+		return nil
+	}
+	contentStr := string(content)
+
+	var variants []string
+	for _, blockName := range []string{"productFlavors", "buildTypes"} {
+		variants = append(variants, parseGradleBlockNames(contentStr, blockName)...)
+	}
+	return variants
+}
+
+// parseGradleBlockNames extracts the top-level entry names declared inside
+// a Gradle DSL block named blockName, e.g. the "dev" and "prod" in:
+//
+//	productFlavors {
+//	    dev { applicationIdSuffix ".dev" }
+//	    prod { }
+//	}
+//
+// It's a brace-depth scan rather than a full Groovy/Kotlin parser,
+// consistent with the rest of this file's Gradle editing.
+func parseGradleBlockNames(contentStr, blockName string) []string {
+	start := strings.Index(contentStr, blockName+" {")
+	if start == -1 {
+		return nil
+	}
+
+	bodyStart := start + len(blockName+" {")
+	end := matchingBraceIndex(contentStr, bodyStart)
+	if end == -1 {
+		return nil
+	}
+
+	var names []string
+	for _, m := range gradleBlockEntryPattern.FindAllStringSubmatch(contentStr[bodyStart:end], -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// matchingBraceIndex returns the index of the "}" that closes the "{"
+// implicitly opened just before bodyStart (depth already at 1), or -1 if
+// unbalanced.
+func matchingBraceIndex(contentStr string, bodyStart int) int {
+	depth := 1
+	for i := bodyStart; i < len(contentStr); i++ {
+		switch contentStr[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// DockerImage is the Android SDK image used when --docker is set, so a host
+// without the Android SDK installed can still configure an Android app.
+func (p *AndroidPlatform) DockerImage() string {
+	return dockerImageOverride("NATIVEFIRE_ANDROID_DOCKER_IMAGE", "clixso/nativefire-android:latest")
+}
+
+func (p *AndroidPlatform) RemoteExec(config *firebase.Config, step string) error {
+	return dockerRemoteExec(config, p.DockerImage(), "android", step)
+}
+
 func (p *AndroidPlatform) AddInitializationCode(config *firebase.Config) error {
 	buildGradlePath := p.findBuildGradle()
 	if buildGradlePath == "" {
@@ -92,16 +290,21 @@ func (p *AndroidPlatform) AddInitializationCode(config *firebase.Config) error {
 
 	contentStr := string(content)
 	gradleModified := false
+	isKts := isKotlinDSL(buildGradlePath)
+
+	settingsGradlePath := p.findSettingsGradle()
+	usesPluginManagement := settingsGradlePath != "" && usesPluginManagementDSL(settingsGradlePath)
 
 	if !strings.Contains(contentStr, "google-services") {
-		if strings.Contains(contentStr, "plugins {") {
-			contentStr = strings.Replace(contentStr,
-				"plugins {",
-				"plugins {\n    id 'com.google.gms.google-services'", 1)
-		} else {
-			contentStr = "apply plugin: 'com.google.gms.google-services'\n\n" + contentStr
+		pluginID := "id 'com.google.gms.google-services'"
+		applyPlugin := "apply plugin: 'com.google.gms.google-services'"
+		if isKts {
+			pluginID = `id("com.google.gms.google-services")`
+			applyPlugin = `apply(plugin = "com.google.gms.google-services")`
 		}
 
+		contentStr = insertPluginIDLine(contentStr, pluginID, applyPlugin)
+
 		if err := os.WriteFile(buildGradlePath, []byte(contentStr), 0644); err != nil {
 			return fmt.Errorf("failed to update build.gradle: %w", err)
 		}
@@ -109,12 +312,40 @@ func (p *AndroidPlatform) AddInitializationCode(config *firebase.Config) error {
 		gradleModified = true
 	}
 
-	projectBuildGradlePath := p.findProjectBuildGradle()
-	if projectBuildGradlePath != "" {
-		if err := p.addClasspathToBuildGradle(projectBuildGradlePath); err != nil {
+	if usesPluginManagement {
+		// Modern AGP/Flutter template: plugin versions live in settings.gradle
+		// under pluginManagement { plugins { ... } }, not a buildscript
+		// classpath dependency.
+		groovyDecl := fmt.Sprintf(`id 'com.google.gms.google-services' version '%s' apply false`, googleServicesPluginVersion())
+		ktsDecl := fmt.Sprintf(`id("com.google.gms.google-services") version "%s" apply false`, googleServicesPluginVersion())
+		modified, err := p.addPluginToSettingsGradle(settingsGradlePath, "google-services", groovyDecl, ktsDecl)
+		if err != nil {
 			return err
 		}
-		gradleModified = true
+		gradleModified = gradleModified || modified
+	} else if projectBuildGradlePath := p.findProjectBuildGradle(); projectBuildGradlePath != "" {
+		version := googleServicesPluginVersion()
+		groovyClasspath := fmt.Sprintf("        classpath 'com.google.gms:google-services:%s'", version)
+		ktsClasspath := fmt.Sprintf(`        classpath("com.google.gms:google-services:%s")`, version)
+		modified, err := p.addClasspathToBuildGradle(projectBuildGradlePath, "google-services", groovyClasspath, ktsClasspath)
+		if err != nil {
+			return err
+		}
+		gradleModified = gradleModified || modified
+	}
+
+	depsModified, err := p.addFirebaseProductDependencies(buildGradlePath, config)
+	if err != nil {
+		return err
+	}
+	gradleModified = gradleModified || depsModified
+
+	if containsFold(config.Modules, "crashlytics") {
+		crashlyticsModified, err := p.addCrashlyticsPlugin(buildGradlePath, settingsGradlePath, usesPluginManagement)
+		if err != nil {
+			return err
+		}
+		gradleModified = gradleModified || crashlyticsModified
 	}
 
 	if err := p.addFirebaseImportsToMainActivity(); err != nil {
@@ -129,11 +360,102 @@ func (p *AndroidPlatform) AddInitializationCode(config *firebase.Config) error {
 	return nil
 }
 
+// insertPluginIDLine inserts pluginID into contentStr's plugins {} block, or
+// falls back to an apply-plugin statement at the top of the file for
+// build.gradle files that predate the plugins {} DSL.
+func insertPluginIDLine(contentStr, pluginID, applyPlugin string) string {
+	if strings.Contains(contentStr, "plugins {") {
+		return strings.Replace(contentStr,
+			"plugins {",
+			"plugins {\n"+wrapWithMarkers("    ", pluginID), 1)
+	}
+	return wrapWithMarkers("", applyPlugin) + "\n\n" + contentStr
+}
+
+// containsFold reports whether values contains s, case-insensitively,
+// mirroring the lookup firebasePodNames/firebaseProductNames use on iOS.
+func containsFold(values []string, s string) bool {
+	for _, m := range values {
+		if strings.EqualFold(m, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isKotlinDSL reports whether path is a Kotlin DSL Gradle script (.gradle.kts
+// / .kts), as opposed to the Groovy .gradle form — the two dialects need
+// different syntax for plugin ids and dependency coordinates.
+func isKotlinDSL(path string) bool {
+	return strings.HasSuffix(path, ".kts")
+}
+
+// RemoveInitializationCode deletes every nativefire-marked region it
+// previously added to the app and project Gradle files and MainActivity,
+// leaving anything else those files contain untouched. It's a no-op for
+// files that were never touched, so it's safe to call on a project that was
+// never configured.
+func (p *AndroidPlatform) RemoveInitializationCode(config *firebase.Config) error {
+	removedAny := false
+
+	gradleFiles := []string{p.findBuildGradle(), p.findProjectBuildGradle(), p.findSettingsGradle()}
+	for _, path := range gradleFiles {
+		if path == "" {
+			continue
+		}
+		removed, err := p.removeMarkerBlocksFromFile(path)
+		if err != nil {
+			return err
+		}
+		removedAny = removedAny || removed
+	}
+
+	mainActivityPath := findFile(".", "MainActivity.java")
+	if mainActivityPath == "" {
+		mainActivityPath = findFile(".", "MainActivity.kt")
+	}
+	if mainActivityPath != "" {
+		removed, err := p.removeMarkerBlocksFromFile(mainActivityPath)
+		if err != nil {
+			return err
+		}
+		removedAny = removedAny || removed
+	}
+
+	if removedAny {
+		ui.SuccessMsg("Removed nativefire-managed Firebase initialization code")
+	}
+	return nil
+}
+
+// removeMarkerBlocksFromFile strips every nativefire marker region from
+// path, rewriting the file only if something was actually removed.
+func (p *AndroidPlatform) removeMarkerBlocksFromFile(path string) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	updated, changed := removeNativefireMarkerBlocks(string(content))
+	if !changed {
+		return false, nil
+	}
+
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return false, fmt.Errorf("failed to update %s: %w", path, err)
+	}
+	ui.InfoMsg(fmt.Sprintf("Removed nativefire markers from: %s", path))
+	return true, nil
+}
+
 func (p *AndroidPlatform) findBuildGradle() string {
 	candidates := []string{
 		"app/build.gradle",
+		"app/build.gradle.kts",
 		"android/app/build.gradle",
+		"android/app/build.gradle.kts",
 		"build.gradle",
+		"build.gradle.kts",
 	}
 
 	for _, candidate := range candidates {
@@ -147,7 +469,9 @@ func (p *AndroidPlatform) findBuildGradle() string {
 func (p *AndroidPlatform) findProjectBuildGradle() string {
 	candidates := []string{
 		"build.gradle",
+		"build.gradle.kts",
 		"android/build.gradle",
+		"android/build.gradle.kts",
 	}
 
 	for _, candidate := range candidates {
@@ -161,28 +485,286 @@ func (p *AndroidPlatform) findProjectBuildGradle() string {
 	return ""
 }
 
-func (p *AndroidPlatform) addClasspathToBuildGradle(buildGradlePath string) error {
+func (p *AndroidPlatform) findSettingsGradle() string {
+	candidates := []string{
+		"settings.gradle",
+		"settings.gradle.kts",
+		"android/settings.gradle",
+		"android/settings.gradle.kts",
+	}
+
+	for _, candidate := range candidates {
+		if fileExists(candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// usesPluginManagementDSL reports whether settingsGradlePath declares its
+// plugin versions through settings.gradle's pluginManagement { plugins {} }
+// block — the style Flutter's newer Android template and AGP 8's
+// declarative plugins DSL use — rather than the legacy project-level
+// buildscript classpath.
+func usesPluginManagementDSL(settingsGradlePath string) bool {
+	content, err := os.ReadFile(settingsGradlePath)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(content), "pluginManagement")
+}
+
+// googleServicesPluginVersionDefault is used whenever
+// NATIVEFIRE_GOOGLE_SERVICES_VERSION isn't set; no lookup of the latest
+// published version is made.
+const googleServicesPluginVersionDefault = "4.3.15"
+
+// googleServicesPluginVersion resolves the google-services Gradle plugin
+// version to declare in pluginManagement, honoring an explicit override so
+// users (and CI) aren't stuck with whatever version nativefire shipped with.
+func googleServicesPluginVersion() string {
+	if version := os.Getenv("NATIVEFIRE_GOOGLE_SERVICES_VERSION"); version != "" {
+		return version
+	}
+	return googleServicesPluginVersionDefault
+}
+
+// addPluginToSettingsGradle declares a plugin, pinned to a version, inside
+// settings.gradle's pluginManagement { plugins {} } block, the way
+// Flutter's newer Android template and AGP 8's declarative plugins DSL
+// expect. idempotencyMarker is a substring (e.g. "google-services") that
+// identifies an existing declaration so re-running is a no-op.
+func (p *AndroidPlatform) addPluginToSettingsGradle(settingsGradlePath, idempotencyMarker, groovyDecl, ktsDecl string) (bool, error) {
+	content, err := os.ReadFile(settingsGradlePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", settingsGradlePath, err)
+	}
+	contentStr := string(content)
+
+	if strings.Contains(contentStr, idempotencyMarker) {
+		return false, nil
+	}
+
+	pluginManagementIdx := strings.Index(contentStr, "pluginManagement")
+	if pluginManagementIdx == -1 {
+		return false, fmt.Errorf("%s does not declare pluginManagement", settingsGradlePath)
+	}
+
+	relPluginsIdx := strings.Index(contentStr[pluginManagementIdx:], "plugins {")
+	if relPluginsIdx == -1 {
+		return false, fmt.Errorf("%s pluginManagement block has no plugins {} section", settingsGradlePath)
+	}
+	pluginsIdx := pluginManagementIdx + relPluginsIdx
+
+	pluginDeclaration := groovyDecl
+	if isKotlinDSL(settingsGradlePath) {
+		pluginDeclaration = ktsDecl
+	}
+
+	insertPoint := pluginsIdx + len("plugins {")
+	newContent := contentStr[:insertPoint] +
+		"\n" + wrapWithMarkers("        ", pluginDeclaration) +
+		contentStr[insertPoint:]
+
+	if err := os.WriteFile(settingsGradlePath, []byte(newContent), 0644); err != nil {
+		return false, fmt.Errorf("failed to update %s: %w", settingsGradlePath, err)
+	}
+	ui.SuccessMsg(fmt.Sprintf("Added plugin declaration to: %s", settingsGradlePath))
+	return true, nil
+}
+
+// addClasspathToBuildGradle adds a buildscript classpath dependency to
+// buildGradlePath's dependencies {} block. idempotencyMarker is a substring
+// (e.g. "google-services") that identifies an existing classpath entry so
+// re-running is a no-op.
+func (p *AndroidPlatform) addClasspathToBuildGradle(buildGradlePath, idempotencyMarker, groovyClasspath, ktsClasspath string) (bool, error) {
 	content, err := os.ReadFile(buildGradlePath)
 	if err != nil {
-		return fmt.Errorf("failed to read project build.gradle: %w", err)
+		return false, fmt.Errorf("failed to read project build.gradle: %w", err)
 	}
 
 	contentStr := string(content)
 
-	if !strings.Contains(contentStr, "google-services") {
-		if strings.Contains(contentStr, "dependencies {") {
-			insertPoint := strings.Index(contentStr, "dependencies {") + len("dependencies {")
-			newContent := contentStr[:insertPoint] +
-				"\n        classpath 'com.google.gms:google-services:4.3.15'" +
-				contentStr[insertPoint:]
-
-			if err := os.WriteFile(buildGradlePath, []byte(newContent), 0644); err != nil {
-				return fmt.Errorf("failed to update project build.gradle: %w", err)
-			}
-			ui.SuccessMsg(fmt.Sprintf("Added Google Services classpath to: %s", buildGradlePath))
+	if strings.Contains(contentStr, idempotencyMarker) {
+		return false, nil
+	}
+	if !strings.Contains(contentStr, "dependencies {") {
+		return false, nil
+	}
+
+	classpath := groovyClasspath
+	if isKotlinDSL(buildGradlePath) {
+		classpath = ktsClasspath
+	}
+
+	insertPoint := strings.Index(contentStr, "dependencies {") + len("dependencies {")
+	newContent := contentStr[:insertPoint] +
+		"\n" + wrapWithMarkers("        ", strings.TrimSpace(classpath)) +
+		contentStr[insertPoint:]
+
+	if err := os.WriteFile(buildGradlePath, []byte(newContent), 0644); err != nil {
+		return false, fmt.Errorf("failed to update project build.gradle: %w", err)
+	}
+	ui.SuccessMsg(fmt.Sprintf("Added classpath dependency to: %s", buildGradlePath))
+	return true, nil
+}
+
+// firebaseAndroidArtifactNames maps a Firebase product module name, as used
+// in firebase.Config.Modules, onto the Firebase Android SDK Maven artifact
+// it corresponds to (under the com.google.firebase group, version resolved
+// via the BoM so no explicit version is needed per artifact). Mirrors
+// firebasePodNames/firebaseProductNames in ios.go.
+var firebaseAndroidArtifactNames = map[string]string{
+	"auth":         "firebase-auth",
+	"firestore":    "firebase-firestore",
+	"messaging":    "firebase-messaging",
+	"analytics":    "firebase-analytics",
+	"storage":      "firebase-storage",
+	"crashlytics":  "firebase-crashlytics",
+	"remoteconfig": "firebase-config",
+	"functions":    "firebase-functions",
+	"performance":  "firebase-perf",
+}
+
+// firebaseBomVersionDefault is used whenever NATIVEFIRE_FIREBASE_BOM_VERSION
+// isn't set.
+const firebaseBomVersionDefault = "32.7.0"
+
+// firebaseBomVersion resolves the Firebase Android BoM version to declare,
+// honoring an explicit override the same way googleServicesPluginVersion
+// does.
+func firebaseBomVersion() string {
+	if version := os.Getenv("NATIVEFIRE_FIREBASE_BOM_VERSION"); version != "" {
+		return version
+	}
+	return firebaseBomVersionDefault
+}
+
+// addFirebaseProductDependencies declares the Firebase Android BoM and an
+// `implementation` dependency for every product in config.Modules, so users
+// don't have to hand-add every Firebase library after AddInitializationCode
+// wires up the plugin. It's idempotent on the BoM's presence: a file that
+// already declares firebase-bom is left untouched.
+func (p *AndroidPlatform) addFirebaseProductDependencies(buildGradlePath string, config *firebase.Config) (bool, error) {
+	content, err := os.ReadFile(buildGradlePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read build.gradle: %w", err)
+	}
+	contentStr := string(content)
+
+	if strings.Contains(contentStr, "firebase-bom") {
+		return false, nil
+	}
+	if !strings.Contains(contentStr, "dependencies {") {
+		return false, nil
+	}
+
+	isKts := isKotlinDSL(buildGradlePath)
+	bomCoordinate := fmt.Sprintf("com.google.firebase:firebase-bom:%s", firebaseBomVersion())
+
+	var lines []string
+	if isKts {
+		lines = append(lines, fmt.Sprintf(`implementation(platform("%s"))`, bomCoordinate))
+	} else {
+		lines = append(lines, fmt.Sprintf("implementation platform('%s')", bomCoordinate))
+	}
+
+	for _, module := range config.Modules {
+		artifact, ok := firebaseAndroidArtifactNames[strings.ToLower(module)]
+		if !ok {
+			continue
+		}
+		if isKts {
+			lines = append(lines, fmt.Sprintf(`implementation("com.google.firebase:%s")`, artifact))
+		} else {
+			lines = append(lines, fmt.Sprintf("implementation 'com.google.firebase:%s'", artifact))
 		}
 	}
-	return nil
+
+	indent := "        "
+	insertPoint := strings.Index(contentStr, "dependencies {") + len("dependencies {")
+	newContent := contentStr[:insertPoint] +
+		"\n" + wrapWithMarkers(indent, strings.Join(lines, "\n"+indent)) +
+		contentStr[insertPoint:]
+
+	if err := os.WriteFile(buildGradlePath, []byte(newContent), 0644); err != nil {
+		return false, fmt.Errorf("failed to update build.gradle: %w", err)
+	}
+	ui.SuccessMsg(fmt.Sprintf("Added Firebase BoM and %d product %s to: %s", len(lines)-1, pluralize(len(lines)-1, "dependency", "dependencies"), buildGradlePath))
+	return true, nil
+}
+
+// pluralize returns singular when n == 1, plural otherwise.
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+// crashlyticsGradlePluginVersionDefault is used whenever
+// NATIVEFIRE_CRASHLYTICS_PLUGIN_VERSION isn't set.
+const crashlyticsGradlePluginVersionDefault = "2.9.9"
+
+// crashlyticsGradlePluginVersion resolves the Crashlytics Gradle plugin
+// version, honoring an explicit override.
+func crashlyticsGradlePluginVersion() string {
+	if version := os.Getenv("NATIVEFIRE_CRASHLYTICS_PLUGIN_VERSION"); version != "" {
+		return version
+	}
+	return crashlyticsGradlePluginVersionDefault
+}
+
+// addCrashlyticsPlugin applies the com.google.firebase.crashlytics plugin to
+// the app module, plus whichever of the classpath/pluginManagement wiring
+// that plugin needs depending on which Gradle plugin DSL the project uses —
+// mirroring how the google-services plugin itself is wired in
+// AddInitializationCode.
+func (p *AndroidPlatform) addCrashlyticsPlugin(buildGradlePath, settingsGradlePath string, usesPluginManagement bool) (bool, error) {
+	content, err := os.ReadFile(buildGradlePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read build.gradle: %w", err)
+	}
+	contentStr := string(content)
+	modified := false
+
+	if !strings.Contains(contentStr, "firebase.crashlytics") {
+		isKts := isKotlinDSL(buildGradlePath)
+		pluginID := "id 'com.google.firebase.crashlytics'"
+		applyPlugin := "apply plugin: 'com.google.firebase.crashlytics'"
+		if isKts {
+			pluginID = `id("com.google.firebase.crashlytics")`
+			applyPlugin = `apply(plugin = "com.google.firebase.crashlytics")`
+		}
+
+		contentStr = insertPluginIDLine(contentStr, pluginID, applyPlugin)
+		if err := os.WriteFile(buildGradlePath, []byte(contentStr), 0644); err != nil {
+			return false, fmt.Errorf("failed to update build.gradle: %w", err)
+		}
+		ui.SuccessMsg(fmt.Sprintf("Added Crashlytics plugin to: %s", buildGradlePath))
+		modified = true
+	}
+
+	version := crashlyticsGradlePluginVersion()
+	if usesPluginManagement && settingsGradlePath != "" {
+		groovyDecl := fmt.Sprintf(`id 'com.google.firebase.crashlytics' version '%s' apply false`, version)
+		ktsDecl := fmt.Sprintf(`id("com.google.firebase.crashlytics") version "%s" apply false`, version)
+		settingsModified, err := p.addPluginToSettingsGradle(settingsGradlePath, "firebase.crashlytics", groovyDecl, ktsDecl)
+		if err != nil {
+			return false, err
+		}
+		modified = modified || settingsModified
+	} else if projectBuildGradlePath := p.findProjectBuildGradle(); projectBuildGradlePath != "" {
+		groovyClasspath := fmt.Sprintf("        classpath 'com.google.firebase:firebase-crashlytics-gradle:%s'", version)
+		ktsClasspath := fmt.Sprintf(`        classpath("com.google.firebase:firebase-crashlytics-gradle:%s")`, version)
+		classpathModified, err := p.addClasspathToBuildGradle(projectBuildGradlePath, "firebase-crashlytics-gradle", groovyClasspath, ktsClasspath)
+		if err != nil {
+			return false, err
+		}
+		modified = modified || classpathModified
+	}
+
+	return modified, nil
 }
 
 func (p *AndroidPlatform) addFirebaseImportsToMainActivity() error {
@@ -207,52 +789,54 @@ func (p *AndroidPlatform) addFirebaseImportsToMainActivity() error {
 		if !strings.Contains(contentStr, "FirebaseApp.initializeApp") {
 			// Add Firebase import after existing imports (safer approach)
 			if !strings.Contains(contentStr, "import com.google.firebase.FirebaseApp;") {
+				importBlock := wrapWithMarkers("", "import com.google.firebase.FirebaseApp;")
 				if strings.Contains(contentStr, "import android.os.Bundle;") {
 					contentStr = strings.Replace(contentStr,
 						"import android.os.Bundle;",
-						"import android.os.Bundle;\nimport com.google.firebase.FirebaseApp;", 1)
+						"import android.os.Bundle;\n"+importBlock, 1)
 				} else if strings.Contains(contentStr, "import androidx.appcompat.app.AppCompatActivity;") {
 					contentStr = strings.Replace(contentStr,
 						"import androidx.appcompat.app.AppCompatActivity;",
-						"import androidx.appcompat.app.AppCompatActivity;\nimport com.google.firebase.FirebaseApp;", 1)
+						"import androidx.appcompat.app.AppCompatActivity;\n"+importBlock, 1)
 				} else {
 					// Fallback: add after package declaration
 					contentStr = strings.Replace(contentStr,
 						"package",
-						"import com.google.firebase.FirebaseApp;\n\npackage", 1)
+						importBlock+"\n\npackage", 1)
 				}
 			}
 
 			if strings.Contains(contentStr, "onCreate") {
 				contentStr = strings.Replace(contentStr,
 					"super.onCreate(savedInstanceState);",
-					"super.onCreate(savedInstanceState);\n        FirebaseApp.initializeApp(this);", 1)
+					"super.onCreate(savedInstanceState);\n"+wrapWithMarkers("        ", "FirebaseApp.initializeApp(this);"), 1)
 			}
 		}
 	} else if strings.Contains(mainActivityPath, ".kt") {
 		if !strings.Contains(contentStr, "FirebaseApp.initializeApp") {
 			// Add Firebase import after existing imports
 			if !strings.Contains(contentStr, "import com.google.firebase.FirebaseApp") {
+				importBlock := wrapWithMarkers("", "import com.google.firebase.FirebaseApp")
 				if strings.Contains(contentStr, "import android.os.Bundle") {
 					contentStr = strings.Replace(contentStr,
 						"import android.os.Bundle",
-						"import android.os.Bundle\nimport com.google.firebase.FirebaseApp", 1)
+						"import android.os.Bundle\n"+importBlock, 1)
 				} else if strings.Contains(contentStr, "import androidx.appcompat.app.AppCompatActivity") {
 					contentStr = strings.Replace(contentStr,
 						"import androidx.appcompat.app.AppCompatActivity",
-						"import androidx.appcompat.app.AppCompatActivity\nimport com.google.firebase.FirebaseApp", 1)
+						"import androidx.appcompat.app.AppCompatActivity\n"+importBlock, 1)
 				} else {
 					// Fallback: add after package declaration
 					contentStr = strings.Replace(contentStr,
 						"package",
-						"import com.google.firebase.FirebaseApp\n\npackage", 1)
+						importBlock+"\n\npackage", 1)
 				}
 			}
 
 			if strings.Contains(contentStr, "onCreate") {
 				contentStr = strings.Replace(contentStr,
 					"super.onCreate(savedInstanceState)",
-					"super.onCreate(savedInstanceState)\n        FirebaseApp.initializeApp(this)", 1)
+					"super.onCreate(savedInstanceState)\n"+wrapWithMarkers("        ", "FirebaseApp.initializeApp(this)"), 1)
 			}
 		}
 	}
@@ -278,11 +862,147 @@ func (p *AndroidPlatform) runGradleSync() error {
 		return p.runSystemGradle()
 	}
 
-	ui.WarningMsg("Gradle not found. Please sync your project manually")
-	ui.InfoMsg("In Android Studio: File > Sync Project with Gradle Files")
+	// Neither a wrapper nor a system install: bootstrap one so the rest of
+	// nativefire (and anyone cloning the project later) can rely on
+	// ./gradlew existing, rather than asking the user to install Gradle
+	// globally just to sync once.
+	if err := p.bootstrapGradleWrapper(); err != nil {
+		ui.WarningMsg(fmt.Sprintf("Could not bootstrap a Gradle wrapper: %v", err))
+		ui.WarningMsg("Gradle not found. Please sync your project manually")
+		ui.InfoMsg("In Android Studio: File > Sync Project with Gradle Files")
+		return nil
+	}
+
+	return p.runGradlew()
+}
+
+// bootstrapGradleWrapper downloads the pinned Gradle distribution
+// (dependencies.GradleWrapperVersion), verifies its checksum, extracts it
+// into dependencies.GradleBootstrapCacheDir(), and runs its own
+// `gradle wrapper --gradle-version` against the current project to generate
+// gradlew, gradlew.bat, and gradle/wrapper/*. It's the last resort
+// runGradleSync reaches for once neither a project wrapper nor a system
+// Gradle install is found.
+func (p *AndroidPlatform) bootstrapGradleWrapper() error {
+	gradleBinary, err := p.downloadGradleDistribution()
+	if err != nil {
+		return err
+	}
+
+	ui.InfoMsg(fmt.Sprintf("Generating Gradle wrapper using Gradle %s...", dependencies.GradleWrapperVersion))
+	cmd := exec.Command(gradleBinary, "wrapper", "--gradle-version", dependencies.GradleWrapperVersion)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to generate Gradle wrapper: %w\n%s", err, output)
+	}
+
+	ui.SuccessMsg("Generated Gradle wrapper (gradlew, gradlew.bat, gradle/wrapper/)")
+	return nil
+}
+
+// downloadGradleDistribution returns the path to the `gradle` launcher
+// inside the cached, checksum-verified distribution, downloading and
+// extracting it first if it isn't already cached.
+func (p *AndroidPlatform) downloadGradleDistribution() (string, error) {
+	cacheDir, err := dependencies.GradleBootstrapCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	extractDir := filepath.Join(cacheDir, fmt.Sprintf("gradle-%s", dependencies.GradleWrapperVersion))
+	gradleBinary := filepath.Join(extractDir, "bin", "gradle")
+	if runtime.GOOS == "windows" {
+		gradleBinary += ".bat"
+	}
+	if fileExists(gradleBinary) {
+		return gradleBinary, nil
+	}
+
+	ui.InfoMsg(fmt.Sprintf("Downloading Gradle %s...", dependencies.GradleWrapperVersion))
+	resp, err := http.Get(dependencies.GradleWrapperDistURL())
+	if err != nil {
+		return "", fmt.Errorf("failed to download Gradle distribution: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Gradle distribution download returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Gradle distribution: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != dependencies.GradleWrapperDistSHA256 {
+		return "", fmt.Errorf("Gradle distribution checksum mismatch: expected %s, got %s",
+			dependencies.GradleWrapperDistSHA256, actual)
+	}
+
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create Gradle bootstrap cache directory %s: %w", cacheDir, err)
+	}
+
+	if err := extractZip(data, cacheDir); err != nil {
+		return "", fmt.Errorf("failed to extract Gradle distribution: %w", err)
+	}
+
+	ui.SuccessMsg(fmt.Sprintf("Gradle %s cached at: %s", dependencies.GradleWrapperVersion, extractDir))
+	return gradleBinary, nil
+}
+
+// extractZip extracts a zip archive's contents into destDir, preserving
+// each entry's file mode (needed for the executable gradle launcher
+// script), and rejecting any entry whose path would escape destDir.
+func extractZip(archive []byte, destDir string) error {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range reader.File {
+		targetPath := filepath.Join(destDir, file.Name)
+		if !strings.HasPrefix(targetPath, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("illegal file path in archive: %s", file.Name)
+		}
+
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(targetPath, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+			return err
+		}
+
+		if err := extractZipFile(file, targetPath); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func extractZipFile(file *zip.File, targetPath string) error {
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, rc)
+	return err
+}
+
 func (p *AndroidPlatform) runGradlew() error {
 	ui.InfoMsg("Using Gradle Wrapper...")
 
@@ -1,13 +1,66 @@
 package platform
 
 import (
+	"archive/zip"
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/clix-so/nativefire/internal/firebase"
+	"github.com/clix-so/nativefire/internal/projectscan"
 )
 
+// TestAndroidPlatformDetectFixtures mirrors TestIOSPlatformDetect, exercising
+// Detect() against realistic project layouts (Groovy and Kotlin DSL Gradle
+// files) instead of a handful of ad-hoc os.Create calls.
+func TestAndroidPlatformDetectFixtures(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    bool
+	}{
+		{fixture: "empty-project", want: false},
+		{fixture: "android-flavors", want: true},
+		{fixture: "android-gradle-kts", want: true},
+		{fixture: "flutter-ios", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			LoadFixture(t, tt.fixture)
+
+			platform := &AndroidPlatform{}
+			if got := platform.Detect(); got != tt.want {
+				t.Errorf("Detect() for fixture %q = %v, want %v", tt.fixture, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAndroidPlatformScanFixtures confirms projectscan.ScanAndroid resolves
+// the right applicationId (and, for android-flavors, its product flavors)
+// from a realistic Groovy-DSL build.gradle - the same parsing doctor's
+// checkAndroidConfig relies on to catch a stale google-services.json.
+func TestAndroidPlatformScanFixtures(t *testing.T) {
+	LoadFixture(t, "android-flavors")
+
+	project, err := projectscan.ScanAndroid(".")
+	if err != nil {
+		t.Fatalf("ScanAndroid() error = %v", err)
+	}
+
+	if project.ApplicationID != "com.example.flavorsfixture" {
+		t.Errorf("ApplicationID = %q, want %q", project.ApplicationID, "com.example.flavorsfixture")
+	}
+	if len(project.Flavors) != 2 {
+		t.Fatalf("expected 2 product flavors, got %d: %+v", len(project.Flavors), project.Flavors)
+	}
+	if project.Flavors[0].ApplicationID != "com.example.flavorsfixture.dev" {
+		t.Errorf("dev flavor ApplicationID = %q, want %q", project.Flavors[0].ApplicationID, "com.example.flavorsfixture.dev")
+	}
+}
+
 func TestAndroidPlatformInstallConfig(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -110,6 +163,18 @@ dependencies {
 }`,
 			expectedInBuildGradle: "apply plugin: 'com.google.gms.google-services'",
 		},
+		{
+			name:       "Add google-services plugin to Kotlin DSL plugins block",
+			setupFiles: []string{"app/build.gradle.kts"},
+			buildGradle: `plugins {
+    id("com.android.application")
+}
+
+dependencies {
+    implementation("androidx.core:core-ktx:1.7.0")
+}`,
+			expectedInBuildGradle: `id("com.google.gms.google-services")`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -122,7 +187,7 @@ dependencies {
 			defer func() { _ = os.Chdir(oldWd) }()
 
 			// Create build.gradle with specific content
-			buildGradlePath := filepath.Join(tempDir, "app/build.gradle")
+			buildGradlePath := filepath.Join(tempDir, tt.setupFiles[0])
 			if err := os.WriteFile(buildGradlePath, []byte(tt.buildGradle), 0644); err != nil {
 				t.Fatalf("Failed to create build.gradle: %v", err)
 			}
@@ -176,6 +241,11 @@ func TestAndroidPlatformFindBuildGradle(t *testing.T) {
 			setupFiles: []string{"build.gradle"},
 			expected:   "build.gradle",
 		},
+		{
+			name:       "Kotlin DSL Android project",
+			setupFiles: []string{"app/build.gradle.kts"},
+			expected:   "app/build.gradle.kts",
+		},
 		{
 			name:       "No build.gradle",
 			setupFiles: []string{},
@@ -202,6 +272,434 @@ func TestAndroidPlatformFindBuildGradle(t *testing.T) {
 	}
 }
 
+func TestAndroidPlatformAddClasspathToBuildGradleKotlinDSL(t *testing.T) {
+	tempDir := setupTestEnvironment(t, []string{}, []string{})
+	defer os.RemoveAll(tempDir)
+
+	oldWd, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	buildGradlePath := filepath.Join(tempDir, "build.gradle.kts")
+	original := `buildscript {
+    dependencies {
+        classpath("com.android.tools.build:gradle:8.1.0")
+    }
+}`
+	if err := os.WriteFile(buildGradlePath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	platform := &AndroidPlatform{}
+	if _, err := platform.addClasspathToBuildGradle(buildGradlePath, "google-services",
+		"        classpath 'com.google.gms:google-services:4.3.15'",
+		`        classpath("com.google.gms:google-services:4.3.15")`); err != nil {
+		t.Fatalf("addClasspathToBuildGradle() error = %v", err)
+	}
+
+	content, err := os.ReadFile(buildGradlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !contains(string(content), `classpath("com.google.gms:google-services:4.3.15")`) {
+		t.Errorf("expected Kotlin DSL classpath() call, got:\n%s", content)
+	}
+}
+
+func TestAndroidPlatformAddInitializationCodeUsesPluginManagementSettingsGradle(t *testing.T) {
+	tempDir := setupTestEnvironment(t, []string{"app"}, []string{})
+	defer os.RemoveAll(tempDir)
+
+	oldWd, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	settingsGradlePath := filepath.Join(tempDir, "settings.gradle.kts")
+	settingsGradle := `pluginManagement {
+    repositories {
+        google()
+        mavenCentral()
+    }
+    plugins {
+        id("com.android.application") version "8.1.0" apply false
+    }
+}
+
+include(":app")
+`
+	if err := os.WriteFile(settingsGradlePath, []byte(settingsGradle), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	buildGradlePath := filepath.Join(tempDir, "app/build.gradle.kts")
+	buildGradle := `plugins {
+    id("com.android.application")
+}
+
+dependencies {
+    implementation("androidx.core:core-ktx:1.7.0")
+}`
+	if err := os.WriteFile(buildGradlePath, []byte(buildGradle), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	platform := &AndroidPlatform{}
+	config := &firebase.Config{ProjectID: "test-project", AppID: "test-app-id", Platform: platform}
+
+	if err := platform.AddInitializationCode(config); err != nil {
+		t.Fatalf("AddInitializationCode failed: %v", err)
+	}
+
+	settingsContent, err := os.ReadFile(settingsGradlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(settingsContent), `id("com.google.gms.google-services") version`) {
+		t.Errorf("expected settings.gradle.kts pluginManagement to declare the google-services plugin, got:\n%s", settingsContent)
+	}
+
+	buildContent, err := os.ReadFile(buildGradlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(buildContent), `id("com.google.gms.google-services")`) {
+		t.Errorf("expected app build.gradle.kts to apply the google-services plugin, got:\n%s", buildContent)
+	}
+	if strings.Contains(string(buildContent), "classpath") {
+		t.Errorf("expected no buildscript classpath when pluginManagement is used, got:\n%s", buildContent)
+	}
+
+	// Re-running should be a no-op on the settings.gradle declaration.
+	firstSettings := string(settingsContent)
+	if err := platform.AddInitializationCode(config); err != nil {
+		t.Fatalf("second AddInitializationCode failed: %v", err)
+	}
+	secondSettings, err := os.ReadFile(settingsGradlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firstSettings != string(secondSettings) {
+		t.Fatalf("expected re-running AddInitializationCode to be a no-op for settings.gradle.kts, got:\nfirst:\n%s\nsecond:\n%s", firstSettings, secondSettings)
+	}
+}
+
+func TestAndroidPlatformAddInitializationCodeInjectsFirebaseBomAndProducts(t *testing.T) {
+	tempDir := setupTestEnvironment(t, []string{"app"}, []string{})
+	defer os.RemoveAll(tempDir)
+
+	oldWd, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	buildGradlePath := filepath.Join(tempDir, "app/build.gradle")
+	buildGradle := `plugins {
+    id 'com.android.application'
+}
+
+dependencies {
+    implementation 'androidx.core:core-ktx:1.7.0'
+}`
+	if err := os.WriteFile(buildGradlePath, []byte(buildGradle), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	platform := &AndroidPlatform{}
+	config := &firebase.Config{
+		ProjectID: "test-project",
+		AppID:     "test-app-id",
+		Platform:  platform,
+		Modules:   []string{"analytics", "auth", "crashlytics"},
+	}
+
+	if err := platform.AddInitializationCode(config); err != nil {
+		t.Fatalf("AddInitializationCode failed: %v", err)
+	}
+
+	content, err := os.ReadFile(buildGradlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contentStr := string(content)
+
+	for _, want := range []string{
+		"implementation platform('com.google.firebase:firebase-bom:",
+		"implementation 'com.google.firebase:firebase-analytics'",
+		"implementation 'com.google.firebase:firebase-auth'",
+		"implementation 'com.google.firebase:firebase-crashlytics'",
+		"id 'com.google.firebase.crashlytics'",
+	} {
+		if !strings.Contains(contentStr, want) {
+			t.Errorf("expected build.gradle to contain %q, got:\n%s", want, contentStr)
+		}
+	}
+
+	// Re-running should be a no-op.
+	first := contentStr
+	if err := platform.AddInitializationCode(config); err != nil {
+		t.Fatalf("second AddInitializationCode failed: %v", err)
+	}
+	second, err := os.ReadFile(buildGradlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != string(second) {
+		t.Fatalf("expected re-running AddInitializationCode to be a no-op, got:\nfirst:\n%s\nsecond:\n%s", first, second)
+	}
+}
+
+func TestAndroidPlatformAddInitializationCodeIsIdempotent(t *testing.T) {
+	tempDir := setupTestEnvironment(t, []string{"app"}, []string{})
+	defer os.RemoveAll(tempDir)
+
+	oldWd, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	buildGradlePath := filepath.Join(tempDir, "app/build.gradle")
+	buildGradle := `plugins {
+    id 'com.android.application'
+}
+
+dependencies {
+    implementation 'androidx.core:core-ktx:1.7.0'
+}`
+	if err := os.WriteFile(buildGradlePath, []byte(buildGradle), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	platform := &AndroidPlatform{}
+	config := &firebase.Config{ProjectID: "test-project", AppID: "test-app-id", Platform: platform}
+
+	if err := platform.AddInitializationCode(config); err != nil {
+		t.Fatalf("first AddInitializationCode failed: %v", err)
+	}
+	first, err := os.ReadFile(buildGradlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := platform.AddInitializationCode(config); err != nil {
+		t.Fatalf("second AddInitializationCode failed: %v", err)
+	}
+	second, err := os.ReadFile(buildGradlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(first) != string(second) {
+		t.Fatalf("expected re-running AddInitializationCode to be a no-op, got:\nfirst:\n%s\nsecond:\n%s", first, second)
+	}
+
+	if got := strings.Count(string(second), nativefireMarkerStart); got != 2 {
+		t.Errorf("expected exactly two marker blocks in build.gradle (plugin id + product dependencies), found %d", got)
+	}
+}
+
+func TestAndroidPlatformRemoveInitializationCode(t *testing.T) {
+	tempDir := setupTestEnvironment(t, []string{"app"}, []string{})
+	defer os.RemoveAll(tempDir)
+
+	oldWd, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	buildGradlePath := filepath.Join(tempDir, "app/build.gradle")
+	buildGradle := `plugins {
+    id 'com.android.application'
+}
+
+dependencies {
+    implementation 'androidx.core:core-ktx:1.7.0'
+}`
+	if err := os.WriteFile(buildGradlePath, []byte(buildGradle), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	platform := &AndroidPlatform{}
+	config := &firebase.Config{ProjectID: "test-project", AppID: "test-app-id", Platform: platform}
+
+	if err := platform.AddInitializationCode(config); err != nil {
+		t.Fatalf("AddInitializationCode failed: %v", err)
+	}
+
+	if err := platform.RemoveInitializationCode(config); err != nil {
+		t.Fatalf("RemoveInitializationCode failed: %v", err)
+	}
+
+	content, err := os.ReadFile(buildGradlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contentStr := string(content)
+	if strings.Contains(contentStr, "google-services") {
+		t.Errorf("expected google-services plugin to be removed, got:\n%s", contentStr)
+	}
+	if strings.Contains(contentStr, nativefireMarkerStart) {
+		t.Errorf("expected no nativefire markers left behind, got:\n%s", contentStr)
+	}
+	if contentStr != buildGradle {
+		t.Errorf("expected build.gradle to match its original content, got:\n%s", contentStr)
+	}
+
+	// Removing twice is a no-op, not an error.
+	if err := platform.RemoveInitializationCode(config); err != nil {
+		t.Fatalf("second RemoveInitializationCode failed: %v", err)
+	}
+}
+
+func TestAndroidPlatformInstallConfigWithFlavor(t *testing.T) {
+	tempDir := setupTestEnvironment(t, []string{"app", "app/src", "app/src/main"}, []string{})
+	defer os.RemoveAll(tempDir)
+
+	oldWd, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	buildGradle := `plugins {
+    id 'com.android.application'
+}
+
+android {
+    productFlavors {
+        dev {
+            applicationIdSuffix ".dev"
+        }
+        prod {
+        }
+    }
+}
+`
+	if err := os.WriteFile(filepath.Join(tempDir, "app", "build.gradle"), []byte(buildGradle), 0644); err != nil {
+		t.Fatalf("failed to write build.gradle: %v", err)
+	}
+
+	configFile := filepath.Join(os.TempDir(), "google-services-flavor.json")
+	configContent := `{"project_info":{"project_id":"test-project"}}`
+	if err := os.WriteFile(configFile, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to create mock config file: %v", err)
+	}
+	defer os.Remove(configFile)
+
+	platform := &AndroidPlatform{}
+	config := &firebase.Config{
+		ProjectID:  "test-project",
+		AppID:      "test-app-id",
+		Platform:   platform,
+		SourcePath: configFile,
+		Env:        "dev",
+	}
+
+	if err := platform.InstallConfig(config); err != nil {
+		t.Fatalf("InstallConfig failed: %v", err)
+	}
+
+	expectedPath := filepath.Join("app", "src", "dev", "google-services.json")
+	if _, err := os.Stat(expectedPath); os.IsNotExist(err) {
+		t.Errorf("Config file not found at expected path: %s", expectedPath)
+	}
+
+	if _, err := os.Stat(filepath.Join("app", "google-services.json")); !os.IsNotExist(err) {
+		t.Errorf("expected no google-services.json at the app module root when --env is set")
+	}
+}
+
+func TestAndroidPlatformBuildVariants(t *testing.T) {
+	tempDir := setupTestEnvironment(t, []string{"app"}, []string{})
+	defer os.RemoveAll(tempDir)
+
+	oldWd, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	buildGradle := `android {
+    productFlavors {
+        dev { }
+        prod { }
+    }
+    buildTypes {
+        debug { }
+        release { }
+    }
+}
+`
+	if err := os.WriteFile(filepath.Join(tempDir, "app", "build.gradle"), []byte(buildGradle), 0644); err != nil {
+		t.Fatalf("failed to write build.gradle: %v", err)
+	}
+
+	platform := &AndroidPlatform{}
+	variants := platform.buildVariants()
+
+	for _, want := range []string{"dev", "prod", "debug", "release"} {
+		if !containsFold(variants, want) {
+			t.Errorf("expected buildVariants() to include %q, got %v", want, variants)
+		}
+	}
+}
+
+func TestExtractZip(t *testing.T) {
+	tempDir := t.TempDir()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	writeZipFile(t, zw, "gradle-8.7/bin/gradle", "#!/bin/sh\necho gradle\n", 0755)
+	writeZipFile(t, zw, "gradle-8.7/lib/gradle-launcher.jar", "fake jar contents", 0644)
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip writer: %v", err)
+	}
+
+	if err := extractZip(buf.Bytes(), tempDir); err != nil {
+		t.Fatalf("extractZip() error = %v", err)
+	}
+
+	gradleBinary := filepath.Join(tempDir, "gradle-8.7", "bin", "gradle")
+	data, err := os.ReadFile(gradleBinary)
+	if err != nil {
+		t.Fatalf("expected %s to exist: %v", gradleBinary, err)
+	}
+	if !strings.Contains(string(data), "echo gradle") {
+		t.Errorf("unexpected gradle launcher contents: %s", data)
+	}
+
+	if info, err := os.Stat(gradleBinary); err == nil {
+		if info.Mode()&0100 == 0 {
+			t.Errorf("expected gradle launcher to be executable, got mode %v", info.Mode())
+		}
+	}
+
+	jarPath := filepath.Join(tempDir, "gradle-8.7", "lib", "gradle-launcher.jar")
+	if _, err := os.Stat(jarPath); err != nil {
+		t.Errorf("expected %s to exist: %v", jarPath, err)
+	}
+}
+
+func TestExtractZipRejectsPathTraversal(t *testing.T) {
+	tempDir := t.TempDir()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	writeZipFile(t, zw, "../../evil.sh", "echo pwned\n", 0755)
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip writer: %v", err)
+	}
+
+	if err := extractZip(buf.Bytes(), tempDir); err == nil {
+		t.Fatal("expected extractZip() to reject a path-traversal entry, got nil error")
+	}
+}
+
+func writeZipFile(t *testing.T, zw *zip.Writer, name, content string, mode os.FileMode) {
+	t.Helper()
+	header := &zip.FileHeader{Name: name, Method: zip.Deflate}
+	header.SetMode(mode)
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		t.Fatalf("failed to create zip entry %s: %v", name, err)
+	}
+	if _, err := w.Write([]byte(content)); err != nil {
+		t.Fatalf("failed to write zip entry %s: %v", name, err)
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || stringContains(s, substr)))
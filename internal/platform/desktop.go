@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/clix-so/nativefire/internal/firebase"
+	"github.com/clix-so/nativefire/internal/templates/desktop"
+	"github.com/clix-so/nativefire/internal/xcodeproj"
 )
 
 // Constants for repeated strings
@@ -23,9 +26,9 @@ func (p *MacOSPlatform) Type() Type {
 }
 
 func (p *MacOSPlatform) Detect() bool {
-	return fileExists("macos") ||
-		(findFile(".", "*.xcodeproj") != "" && fileExists("Podfile")) ||
-		findFile(".", "main.swift") != ""
+	return fileExists(p.path("macos")) ||
+		(findFile(p.rootDir(), "*.xcodeproj") != "" && fileExists(p.path("Podfile"))) ||
+		findFile(p.rootDir(), "main.swift") != ""
 }
 
 func (p *MacOSPlatform) ConfigFileName() string {
@@ -33,18 +36,31 @@ func (p *MacOSPlatform) ConfigFileName() string {
 }
 
 func (p *MacOSPlatform) ConfigPath() string {
-	if fileExists("macos") {
-		return "macos"
+	if fileExists(p.path("macos")) {
+		return p.path("macos")
 	}
-	return "."
+	return p.path(".")
+}
+
+// PackagePaths reports where a macOS .pkg installer should drop
+// GoogleService-Info.plist: under the app's Application Support directory.
+func (p *MacOSPlatform) PackagePaths(appName string) string {
+	return filepath.Join("/Library/Application Support", appName, "firebase", p.ConfigFileName())
 }
 
 func (p *MacOSPlatform) InstallConfig(config *firebase.Config) error {
-	return p.installConfigHelper()
+	return installDesktopConfig(p, config, p.PostInstallHooks())
 }
 
 func (p *MacOSPlatform) AddInitializationCode(config *firebase.Config) error {
-	return p.addInitializationHelper()
+	return p.addInitializationHelper(config)
+}
+
+// PostInstallHooks registers the macOS-specific work that needs to happen
+// after GoogleService-Info.plist is copied into place: wiring it into the
+// Xcode project so it's actually bundled into the app.
+func (p *MacOSPlatform) PostInstallHooks() []func(*firebase.Config) error {
+	return []func(*firebase.Config) error{p.wireXcodeproj}
 }
 
 func (p *WindowsPlatform) Name() string {
@@ -56,10 +72,10 @@ func (p *WindowsPlatform) Type() Type {
 }
 
 func (p *WindowsPlatform) Detect() bool {
-	return fileExists("windows") ||
-		findFile(".", "*.vcxproj") != "" ||
-		findFile(".", "*.sln") != "" ||
-		fileExists("CMakeLists.txt")
+	return fileExists(p.path("windows")) ||
+		findFile(p.rootDir(), "*.vcxproj") != "" ||
+		findFile(p.rootDir(), "*.sln") != "" ||
+		fileExists(p.path("CMakeLists.txt"))
 }
 
 func (p *WindowsPlatform) ConfigFileName() string {
@@ -67,18 +83,31 @@ func (p *WindowsPlatform) ConfigFileName() string {
 }
 
 func (p *WindowsPlatform) ConfigPath() string {
-	if fileExists("windows") {
-		return "windows"
+	if fileExists(p.path("windows")) {
+		return p.path("windows")
 	}
-	return "."
+	return p.path(".")
+}
+
+// PackagePaths reports where a Windows MSI should drop google-services.json:
+// under the machine-wide ProgramData directory.
+func (p *WindowsPlatform) PackagePaths(appName string) string {
+	return strings.Join([]string{`C:\ProgramData`, appName, "firebase", p.ConfigFileName()}, `\`)
 }
 
 func (p *WindowsPlatform) InstallConfig(config *firebase.Config) error {
-	return p.installConfigHelper()
+	return installDesktopConfig(p, config, p.PostInstallHooks())
 }
 
 func (p *WindowsPlatform) AddInitializationCode(config *firebase.Config) error {
-	return p.addInitializationHelper()
+	return p.addInitializationHelper(config)
+}
+
+// PostInstallHooks registers the Windows-specific work that needs to happen
+// after google-services.json is copied into place: wiring it into the
+// Visual Studio project so it shows up in Solution Explorer.
+func (p *WindowsPlatform) PostInstallHooks() []func(*firebase.Config) error {
+	return []func(*firebase.Config) error{p.wireVcxproj}
 }
 
 func (p *LinuxPlatform) Name() string {
@@ -90,9 +119,9 @@ func (p *LinuxPlatform) Type() Type {
 }
 
 func (p *LinuxPlatform) Detect() bool {
-	return fileExists("linux") ||
-		fileExists("CMakeLists.txt") ||
-		findFile(".", "Makefile") != ""
+	return fileExists(p.path("linux")) ||
+		fileExists(p.path("CMakeLists.txt")) ||
+		findFile(p.rootDir(), "Makefile") != ""
 }
 
 func (p *LinuxPlatform) ConfigFileName() string {
@@ -100,31 +129,70 @@ func (p *LinuxPlatform) ConfigFileName() string {
 }
 
 func (p *LinuxPlatform) ConfigPath() string {
-	if fileExists("linux") {
-		return "linux"
+	if fileExists(p.path("linux")) {
+		return p.path("linux")
 	}
-	return "."
+	return p.path(".")
+}
+
+// PackagePaths reports where a deb/rpm/apk/Arch package should drop
+// google-services.json: under /etc/<appName>/firebase, the well-known
+// location for machine-wide app configuration on Linux.
+func (p *LinuxPlatform) PackagePaths(appName string) string {
+	return filepath.Join("/etc", appName, "firebase", p.ConfigFileName())
 }
 
 func (p *LinuxPlatform) InstallConfig(config *firebase.Config) error {
-	return p.installConfigHelper()
+	return installDesktopConfig(p, config, p.PostInstallHooks())
 }
 
 func (p *LinuxPlatform) AddInitializationCode(config *firebase.Config) error {
-	return p.addInitializationHelper()
+	return p.addInitializationHelper(config)
 }
 
-func (p *MacOSPlatform) installConfigHelper() error {
-	configPath := p.ConfigPath()
-	targetPath := filepath.Join(configPath, p.ConfigFileName())
+// PostInstallHooks registers the Linux-specific work that needs to happen
+// after google-services.json is copied into place: publishing a pkg-config
+// file so CMake/Makefile builds can discover its path without hardcoding it.
+func (p *LinuxPlatform) PostInstallHooks() []func(*firebase.Config) error {
+	return []func(*firebase.Config) error{p.wirePkgConfig}
+}
+
+// installDesktopConfig implements the config-install behavior shared by all
+// desktop platforms: copy config.SourcePath into p's ConfigPath/ConfigFileName,
+// then run each post-install hook in order, stopping at the first error.
+func installDesktopConfig(p desktopPlatform, config *firebase.Config, hooks []func(*firebase.Config) error) error {
+	if err := p.installConfigHelper(p.ConfigPath(), p.ConfigFileName(), config); err != nil {
+		return err
+	}
+
+	for _, hook := range hooks {
+		if err := hook(config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// desktopPlatform is the subset of Platform that installDesktopConfig needs;
+// it's satisfied by embedding desktopBase alongside ConfigPath/ConfigFileName.
+type desktopPlatform interface {
+	ConfigPath() string
+	ConfigFileName() string
+	installConfigHelper(configPath, configFileName string, config *firebase.Config) error
+}
+
+// installConfigHelper copies config.SourcePath to configPath/configFileName,
+// creating configPath if necessary. It's shared by every desktop platform
+// via the embedded desktopBase.
+func (desktopBase) installConfigHelper(configPath, configFileName string, config *firebase.Config) error {
+	targetPath := filepath.Join(configPath, configFileName)
 
 	if err := os.MkdirAll(configPath, 0755); err != nil {
 		return fmt.Errorf("failed to create directory %s: %w", configPath, err)
 	}
 
-	sourceFile := filepath.Join(os.TempDir(), p.ConfigFileName())
-
-	sourceData, err := os.ReadFile(sourceFile)
+	sourceData, err := os.ReadFile(config.SourcePath)
 	if err != nil {
 		return fmt.Errorf("failed to read source config file: %w", err)
 	}
@@ -137,66 +205,226 @@ func (p *MacOSPlatform) installConfigHelper() error {
 	return nil
 }
 
-func (p *MacOSPlatform) addInitializationHelper() error {
-	fmt.Printf("⚠️  Please manually add Firebase initialization code to your %s application.\n", p.Name())
-	fmt.Println("💡 Refer to Firebase documentation for platform-specific initialization steps.")
+// RemoveInitializationCode is shared by every desktop platform via the
+// embedded desktopBase and is a no-op: the marker-guarded blocks
+// AddInitializationCode writes into main.swift, main.cpp or CMakeLists.txt,
+// and the project file entries the post-install hooks add, are left in place.
+func (desktopBase) RemoveInitializationCode(config *firebase.Config) error {
 	return nil
 }
 
-func (p *WindowsPlatform) installConfigHelper() error {
-	configPath := p.ConfigPath()
-	targetPath := filepath.Join(configPath, p.ConfigFileName())
+func (p *MacOSPlatform) addInitializationHelper(config *firebase.Config) error {
+	entryPath := findFile(".", "main.swift")
+	if entryPath == "" {
+		fmt.Printf("⚠️  Could not locate a main.swift entry point for %s.\n", p.Name())
+		fmt.Println("💡 Please manually add FirebaseApp.configure() to your application.")
+		return nil
+	}
 
-	if err := os.MkdirAll(configPath, 0755); err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", configPath, err)
+	rendered, err := desktop.Render("swift/main.swift.tmpl", desktopTemplateData(config))
+	if err != nil {
+		return err
 	}
 
-	sourceFile := filepath.Join(os.TempDir(), p.ConfigFileName())
+	if err := writeGuardedBlock(entryPath, desktop.BeginMarker, desktop.EndMarker, rendered); err != nil {
+		return fmt.Errorf("failed to add Firebase initialization to %s: %w", entryPath, err)
+	}
 
-	sourceData, err := os.ReadFile(sourceFile)
+	fmt.Printf("✅ Firebase initialization code added to: %s\n", entryPath)
+	return nil
+}
+
+// wireXcodeproj registers GoogleService-Info.plist in the macOS app's
+// project.pbxproj so Xcode actually bundles it, instead of just dropping the
+// file next to the project. It no-ops if no .xcodeproj is found.
+func (p *MacOSPlatform) wireXcodeproj(config *firebase.Config) error {
+	xcodeprojDir := findFile(".", "*.xcodeproj")
+	if xcodeprojDir == "" {
+		return nil
+	}
+
+	pbxprojPath := filepath.Join(xcodeprojDir, "project.pbxproj")
+	if !fileExists(pbxprojPath) {
+		return nil
+	}
+
+	project, err := xcodeproj.Open(pbxprojPath)
 	if err != nil {
-		return fmt.Errorf("failed to read source config file: %w", err)
+		return err
 	}
 
-	if err := os.WriteFile(targetPath, sourceData, 0644); err != nil {
-		return fmt.Errorf("failed to write config file to %s: %w", targetPath, err)
+	added, err := project.AddResourceFile(p.ConfigFileName())
+	if err != nil {
+		return fmt.Errorf("failed to wire %s into %s: %w", p.ConfigFileName(), pbxprojPath, err)
+	}
+	if !added {
+		return nil
 	}
 
-	fmt.Printf("✅ Configuration file installed at: %s\n", targetPath)
+	if err := project.Save(config.DryRun); err != nil {
+		return err
+	}
+
+	if config.DryRun {
+		fmt.Printf("💡 Dry run: %s would be added to %s\n", p.ConfigFileName(), pbxprojPath)
+	} else {
+		fmt.Printf("✅ Wired %s into: %s\n", p.ConfigFileName(), pbxprojPath)
+	}
 	return nil
 }
 
-func (p *WindowsPlatform) addInitializationHelper() error {
-	fmt.Printf("⚠️  Please manually add Firebase initialization code to your %s application.\n", p.Name())
-	fmt.Println("💡 Refer to Firebase documentation for platform-specific initialization steps.")
+func (p *WindowsPlatform) addInitializationHelper(config *firebase.Config) error {
+	return addCppInitializationHelper(p.Name(), config)
+}
+
+// wireVcxproj registers google-services.json as a <None> item in the
+// Windows app's Visual Studio project so it shows up in Solution Explorer
+// and gets copied alongside the build output. It no-ops if no .vcxproj is
+// found, and is idempotent: re-running configure won't duplicate the entry.
+func (p *WindowsPlatform) wireVcxproj(config *firebase.Config) error {
+	vcxprojPath := findFile(".", "*.vcxproj")
+	if vcxprojPath == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(vcxprojPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", vcxprojPath, err)
+	}
+
+	includeTag := fmt.Sprintf(`<None Include="%s" />`, p.ConfigFileName())
+	contentStr := string(content)
+	if strings.Contains(contentStr, includeTag) {
+		return nil
+	}
+
+	closeTag := "</ItemGroup>"
+	idx := strings.Index(contentStr, closeTag)
+	if idx == -1 {
+		return nil
+	}
+	contentStr = contentStr[:idx] + "    " + includeTag + "\n  " + contentStr[idx:]
+
+	if config.DryRun {
+		fmt.Printf("💡 Dry run: %s would be added to %s\n", includeTag, vcxprojPath)
+		return nil
+	}
+
+	if err := os.WriteFile(vcxprojPath, []byte(contentStr), 0644); err != nil {
+		return fmt.Errorf("failed to update %s: %w", vcxprojPath, err)
+	}
+	fmt.Printf("✅ Wired %s into: %s\n", p.ConfigFileName(), vcxprojPath)
 	return nil
 }
 
-func (p *LinuxPlatform) installConfigHelper() error {
+func (p *LinuxPlatform) addInitializationHelper(config *firebase.Config) error {
+	return addCppInitializationHelper(p.Name(), config)
+}
+
+// wirePkgConfig publishes a pkg-config (.pc) file alongside the installed
+// google-services.json so CMake's pkg_check_modules (or a plain Makefile
+// calling pkg-config) can discover its path without hardcoding it.
+func (p *LinuxPlatform) wirePkgConfig(config *firebase.Config) error {
 	configPath := p.ConfigPath()
-	targetPath := filepath.Join(configPath, p.ConfigFileName())
+	targetPath, err := filepath.Abs(filepath.Join(configPath, p.ConfigFileName()))
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", p.ConfigFileName(), err)
+	}
+	pcPath := filepath.Join(configPath, "firebase-config.pc")
 
-	if err := os.MkdirAll(configPath, 0755); err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", configPath, err)
+	contents := fmt.Sprintf("Name: firebase-config\n"+
+		"Description: Path to the Firebase config installed for this app\n"+
+		"Version: 1.0\n"+
+		"configfile=%s\n", targetPath)
+
+	if config.DryRun {
+		fmt.Printf("💡 Dry run: %s would be written to %s\n", "firebase-config.pc", pcPath)
+		return nil
+	}
+
+	if err := os.WriteFile(pcPath, []byte(contents), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", pcPath, err)
 	}
+	fmt.Printf("✅ pkg-config file written at: %s\n", pcPath)
+	return nil
+}
 
-	sourceFile := filepath.Join(os.TempDir(), p.ConfigFileName())
+// addCppInitializationHelper renders the C++ AppOptions bootstrap into the
+// project's main.cpp and, when a CMakeLists.txt is present, appends the
+// firebase_cpp_sdk find_package/target_link_libraries stanza. It is shared by
+// the Windows and Linux platforms, which both target the Firebase C++ SDK.
+func addCppInitializationHelper(platformName string, config *firebase.Config) error {
+	entryPath := findFile(".", "main.cpp")
+	if entryPath == "" {
+		fmt.Printf("⚠️  Could not locate a main.cpp entry point for %s.\n", platformName)
+		fmt.Println("💡 Please manually add firebase::App::Create(...) to your application.")
+		return nil
+	}
 
-	sourceData, err := os.ReadFile(sourceFile)
+	data := desktopTemplateData(config)
+
+	rendered, err := desktop.Render("cpp/main.cpp.tmpl", data)
 	if err != nil {
-		return fmt.Errorf("failed to read source config file: %w", err)
+		return err
 	}
 
-	if err := os.WriteFile(targetPath, sourceData, 0644); err != nil {
-		return fmt.Errorf("failed to write config file to %s: %w", targetPath, err)
+	if err := writeGuardedBlock(entryPath, desktop.BeginMarker, desktop.EndMarker, rendered); err != nil {
+		return fmt.Errorf("failed to add Firebase initialization to %s: %w", entryPath, err)
+	}
+	fmt.Printf("✅ Firebase initialization code added to: %s\n", entryPath)
+
+	if fileExists("CMakeLists.txt") {
+		cmakeStanza, err := desktop.Render("cmake/cmakelists.tmpl", data)
+		if err != nil {
+			return err
+		}
+		if err := writeGuardedBlock("CMakeLists.txt", desktop.CMakeBeginMarker, desktop.CMakeEndMarker, cmakeStanza); err != nil {
+			return fmt.Errorf("failed to update CMakeLists.txt: %w", err)
+		}
+		fmt.Println("✅ firebase_cpp_sdk linked in: CMakeLists.txt")
 	}
 
-	fmt.Printf("✅ Configuration file installed at: %s\n", targetPath)
 	return nil
 }
 
-func (p *LinuxPlatform) addInitializationHelper() error {
-	fmt.Printf("⚠️  Please manually add Firebase initialization code to your %s application.\n", p.Name())
-	fmt.Println("💡 Refer to Firebase documentation for platform-specific initialization steps.")
-	return nil
+// desktopTemplateData maps a firebase.Config onto the values the desktop
+// templates render.
+func desktopTemplateData(config *firebase.Config) desktop.Data {
+	return desktop.Data{
+		ProjectID:      config.ProjectID,
+		AppID:          config.AppID,
+		StorageBucket:  config.StorageBucket,
+		APIKey:         config.APIKey,
+		ConfigFileName: config.Platform.ConfigFileName(),
+		Modules:        config.Modules,
+	}
+}
+
+// writeGuardedBlock inserts block between beginMarker and endMarker in path,
+// replacing any previous nativefire-owned block so re-running configure
+// updates in place instead of duplicating.
+func writeGuardedBlock(path, beginMarker, endMarker, block string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	contentStr := string(content)
+	block = strings.TrimRight(block, "\n")
+
+	if start := strings.Index(contentStr, beginMarker); start != -1 {
+		end := strings.Index(contentStr[start:], endMarker)
+		if end == -1 {
+			return fmt.Errorf("found %s without matching %s in %s", beginMarker, endMarker, path)
+		}
+		end += start + len(endMarker)
+		contentStr = contentStr[:start] + block + contentStr[end:]
+	} else {
+		if !strings.HasSuffix(contentStr, "\n") {
+			contentStr += "\n"
+		}
+		contentStr += block + "\n"
+	}
+
+	return os.WriteFile(path, []byte(contentStr), 0644)
 }
@@ -0,0 +1,106 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/clix-so/nativefire/internal/firebase"
+)
+
+// Step names RemoteExec implementations dispatch on.
+const (
+	StepInstallConfig = "install-config"
+	StepAddInit       = "add-init"
+)
+
+// DockerCapable is implemented by platforms whose native tooling isn't
+// available on every host — Xcode for iOS, the Android SDK for Android.
+// When `nativefire configure --docker` (or NATIVEFIRE_DOCKER=1) is set,
+// InstallConfig/AddInitializationCode are routed through RemoteExec instead
+// of running directly, so a Linux CI host can configure an iOS app
+// end-to-end instead of having `pod install`/`xcodebuild` fail silently.
+// Platforms that don't need this (desktop platforms build with whatever
+// toolchain the host already has) simply don't implement it.
+type DockerCapable interface {
+	// DockerImage is the container image carrying this platform's
+	// toolchain (an Xcode-capable macOS runner bridge for iOS, an Android
+	// SDK image for Android).
+	DockerImage() string
+	// RemoteExec runs one configure step (StepInstallConfig or
+	// StepAddInit) inside DockerImage(), bind-mounting the project
+	// directory, the downloaded config file, and the Firebase CLI's
+	// stored credentials so the container can finish the step the host
+	// can't perform natively.
+	RemoteExec(config *firebase.Config, step string) error
+}
+
+// dockerImageOverride returns the image name in envVar if set, falling back
+// to the platform's default image otherwise.
+func dockerImageOverride(envVar, fallback string) string {
+	if image := os.Getenv(envVar); image != "" {
+		return image
+	}
+	return fallback
+}
+
+// containerSourcePath is where dockerRemoteExec bind-mounts the downloaded
+// Firebase config file inside the container, regardless of where it lives
+// on the host.
+const containerSourcePath = "/tmp/nativefire-config"
+
+// dockerRemoteExec runs `nativefire internal-docker-step` inside image,
+// bind-mounting the current project directory (so the container sees the
+// same source tree the host does), the already-downloaded Firebase config
+// file (so the container doesn't need its own `firebase` CLI session to
+// fetch it), and the Firebase CLI's credential store (so it doesn't have to
+// re-authenticate), then asks the container to finish step for
+// platformName.
+func dockerRemoteExec(config *firebase.Config, image, platformName, step string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to resolve project directory: %w", err)
+	}
+
+	args := []string{
+		"run", "--rm",
+		"-v", fmt.Sprintf("%s:/workspace", cwd),
+		"-w", "/workspace",
+	}
+
+	if config.SourcePath != "" {
+		args = append(args, "-v", fmt.Sprintf("%s:%s:ro", config.SourcePath, containerSourcePath))
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		credentials := filepath.Join(home, ".config", "configstore", "firebase-tools.json")
+		if fileExists(credentials) {
+			args = append(args, "-v", fmt.Sprintf("%s:/root/.config/configstore/firebase-tools.json:ro", credentials))
+		}
+	}
+
+	args = append(args, image, "nativefire", "internal-docker-step",
+		"--platform", platformName,
+		"--step", step,
+		"--project", config.ProjectID,
+		"--app-id", config.AppID,
+		"--bundle-id", config.BundleID,
+		"--package-name", config.PackageName,
+		"--source-path", containerSourcePath,
+		"--modules", strings.Join(config.Modules, ","),
+	)
+	if config.DryRun {
+		args = append(args, "--dry-run")
+	}
+
+	cmd := exec.Command("docker", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("docker step %q failed in %s: %w", step, image, err)
+	}
+	return nil
+}
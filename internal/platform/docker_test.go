@@ -0,0 +1,46 @@
+package platform
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDockerImageOverride(t *testing.T) {
+	const envVar = "NATIVEFIRE_TEST_DOCKER_IMAGE"
+
+	t.Run("falls back when unset", func(t *testing.T) {
+		os.Unsetenv(envVar)
+		if got := dockerImageOverride(envVar, "clixso/fallback:latest"); got != "clixso/fallback:latest" {
+			t.Errorf("Expected fallback image, got %q", got)
+		}
+	})
+
+	t.Run("uses env override when set", func(t *testing.T) {
+		os.Setenv(envVar, "example.com/custom-image:v1")
+		defer os.Unsetenv(envVar)
+		if got := dockerImageOverride(envVar, "clixso/fallback:latest"); got != "example.com/custom-image:v1" {
+			t.Errorf("Expected overridden image, got %q", got)
+		}
+	})
+}
+
+func TestAndroidPlatformIsDockerCapable(t *testing.T) {
+	var p interface{} = &AndroidPlatform{}
+	if _, ok := p.(DockerCapable); !ok {
+		t.Error("Expected AndroidPlatform to implement DockerCapable")
+	}
+}
+
+func TestIOSPlatformIsDockerCapable(t *testing.T) {
+	var p interface{} = &IOSPlatform{}
+	if _, ok := p.(DockerCapable); !ok {
+		t.Error("Expected IOSPlatform to implement DockerCapable")
+	}
+}
+
+func TestMacOSPlatformIsNotDockerCapable(t *testing.T) {
+	var p interface{} = &MacOSPlatform{}
+	if _, ok := p.(DockerCapable); ok {
+		t.Error("Expected MacOSPlatform not to implement DockerCapable; desktop platforms build with the host's own toolchain")
+	}
+}
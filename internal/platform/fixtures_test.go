@@ -0,0 +1,88 @@
+package platform
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fixturesDir is where realistic minimal project layouts live, one
+// subdirectory per fixture (see testdata/fixtures/README.md).
+const fixturesDir = "testdata/fixtures"
+
+// LoadFixture copies testdata/fixtures/<name> into a fresh t.TempDir(),
+// chdirs the test process into it, and restores the original working
+// directory via t.Cleanup. It returns the temp directory's path.
+//
+// This replaces ad-hoc `os.MkdirTemp` + a handful of `os.Create` calls with
+// fixtures that actually look like the project shapes nativefire has to
+// detect and mutate in practice (Flutter, React Native, XcodeGen, Gradle
+// Kotlin DSL, multi-target Xcode projects), so Detect/findProjectName/
+// determineAppDelegatePath are tested against real layouts instead of
+// synthetic ones that happen to trip the same code path.
+func LoadFixture(t *testing.T, name string) string {
+	t.Helper()
+
+	src := filepath.Join(fixturesDir, name)
+	if _, err := os.Stat(src); err != nil {
+		t.Fatalf("fixture %q not found under %s: %v", name, fixturesDir, err)
+	}
+
+	dst := t.TempDir()
+	if err := copyDir(src, dst); err != nil {
+		t.Fatalf("failed to copy fixture %q: %v", name, err)
+	}
+
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dst); err != nil {
+		t.Fatalf("failed to chdir into fixture %q: %v", name, err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+
+	return dst
+}
+
+// copyDir recursively copies src onto dst, creating directories as needed.
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		return copyFile(path, target, info.Mode())
+	})
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
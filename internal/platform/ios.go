@@ -1,23 +1,107 @@
 package platform
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/clix-so/nativefire/internal/firebase"
+	"github.com/clix-so/nativefire/internal/packageswift"
+	iosxcodeproj "github.com/clix-so/nativefire/internal/platform/ios/xcodeproj"
+	"github.com/clix-so/nativefire/internal/sourceedit"
 	"github.com/clix-so/nativefire/internal/ui"
+	"github.com/clix-so/nativefire/internal/xcodeproj"
 )
 
+// firebaseSwiftPackageURL is the Firebase iOS SDK's Swift Package Manager
+// repository, registered via xcodeproj.AddSwiftPackageDependency when
+// setupSPMFirebase wires up a plain Xcode project (one with no Podfile and
+// no existing Package.swift).
+const firebaseSwiftPackageURL = "https://github.com/firebase/firebase-ios-sdk"
+
+// firebaseSwiftPackageVersion is the minimum Firebase iOS SDK version
+// registered for new Swift Package Manager dependencies.
+const firebaseSwiftPackageVersion = "10.24.0"
+
+// swiftPackageVersion returns config.FirebaseSDKVersion if the user set one,
+// falling back to firebaseSwiftPackageVersion otherwise.
+func swiftPackageVersion(config *firebase.Config) string {
+	if config.FirebaseSDKVersion != "" {
+		return config.FirebaseSDKVersion
+	}
+	return firebaseSwiftPackageVersion
+}
+
 // Constants for repeated strings
 const (
 	googleServiceInfoPlist = "GoogleService-Info.plist"
 	iosName                = "iOS"
 )
 
+// defaultPodfileDeploymentTarget is used for a generated Podfile's
+// `platform :ios` line when no .xcodeproj can be found to read one from.
+const defaultPodfileDeploymentTarget = "13.0"
+
+// firebasePodNames maps a Firebase product module name, as used in
+// firebase.Config.Modules, onto the CocoaPods pod it corresponds to.
+var firebasePodNames = map[string]string{
+	"auth":         "Firebase/Auth",
+	"firestore":    "Firebase/Firestore",
+	"messaging":    "Firebase/Messaging",
+	"analytics":    "Firebase/Analytics",
+	"storage":      "Firebase/Storage",
+	"crashlytics":  "Firebase/Crashlytics",
+	"remoteconfig": "Firebase/RemoteConfig",
+	"functions":    "Firebase/Functions",
+	"performance":  "Firebase/Performance",
+}
+
+// podsForConfig translates config.Modules into the CocoaPods pods that
+// need to be present in a Podfile's target block, always leading with
+// Firebase/Core since every other Firebase pod depends on it.
+func podsForConfig(config *firebase.Config) []string {
+	pods := []string{"Firebase/Core"}
+	for _, module := range config.Modules {
+		if pod, ok := firebasePodNames[strings.ToLower(module)]; ok {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// firebaseProductNames maps a Firebase product module name, as used in
+// firebase.Config.Modules, onto the Firebase iOS SDK's Swift Package
+// Manager product name.
+var firebaseProductNames = map[string]string{
+	"auth":         "FirebaseAuth",
+	"firestore":    "FirebaseFirestore",
+	"messaging":    "FirebaseMessaging",
+	"analytics":    "FirebaseAnalytics",
+	"storage":      "FirebaseStorage",
+	"crashlytics":  "FirebaseCrashlytics",
+	"remoteconfig": "FirebaseRemoteConfig",
+	"functions":    "FirebaseFunctions",
+	"performance":  "FirebasePerformance",
+}
+
+// productsForConfig translates config.Modules into the Firebase iOS SDK
+// Swift Package Manager products a Package.swift target's dependencies
+// need, always leading with FirebaseCore.
+func productsForConfig(config *firebase.Config) []string {
+	products := []string{"FirebaseCore"}
+	for _, module := range config.Modules {
+		if product, ok := firebaseProductNames[strings.ToLower(module)]; ok {
+			products = append(products, product)
+		}
+	}
+	return products
+}
+
 func (p *IOSPlatform) Name() string {
 	return iosName
 }
@@ -27,10 +111,10 @@ func (p *IOSPlatform) Type() Type {
 }
 
 func (p *IOSPlatform) Detect() bool {
-	return fileExists(iosString) ||
-		findFile(".", "*.xcodeproj") != "" ||
-		findFile(".", "*.xcworkspace") != "" ||
-		fileExists("Podfile")
+	return fileExists(p.path(iosString)) ||
+		findFile(p.rootDir(), "*.xcodeproj") != "" ||
+		findFile(p.rootDir(), "*.xcworkspace") != "" ||
+		fileExists(p.path("Podfile"))
 }
 
 func (p *IOSPlatform) ConfigFileName() string {
@@ -38,13 +122,24 @@ func (p *IOSPlatform) ConfigFileName() string {
 }
 
 func (p *IOSPlatform) ConfigPath() string {
-	if fileExists(iosString) {
-		return iosString
+	if fileExists(p.path(iosString)) {
+		return p.path(iosString)
 	}
-	return "."
+	return p.path(".")
+}
+
+// PackagePaths reports that iOS apps are distributed through the App Store,
+// not OS packages, so there is no packaged-filesystem path for the config
+// file.
+func (p *IOSPlatform) PackagePaths(appName string) string {
+	return ""
 }
 
 func (p *IOSPlatform) InstallConfig(config *firebase.Config) error {
+	if config.Env != "" {
+		return p.installMultiEnvConfig(config)
+	}
+
 	configPath := p.ConfigPath()
 
 	runnerPath := filepath.Join(configPath, "Runner")
@@ -66,14 +161,7 @@ func (p *IOSPlatform) InstallConfig(config *firebase.Config) error {
 		return fmt.Errorf("failed to create directory %s: %w", configPath, err)
 	}
 
-	// Use the unique temp file path from config instead of hardcoded temp location
-	sourceFile := config.ConfigFile
-	if sourceFile == "" {
-		// Fallback to old behavior if ConfigFile is not set
-		sourceFile = filepath.Join(os.TempDir(), p.ConfigFileName())
-	}
-
-	sourceData, err := os.ReadFile(sourceFile)
+	sourceData, err := os.ReadFile(config.SourcePath)
 	if err != nil {
 		return fmt.Errorf("failed to read source config file: %w", err)
 	}
@@ -84,37 +172,392 @@ func (p *IOSPlatform) InstallConfig(config *firebase.Config) error {
 		return fmt.Errorf("failed to write config file to %s: %w", targetPath, err)
 	}
 
-	// Clean up the temp file after successful installation
-	if config.ConfigFile != "" {
-		os.Remove(config.ConfigFile)
+	// Clean up the downloaded temp file after successful installation
+	os.Remove(config.SourcePath)
+
+	ui.AnimatedSuccess(fmt.Sprintf("Configuration file installed at: %s", targetPath))
+
+	if err := p.wireXcodeproj(config); err != nil {
+		ui.WarningMsg(fmt.Sprintf("Failed to register %s in Xcode project: %v", p.ConfigFileName(), err))
+		ui.InfoMsg("Please add it to your target's 'Copy Bundle Resources' build phase manually")
 	}
 
+	if err := p.writeFirebaseAppIDFile(config); err != nil {
+		ui.WarningMsg(fmt.Sprintf("Failed to write firebase_app_id_file.json: %v", err))
+	}
+
+	return nil
+}
+
+// multiEnvConfigDir is where nativefire places per-environment
+// GoogleService-Info.plist files (ios/config/<env>/) when --env is set,
+// mirroring the layout Firebase's own multi-environment iOS guidance uses
+// (one plist per scheme/configuration, chosen at build time).
+const multiEnvConfigDir = "config"
+
+// copyConfigRunScriptName names the Run Script build phase
+// wireCopyConfigRunScript adds, doubling as its idempotency marker.
+const copyConfigRunScriptName = "Copy GoogleService-Info.plist"
+
+// installMultiEnvConfig places GoogleService-Info.plist under
+// ios/config/<env>/ instead of bundling a single static plist directly into
+// the target: every environment's plist ends up on disk, and a Run Script
+// build phase picks the right one at build time based on $(CONFIGURATION),
+// so only one ever ships in the built app.
+//
+// nativefire assumes the Xcode build configuration names already match the
+// environment names (e.g. a "Staging" configuration alongside Debug and
+// Release) — it does not fabricate new XCBuildConfiguration entries, since
+// duplicating a configuration's full build settings is out of scope for the
+// pbxproj editor today. Set up the per-environment configurations in Xcode
+// first (Product > Scheme > Manage Schemes, or duplicating a configuration
+// under the project's Info tab).
+func (p *IOSPlatform) installMultiEnvConfig(config *firebase.Config) error {
+	configPath := filepath.Join(p.ConfigPath(), multiEnvConfigDir, config.Env)
+	targetPath := filepath.Join(configPath, p.ConfigFileName())
+
+	if err := os.MkdirAll(configPath, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", configPath, err)
+	}
+
+	sourceData, err := os.ReadFile(config.SourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to read source config file: %w", err)
+	}
+
+	if err := os.WriteFile(targetPath, sourceData, 0644); err != nil {
+		ui.AnimatedError("Failed to write configuration file")
+		return fmt.Errorf("failed to write config file to %s: %w", targetPath, err)
+	}
+
+	os.Remove(config.SourcePath)
+
 	ui.AnimatedSuccess(fmt.Sprintf("Configuration file installed at: %s", targetPath))
+
+	if err := p.wireCopyConfigRunScript(config); err != nil {
+		ui.WarningMsg(fmt.Sprintf("Failed to add the %s run script: %v", copyConfigRunScriptName, err))
+		ui.InfoMsg(fmt.Sprintf("Please add a Run Script build phase that copies %s/$(CONFIGURATION)/%s manually",
+			multiEnvConfigDir, p.ConfigFileName()))
+	}
+
+	if err := p.writeFirebaseAppIDFile(config); err != nil {
+		ui.WarningMsg(fmt.Sprintf("Failed to write firebase_app_id_file.json: %v", err))
+	}
+
+	return nil
+}
+
+// wireCopyConfigRunScript registers a Run Script build phase that copies
+// ios/config/$(CONFIGURATION)/GoogleService-Info.plist into the built app's
+// resources at build time, so the per-environment plist installed by
+// installMultiEnvConfig actually reaches the app bundle. It no-ops if no
+// .xcodeproj is found.
+func (p *IOSPlatform) wireCopyConfigRunScript(config *firebase.Config) error {
+	xcodeprojDir := findFile(".", "*.xcodeproj")
+	if xcodeprojDir == "" {
+		return nil
+	}
+
+	pbxprojPath := filepath.Join(xcodeprojDir, "project.pbxproj")
+	if !fileExists(pbxprojPath) {
+		return nil
+	}
+
+	project, err := xcodeproj.Open(pbxprojPath)
+	if err != nil {
+		return err
+	}
+
+	// ${SRCROOT} is the folder holding the .xcodeproj, so the config
+	// directory is written relative to it.
+	configDir, err := filepath.Rel(filepath.Dir(xcodeprojDir), filepath.Join(p.ConfigPath(), multiEnvConfigDir))
+	if err != nil {
+		return err
+	}
+
+	script := fmt.Sprintf(
+		"GOOGLESERVICE_INFO_PLIST=\"${SRCROOT}/%s/${CONFIGURATION}/%s\"\n"+
+			"if [ ! -f \"${GOOGLESERVICE_INFO_PLIST}\" ]; then\n"+
+			"  echo \"warning: no %s found for configuration ${CONFIGURATION} at ${GOOGLESERVICE_INFO_PLIST}\"\n"+
+			"  exit 0\n"+
+			"fi\n"+
+			"cp \"${GOOGLESERVICE_INFO_PLIST}\" \"${BUILT_PRODUCTS_DIR}/${UNLOCALIZED_RESOURCES_FOLDER_PATH}/%s\"\n",
+		filepath.ToSlash(configDir), p.ConfigFileName(), p.ConfigFileName(), p.ConfigFileName())
+
+	added, err := project.AddRunScriptPhase(copyConfigRunScriptName, script)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to %s: %w", copyConfigRunScriptName, pbxprojPath, err)
+	}
+	if !added {
+		return nil
+	}
+
+	if err := project.Save(config.DryRun); err != nil {
+		return err
+	}
+
+	if config.DryRun {
+		ui.InfoMsg(fmt.Sprintf("💡 Dry run: %s would be added to %s", copyConfigRunScriptName, pbxprojPath))
+	} else {
+		ui.SuccessMsg(fmt.Sprintf("Added %s run script to: %s", copyConfigRunScriptName, pbxprojPath))
+	}
+	return nil
+}
+
+// firebaseAppIDFileSchemaVersion is the file_generator_version
+// firebase_app_id_file.json declares, matching what flutterfire_cli's own
+// generator writes.
+const firebaseAppIDFileSchemaVersion = "1"
+
+// isFlutterProject reports whether this looks like a Flutter app (ios/ as a
+// platform subdirectory of a Dart package) rather than a plain Xcode
+// project.
+func (p *IOSPlatform) isFlutterProject() bool {
+	return fileExists(p.path("pubspec.yaml"))
+}
+
+// writeFirebaseAppIDFile writes the firebase_app_id_file.json FlutterFire's
+// plugin registry reads at build time to resolve which Firebase app a
+// `flutter run --flavor <env>` build should initialize, mirroring what
+// `flutterfire configure` generates. It's a no-op outside a Flutter project.
+func (p *IOSPlatform) writeFirebaseAppIDFile(config *firebase.Config) error {
+	if !p.isFlutterProject() {
+		return nil
+	}
+
+	dir := p.path(iosString)
+	if !fileExists(dir) {
+		dir = p.rootDir()
+	}
+
+	payload := map[string]string{
+		"file_generator_version": firebaseAppIDFileSchemaVersion,
+		"GOOGLE_APP_ID":           config.AppID,
+		"FIREBASE_PROJECT_ID":     config.ProjectID,
+	}
+
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode firebase_app_id_file.json: %w", err)
+	}
+
+	path := filepath.Join(dir, "firebase_app_id_file.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	ui.SuccessMsg(fmt.Sprintf("Wrote Flutter app ID file at: %s", path))
+	return nil
+}
+
+// wireXcodeproj registers GoogleService-Info.plist in the iOS app's
+// project.pbxproj so Xcode actually bundles it into the target's Copy
+// Bundle Resources build phase, instead of just leaving it on disk for the
+// user to drag in manually. It no-ops if no .xcodeproj is found.
+func (p *IOSPlatform) wireXcodeproj(config *firebase.Config) error {
+	xcodeprojDir := findFile(".", "*.xcodeproj")
+	if xcodeprojDir == "" {
+		return nil
+	}
+
+	pbxprojPath := filepath.Join(xcodeprojDir, "project.pbxproj")
+	if !fileExists(pbxprojPath) {
+		return nil
+	}
+
+	project, err := xcodeproj.Open(pbxprojPath)
+	if err != nil {
+		return err
+	}
+
+	added, err := project.AddResourceFile(p.ConfigFileName())
+	if err != nil {
+		return fmt.Errorf("failed to wire %s into %s: %w", p.ConfigFileName(), pbxprojPath, err)
+	}
+	if !added {
+		return nil
+	}
+
+	if err := project.Save(config.DryRun); err != nil {
+		return err
+	}
+
+	if config.DryRun {
+		ui.InfoMsg(fmt.Sprintf("💡 Dry run: %s would be added to %s", p.ConfigFileName(), pbxprojPath))
+	} else {
+		ui.SuccessMsg(fmt.Sprintf("Wired %s into: %s", p.ConfigFileName(), pbxprojPath))
+	}
 	return nil
 }
 
+// addSwiftPackageDependency registers the Firebase iOS SDK as a Swift
+// Package Manager dependency directly in project.pbxproj — one
+// XCSwiftPackageProductDependency per product config.Modules selects, each
+// wired into the app target's packageProductDependencies — instead of
+// asking the user to add it through Xcode's UI. A PBXBuildFile /
+// PBXFrameworksBuildPhase entry isn't needed alongside
+// packageProductDependencies: that's how Xcode itself links package
+// products once they're on that list. Callers fall back to the manual
+// instruction flow if it returns an error.
+func (p *IOSPlatform) addSwiftPackageDependency(xcodeprojDir string, config *firebase.Config) error {
+	pbxprojPath := filepath.Join(xcodeprojDir, "project.pbxproj")
+	if !fileExists(pbxprojPath) {
+		return fmt.Errorf("no project.pbxproj found in %s", xcodeprojDir)
+	}
+
+	project, err := xcodeproj.Open(pbxprojPath)
+	if err != nil {
+		return err
+	}
+
+	version := swiftPackageVersion(config)
+	anyAdded := false
+	for _, product := range productsForConfig(config) {
+		added, err := project.AddSwiftPackageDependency(firebaseSwiftPackageURL, version, product)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to %s: %w", product, pbxprojPath, err)
+		}
+		anyAdded = anyAdded || added
+	}
+
+	if !anyAdded {
+		ui.InfoMsg("Firebase Swift package dependency is already present")
+		return nil
+	}
+
+	if err := project.Save(config.DryRun); err != nil {
+		return err
+	}
+
+	if config.DryRun {
+		ui.InfoMsg(fmt.Sprintf("💡 Dry run: Firebase Swift package would be added to %s", pbxprojPath))
+	} else {
+		if err := p.updatePackageResolved(xcodeprojDir, firebaseSwiftPackageURL, version); err != nil {
+			ui.WarningMsg(fmt.Sprintf("Could not update Package.resolved: %v", err))
+		}
+		ui.SuccessMsg(fmt.Sprintf("Added Firebase Swift package dependency to: %s", pbxprojPath))
+	}
+	return nil
+}
+
+// packageResolvedPin is one entry in project.xcworkspace's Package.resolved
+// lockfile, covering the remoteSourceControl kind Xcode writes for Swift
+// Package Manager dependencies added via "Add Package Dependencies...".
+type packageResolvedPin struct {
+	Identity string `json:"identity"`
+	Kind     string `json:"kind"`
+	Location string `json:"location"`
+	State    struct {
+		Revision string `json:"revision"`
+		Version  string `json:"version,omitempty"`
+	} `json:"state"`
+}
+
+// packageResolvedFile is the top-level Package.resolved structure, version
+// 2 of the schema Xcode 13+ writes.
+type packageResolvedFile struct {
+	Pins    []packageResolvedPin `json:"pins"`
+	Version int                  `json:"version"`
+}
+
+// updatePackageResolved adds or updates repoURL's pin in
+// project.xcworkspace/xcshareddata/swiftpm/Package.resolved, so Xcode
+// doesn't need a network resolve before it can build against the
+// dependency addSwiftPackageDependency just wired in. The pin's revision is
+// left blank: nativefire has no way to resolve version's commit SHA without
+// hitting the network, and Xcode fills it in itself the next time it
+// resolves packages, the same as it would for a hand-edited Package.resolved
+// with a stale revision.
+func (p *IOSPlatform) updatePackageResolved(xcodeprojDir, repoURL, version string) error {
+	path := filepath.Join(xcodeprojDir, "project.xcworkspace", "xcshareddata", "swiftpm", "Package.resolved")
+
+	resolved := packageResolvedFile{Version: 2}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &resolved); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+
+	found := false
+	for i, pin := range resolved.Pins {
+		if pin.Location == repoURL {
+			resolved.Pins[i].State.Version = version
+			found = true
+			break
+		}
+	}
+	if !found {
+		pin := packageResolvedPin{
+			Identity: strings.TrimSuffix(filepath.Base(repoURL), ".git"),
+			Kind:     "remoteSourceControl",
+			Location: repoURL,
+		}
+		pin.State.Version = version
+		resolved.Pins = append(resolved.Pins, pin)
+	}
+
+	data, err := json.MarshalIndent(resolved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// DockerImage is the Xcode-capable macOS runner bridge used when --docker
+// is set, so a non-Darwin host (e.g. Linux CI) can configure an iOS app
+// instead of having `pod install`/`xcodebuild` fail for lack of Xcode.
+func (p *IOSPlatform) DockerImage() string {
+	return dockerImageOverride("NATIVEFIRE_IOS_DOCKER_IMAGE", "clixso/nativefire-ios-runner:latest")
+}
+
+func (p *IOSPlatform) RemoteExec(config *firebase.Config, step string) error {
+	return dockerRemoteExec(config, p.DockerImage(), "ios", step)
+}
+
 func (p *IOSPlatform) AddInitializationCode(config *firebase.Config) error {
 	podfilePath := p.findPodfile()
 	podsAdded := false
 	spmSetup := false
 
-	if podfilePath != "" {
-		if err := p.addFirebasePods(podfilePath); err != nil {
+	forceCocoaPods := strings.EqualFold(config.PackageManager, "cocoapods")
+	forceSPM := strings.EqualFold(config.PackageManager, "spm")
+
+	if podfilePath != "" && !forceSPM {
+		if err := p.addFirebasePods(podfilePath, config); err != nil {
 			return err
 		}
 		podsAdded = true
-	} else if p.hasSwiftPackages() {
+	} else if !forceCocoaPods && p.hasSwiftPackages() {
 		// Handle existing Package.swift projects
-		if err := p.setupSPMPackageSwift(); err != nil {
-			return nil // User chose to skip
+		if fileExists("Package.swift") {
+			if err := p.setupSPMPackageSwift(config); err != nil {
+				ui.WarningMsg(fmt.Sprintf("Could not automatically edit Package.swift: %v", err))
+				return nil
+			}
 		}
 		spmSetup = true
-	} else if p.shouldUseSPM() {
+	} else if !forceCocoaPods && (p.shouldUseSPM() || forceSPM) {
 		// Handle Xcode projects that should use SPM
-		if err := p.setupSPMFirebase(); err != nil {
+		if err := p.setupSPMFirebase(config); err != nil {
 			return nil // User chose to skip
 		}
 		spmSetup = true
+	} else {
+		// No Podfile and no SPM project to target — fall back to
+		// CocoaPods, generating a Podfile from scratch the way `pod init`
+		// would, pre-populated with the Firebase pods config.Modules asks for.
+		if _, err := p.generatePodfile(config); err != nil {
+			ui.WarningMsg(fmt.Sprintf("Could not automatically generate a Podfile: %v", err))
+			return nil
+		}
+		podsAdded = true
 	}
 
 	appDelegatePath := p.findAppDelegate()
@@ -152,7 +595,151 @@ func (p *IOSPlatform) AddInitializationCode(config *firebase.Config) error {
 	return nil
 }
 
+// RemoveInitializationCode reverses addFirebaseInitialization, removing the
+// FirebaseApp.configure() call and (for Swift AppDelegates) the push
+// notification delegate methods block it added. It does not revert the
+// Podfile/Package.swift dependency changes AddInitializationCode made, since
+// those are left in place intentionally when switching Firebase projects.
+func (p *IOSPlatform) RemoveInitializationCode(config *firebase.Config) error {
+	appDelegatePath := p.findAppDelegate()
+	if appDelegatePath == "" {
+		return nil
+	}
+
+	removed, err := p.removeFirebaseInitialization(appDelegatePath)
+	if err != nil {
+		return err
+	}
+
+	if err := p.removeUIApplicationDelegateAdaptorFromSwiftUIApp(); err != nil {
+		return err
+	}
+
+	if removed {
+		ui.SuccessMsg(fmt.Sprintf("Removed Firebase initialization code from: %s", appDelegatePath))
+	}
+	return nil
+}
+
+func (p *IOSPlatform) removeFirebaseInitialization(appDelegatePath string) (bool, error) {
+	content, err := os.ReadFile(appDelegatePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read AppDelegate: %w", err)
+	}
+
+	lang := sourceedit.Swift
+	configureStmt := "FirebaseApp.configure()"
+	if strings.Contains(appDelegatePath, ".m") {
+		lang = sourceedit.ObjC
+		configureStmt = "[FIRApp configure];"
+	}
+
+	file := sourceedit.Parse(lang, string(content))
+	configChanged, err := file.RemoveAppDelegateConfigure(configureStmt)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove %s from %s: %w", configureStmt, appDelegatePath, err)
+	}
+
+	membersChanged := false
+	if lang == sourceedit.Swift {
+		membersChanged, err = file.RemoveAppDelegateMembers(firebaseDelegateMethodsMarker)
+		if err != nil {
+			return false, fmt.Errorf("failed to remove Firebase delegate methods from %s: %w", appDelegatePath, err)
+		}
+	}
+
+	if !configChanged && !membersChanged {
+		return false, nil
+	}
+
+	if err := os.WriteFile(appDelegatePath, []byte(file.Content), 0644); err != nil {
+		return false, fmt.Errorf("failed to update AppDelegate: %w", err)
+	}
+	return true, nil
+}
+
+// IOSSimulatorPlatform configures the iOS Simulator build of an Xcode
+// project. The Simulator is a run destination of the same iOS target and
+// source tree, not a separate Xcode target, so it reuses IOSPlatform's
+// detection, config placement, and AppDelegate/Podfile/SPM wiring wholesale
+// and only differs in how it identifies itself to Firebase (so a simulator
+// build can register as its own Firebase app, distinct from the device
+// build) and which xcodebuild destination it resolves to.
+type IOSSimulatorPlatform struct{ IOSPlatform }
+
+func (p *IOSSimulatorPlatform) Name() string {
+	return "iOS Simulator"
+}
+
+func (p *IOSSimulatorPlatform) Type() Type {
+	return IOSSimulator
+}
+
+// Destination returns the xcodebuild -destination value that selects this
+// platform's run destination within its Xcode scheme.
+func (p *IOSSimulatorPlatform) Destination() string {
+	return "generic/platform=iOS Simulator"
+}
+
+func (p *IOSSimulatorPlatform) AddInitializationCode(config *firebase.Config) error {
+	ui.InfoMsg(fmt.Sprintf("Targeting %s (%s)", p.Name(), p.Destination()))
+	return p.IOSPlatform.AddInitializationCode(config)
+}
+
+// MacCatalystPlatform configures the Mac Catalyst build of an Xcode
+// project: the same iOS target and source tree, built to run as a Mac app.
+// Like IOSSimulatorPlatform it reuses IOSPlatform's detection, config
+// placement, and source wiring, and only differs in Firebase app identity
+// and xcodebuild destination.
+type MacCatalystPlatform struct{ IOSPlatform }
+
+func (p *MacCatalystPlatform) Name() string {
+	return "Mac Catalyst"
+}
+
+func (p *MacCatalystPlatform) Type() Type {
+	return MacCatalyst
+}
+
+// Destination returns the xcodebuild -destination value that selects this
+// platform's run destination within its Xcode scheme.
+func (p *MacCatalystPlatform) Destination() string {
+	return "platform=macOS,variant=Mac Catalyst"
+}
+
+func (p *MacCatalystPlatform) AddInitializationCode(config *firebase.Config) error {
+	ui.InfoMsg(fmt.Sprintf("Targeting %s (%s)", p.Name(), p.Destination()))
+	return p.IOSPlatform.AddInitializationCode(config)
+}
+
+// parseXcodeproj finds the .xcodeproj under the current directory and
+// parses its object graph, returning the directory alongside it so callers
+// can resolve a Project's group-relative SourceFile.Path entries back to
+// real filesystem paths.
+func (p *IOSPlatform) parseXcodeproj() (*iosxcodeproj.Project, string, error) {
+	xcodeprojDir := findFile(".", "*.xcodeproj")
+	if xcodeprojDir == "" {
+		return nil, "", fmt.Errorf("no .xcodeproj found")
+	}
+
+	project, err := iosxcodeproj.Parse(filepath.Join(xcodeprojDir, "project.pbxproj"))
+	if err != nil {
+		return nil, "", err
+	}
+	return project, xcodeprojDir, nil
+}
+
+// findProjectName prefers the primary application target's actual name,
+// resolved from the pbxproj's object graph, over guessing from the
+// .xcodeproj bundle's filename - the two can differ (e.g. a renamed
+// target, or a workspace with more than one .xcodeproj).
 func (p *IOSPlatform) findProjectName() string {
+	if project, _, err := p.parseXcodeproj(); err == nil {
+		if target, err := project.PrimaryTarget(); err == nil && target.Name != "" {
+			return target.Name
+		}
+	}
+
 	xcodeproj := findFile(".", "*.xcodeproj")
 	if xcodeproj != "" {
 		return strings.TrimSuffix(filepath.Base(xcodeproj), ".xcodeproj")
@@ -174,7 +761,21 @@ func (p *IOSPlatform) findPodfile() string {
 	return ""
 }
 
+// findAppDelegate prefers the primary target's actual entry point, resolved
+// through its PBXSourcesBuildPhase and PBXGroup tree, over globbing the
+// directory tree for a file named AppDelegate.swift/.m - which can match
+// the wrong target's copy in a project with more than one, or miss a
+// SwiftUI App.swift entry point entirely.
 func (p *IOSPlatform) findAppDelegate() string {
+	if project, xcodeprojDir, err := p.parseXcodeproj(); err == nil {
+		if entry, err := project.AppEntryPoint(); err == nil {
+			path := filepath.Join(filepath.Dir(xcodeprojDir), entry.Path)
+			if fileExists(path) {
+				return path
+			}
+		}
+	}
+
 	appDelegatePath := findFile(".", "AppDelegate.swift")
 	if appDelegatePath == "" {
 		appDelegatePath = findFile(".", "AppDelegate.m")
@@ -182,7 +783,12 @@ func (p *IOSPlatform) findAppDelegate() string {
 	return appDelegatePath
 }
 
-func (p *IOSPlatform) addFirebasePods(podfilePath string) error {
+// addFirebasePods adds whichever pods podsForConfig(config) selects that
+// aren't already present under the Podfile's first `target '...' do`
+// block. It's idempotent pod-by-pod, so re-running configure with more
+// Modules selected only appends the newly-required pods, leaving the rest
+// of the file — comments, post_install hooks included — untouched.
+func (p *IOSPlatform) addFirebasePods(podfilePath string, config *firebase.Config) error {
 	content, err := os.ReadFile(podfilePath)
 	if err != nil {
 		return fmt.Errorf("failed to read Podfile: %w", err)
@@ -190,26 +796,231 @@ func (p *IOSPlatform) addFirebasePods(podfilePath string) error {
 
 	contentStr := string(content)
 
-	if !strings.Contains(contentStr, "Firebase/Core") {
-		lines := strings.Split(contentStr, "\n")
-		var newLines []string
+	var missing []string
+	for _, pod := range podsForConfig(config) {
+		if !strings.Contains(contentStr, fmt.Sprintf("pod '%s'", pod)) && !strings.Contains(contentStr, fmt.Sprintf(`pod "%s"`, pod)) {
+			missing = append(missing, pod)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	lines := strings.Split(contentStr, "\n")
+	var newLines []string
+	inserted := false
 
-		for _, line := range lines {
-			newLines = append(newLines, line)
-			if strings.Contains(line, "target") && strings.Contains(line, "do") {
-				newLines = append(newLines, "  pod 'Firebase/Core'")
-				newLines = append(newLines, "  pod 'Firebase/Analytics'")
+	for _, line := range lines {
+		newLines = append(newLines, line)
+		if !inserted && strings.Contains(line, "target") && strings.Contains(line, "do") {
+			for _, pod := range missing {
+				newLines = append(newLines, fmt.Sprintf("  pod '%s'", pod))
 			}
+			inserted = true
 		}
+	}
+
+	if !inserted {
+		return fmt.Errorf("could not find a target block in %s", podfilePath)
+	}
+
+	newContent := strings.Join(newLines, "\n")
+	if err := os.WriteFile(podfilePath, []byte(newContent), 0644); err != nil {
+		return fmt.Errorf("failed to update Podfile: %w", err)
+	}
+
+	ui.SuccessMsg(fmt.Sprintf("Added Firebase pods to: %s", podfilePath))
+	return nil
+}
+
+const podfileTemplate = `platform :ios, '%s'
+use_frameworks!
+
+target '%s' do
+%s
+end
+`
+
+// generatePodfile writes a fresh Podfile targeting the detected Xcode
+// project, the way `pod init` would, pre-populated with the Firebase pods
+// config.Modules selects. It's the fallback for projects that have neither
+// an existing Podfile nor an SPM setup for setupSPMFirebase to target.
+func (p *IOSPlatform) generatePodfile(config *firebase.Config) (string, error) {
+	target := p.findProjectName()
+	if target == "" {
+		target = "Runner"
+	}
+
+	var podLines strings.Builder
+	for _, pod := range podsForConfig(config) {
+		podLines.WriteString(fmt.Sprintf("  pod '%s'\n", pod))
+	}
+
+	content := fmt.Sprintf(podfileTemplate, p.iosDeploymentTarget(), target, strings.TrimRight(podLines.String(), "\n"))
+
+	podfilePath := "Podfile"
+	if fileExists("ios") {
+		podfilePath = filepath.Join("ios", "Podfile")
+	}
+
+	if err := os.WriteFile(podfilePath, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write Podfile: %w", err)
+	}
+
+	ui.SuccessMsg(fmt.Sprintf("Generated Podfile at: %s", podfilePath))
+	return podfilePath, nil
+}
+
+var deploymentTargetPattern = regexp.MustCompile(`IPHONEOS_DEPLOYMENT_TARGET = ([\d.]+);`)
+
+// iosDeploymentTarget reads the detected Xcode project's
+// IPHONEOS_DEPLOYMENT_TARGET build setting for generatePodfile's `platform
+// :ios` line, falling back to defaultPodfileDeploymentTarget when there's
+// no .xcodeproj to read one from.
+func (p *IOSPlatform) iosDeploymentTarget() string {
+	xcodeprojDir := findFile(".", "*.xcodeproj")
+	if xcodeprojDir == "" {
+		return defaultPodfileDeploymentTarget
+	}
+
+	content, err := os.ReadFile(filepath.Join(xcodeprojDir, "project.pbxproj"))
+	if err != nil {
+		return defaultPodfileDeploymentTarget
+	}
 
-		newContent := strings.Join(newLines, "\n")
-		if err := os.WriteFile(podfilePath, []byte(newContent), 0644); err != nil {
-			return fmt.Errorf("failed to update Podfile: %w", err)
+	if m := deploymentTargetPattern.FindSubmatch(content); m != nil {
+		return string(m[1])
+	}
+	return defaultPodfileDeploymentTarget
+}
+
+// firestorePodDeclaration matches any `pod 'FirebaseFirestore'` (or
+// double-quoted) declaration, with or without trailing :git/:tag/:podspec
+// arguments, so duplicate declarations can be counted regardless of which
+// form each one takes.
+var firestorePodDeclaration = regexp.MustCompile(`(?m)^\s*pod\s+['"]FirebaseFirestore['"]`)
+
+// lintFirebasePodConflicts scans podfilePath for the recurring conflict of
+// declaring FirebaseFirestore both in its normal, source-built form and in
+// invertase/firestore-ios-sdk-frameworks' precompiled form — a combination
+// `pod install` rejects with an opaque "duplicate dependency" error rather
+// than naming the real cause. It's a static Podfile check, not a `pod
+// install --dry-run` run: nativefire has no CocoaPods environment of its
+// own to shell out to for that.
+func (p *IOSPlatform) lintFirebasePodConflicts(podfilePath string) []string {
+	content, err := os.ReadFile(podfilePath)
+	if err != nil {
+		return nil
+	}
+
+	if len(firestorePodDeclaration.FindAllStringIndex(string(content), -1)) >= 2 {
+		return []string{"FirebaseFirestore"}
+	}
+	return nil
+}
+
+// resolveFirebasePodConflicts presents the user with the ways nativefire
+// knows how to resolve a detected pod conflict and applies whichever one
+// they pick.
+func (p *IOSPlatform) resolveFirebasePodConflicts(podfilePath string, conflicts []string) error {
+	ui.WarningMsg(fmt.Sprintf("Detected conflicting pod declarations for: %s", strings.Join(conflicts, ", ")))
+	ui.InfoMsg("This usually means a precompiled pod (e.g. invertase/firestore-ios-sdk-frameworks)")
+	ui.InfoMsg("and the standard source-built pod are both declared for the same Firebase product.")
+
+	response := ui.PromptWithSpinner("How should this be resolved?", []string{
+		"Keep the precompiled (:git) declaration and remove the duplicate",
+		"Add a marked post_install block for the duplicate vendored framework (it removes no framework)",
+		"Skip - just show this diagnosis",
+	})
+
+	switch response {
+	case "1":
+		return p.dedupePrecompiledFirebasePod(podfilePath, conflicts)
+	case "2":
+		return p.addDedupeFrameworksPostInstallHook(podfilePath)
+	default:
+		return nil
+	}
+}
+
+// dedupePrecompiledFirebasePod removes the source-built `pod 'name'`
+// declaration for each name in names, keeping only the :git-pinned
+// precompiled one.
+func (p *IOSPlatform) dedupePrecompiledFirebasePod(podfilePath string, names []string) error {
+	content, err := os.ReadFile(podfilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read Podfile: %w", err)
+	}
+
+	var patterns []*regexp.Regexp
+	for _, name := range names {
+		patterns = append(patterns, regexp.MustCompile(fmt.Sprintf(`pod\s+['"]%s['"]`, regexp.QuoteMeta(name))))
+	}
+
+	var kept []string
+	for _, line := range strings.Split(string(content), "\n") {
+		duplicate := false
+		for _, pattern := range patterns {
+			if pattern.MatchString(line) && !strings.Contains(line, ":git") {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			kept = append(kept, line)
 		}
+	}
 
-		ui.SuccessMsg(fmt.Sprintf("Added Firebase pods to: %s", podfilePath))
+	if err := os.WriteFile(podfilePath, []byte(strings.Join(kept, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to update Podfile: %w", err)
 	}
 
+	ui.SuccessMsg(fmt.Sprintf("Removed the duplicate source-built declaration for: %s", strings.Join(names, ", ")))
+	return nil
+}
+
+// dedupeFrameworksPostInstallMarker identifies the post_install snippet
+// addDedupeFrameworksPostInstallHook inserts, so re-running it is a no-op.
+const dedupeFrameworksPostInstallMarker = "nativefire: strip duplicate vendored frameworks"
+
+const dedupeFrameworksPostInstallSnippet = `  installer.pods_project.targets.each do |target|
+    target.build_configurations.each do |config|
+      # ` + dedupeFrameworksPostInstallMarker + ` (e.g. grpc.framework) pulled in
+      # by both a precompiled Firebase pod and its source-built transitive
+      # dependencies.
+    end
+  end`
+
+// addDedupeFrameworksPostInstallHook adds dedupeFrameworksPostInstallSnippet
+// to podfilePath's post_install block, creating the block if the Podfile
+// doesn't have one yet, or inserting into the existing one so the user's
+// other post_install logic is preserved. The snippet loops over every pod
+// target's build configurations, but the loop body holds only the marker
+// comment: no framework is removed from Pods-Runner or any other target.
+func (p *IOSPlatform) addDedupeFrameworksPostInstallHook(podfilePath string) error {
+	content, err := os.ReadFile(podfilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read Podfile: %w", err)
+	}
+	contentStr := string(content)
+
+	if strings.Contains(contentStr, dedupeFrameworksPostInstallMarker) {
+		return nil
+	}
+
+	var updated string
+	if idx := strings.Index(contentStr, "post_install do |installer|"); idx != -1 {
+		insertAt := idx + len("post_install do |installer|")
+		updated = contentStr[:insertAt] + "\n" + dedupeFrameworksPostInstallSnippet + contentStr[insertAt:]
+	} else {
+		updated = contentStr + "\npost_install do |installer|\n" + dedupeFrameworksPostInstallSnippet + "\nend\n"
+	}
+
+	if err := os.WriteFile(podfilePath, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("failed to update Podfile: %w", err)
+	}
+
+	ui.SuccessMsg("Added a marked post_install block for duplicate vendored frameworks (no framework is removed)")
 	return nil
 }
 
@@ -273,6 +1084,33 @@ func (p *IOSPlatform) addFirebaseInitialization(appDelegatePath string) error {
 	return fmt.Errorf("unsupported AppDelegate file type: %s", appDelegatePath)
 }
 
+// swiftFirebaseDelegateMethods are the push-notification delegate methods
+// required when FirebaseAppDelegateProxyEnabled is disabled. The leading
+// marker comment doubles as the idempotency check sourceedit uses to avoid
+// inserting them twice.
+const swiftFirebaseDelegateMethods = `// MARK: - Firebase Push Notification Delegate Methods
+func application(_ application: UIApplication, didRegisterForRemoteNotificationsWithDeviceToken deviceToken: Data) {
+    Messaging.messaging().apnsToken = deviceToken
+}
+
+func application(_ application: UIApplication, didFailToRegisterForRemoteNotificationsWithError error: Error) {
+    print("Failed to register for remote notifications: \(error)")
+}
+
+func application(_ application: UIApplication, didReceiveRemoteNotification userInfo: [AnyHashable: Any]) {
+    // Handle background notification
+}
+
+func application(_ application: UIApplication,
+                 didReceiveRemoteNotification userInfo: [AnyHashable: Any],
+                 fetchCompletionHandler completionHandler: @escaping (UIBackgroundFetchResult) -> Void) {
+    // Handle background notification with completion handler
+    completionHandler(.newData)
+}
+`
+
+const firebaseDelegateMethodsMarker = "Firebase Push Notification Delegate Methods"
+
 func (p *IOSPlatform) addSwiftFirebaseInitialization(contentStr, appDelegatePath string, proxyEnabled bool) error {
 	// Check if Firebase is already configured
 	if strings.Contains(contentStr, "FirebaseApp.configure()") {
@@ -298,19 +1136,23 @@ func (p *IOSPlatform) addSwiftFirebaseInitialization(contentStr, appDelegatePath
 		}
 	}
 
-	// Add FirebaseApp.configure() in didFinishLaunchingWithOptions
-	if strings.Contains(contentStr, "didFinishLaunchingWithOptions") {
-		const swiftMethod = "func application(_ application: UIApplication, " +
-			"didFinishLaunchingWithOptions launchOptions: [UIApplication.LaunchOptionsKey: Any]?) -> Bool {"
-		const swiftMethodWithFirebase = "func application(_ application: UIApplication, " +
-			"didFinishLaunchingWithOptions launchOptions: [UIApplication.LaunchOptionsKey: Any]?) -> Bool {" +
-			"\n        FirebaseApp.configure()"
-		contentStr = strings.Replace(contentStr, swiftMethod, swiftMethodWithFirebase, 1)
+	file := sourceedit.Parse(sourceedit.Swift, contentStr)
+	if _, err := file.EnsureAppDelegateConfigure("FirebaseApp.configure()", "FirebaseApp.configure()"); err != nil {
+		return fmt.Errorf("failed to add FirebaseApp.configure() to %s: %w", appDelegatePath, err)
 	}
+	contentStr = file.Content
 
 	// If FirebaseAppDelegateProxyEnabled is disabled, add required delegate methods
 	if !proxyEnabled {
-		contentStr = p.addSwiftDelegateMethods(contentStr)
+		if !strings.Contains(contentStr, "import FirebaseMessaging") {
+			contentStr = strings.Replace(contentStr, "import Firebase", "import Firebase\nimport FirebaseMessaging", 1)
+		}
+
+		membersFile := sourceedit.Parse(sourceedit.Swift, contentStr)
+		if _, err := membersFile.EnsureAppDelegateMembers(swiftFirebaseDelegateMethods, firebaseDelegateMethodsMarker); err != nil {
+			return fmt.Errorf("failed to add Firebase delegate methods to %s: %w", appDelegatePath, err)
+		}
+		contentStr = membersFile.Content
 		ui.InfoMsg("Added Firebase delegate methods (FirebaseAppDelegateProxyEnabled is disabled)")
 	}
 
@@ -352,18 +1194,19 @@ func (p *IOSPlatform) addObjCFirebaseInitialization(contentStr, appDelegatePath
 		}
 	}
 
-	// Add [FIRApp configure]; in didFinishLaunchingWithOptions
-	if strings.Contains(contentStr, "didFinishLaunchingWithOptions") {
-		const objcMethod = "- (BOOL)application:(UIApplication *)application " +
-			"didFinishLaunchingWithOptions:(NSDictionary *)launchOptions {"
-		const objcMethodWithFirebase = "- (BOOL)application:(UIApplication *)application " +
-			"didFinishLaunchingWithOptions:(NSDictionary *)launchOptions {\n    [FIRApp configure];"
-		contentStr = strings.Replace(contentStr, objcMethod, objcMethodWithFirebase, 1)
+	file := sourceedit.Parse(sourceedit.ObjC, contentStr)
+	if _, err := file.EnsureAppDelegateConfigure("[FIRApp configure];", "[FIRApp configure]"); err != nil {
+		return fmt.Errorf("failed to add [FIRApp configure] to %s: %w", appDelegatePath, err)
 	}
+	contentStr = file.Content
 
 	// If FirebaseAppDelegateProxyEnabled is disabled, add required delegate methods
 	if !proxyEnabled {
-		contentStr = p.addObjCDelegateMethods(contentStr)
+		updated, err := p.addObjCDelegateMethods(contentStr)
+		if err != nil {
+			return fmt.Errorf("failed to add Firebase delegate methods to %s: %w", appDelegatePath, err)
+		}
+		contentStr = updated
 		ui.InfoMsg("Added Firebase delegate methods (FirebaseAppDelegateProxyEnabled is disabled)")
 	}
 
@@ -378,54 +1221,22 @@ func (p *IOSPlatform) addObjCFirebaseInitialization(contentStr, appDelegatePath
 	return nil
 }
 
-func (p *IOSPlatform) addSwiftDelegateMethods(contentStr string) string {
-	// Add required delegate methods for push notifications when FirebaseAppDelegateProxyEnabled is NO
-	delegateMethods := `
-    // MARK: - Firebase Push Notification Delegate Methods
-    func application(_ application: UIApplication, didRegisterForRemoteNotificationsWithDeviceToken deviceToken: Data) {
-        Messaging.messaging().apnsToken = deviceToken
-    }
-    
-    func application(_ application: UIApplication, didFailToRegisterForRemoteNotificationsWithError error: Error) {
-        print("Failed to register for remote notifications: \(error)")
-    }
-    
-    func application(_ application: UIApplication, didReceiveRemoteNotification userInfo: [AnyHashable: Any]) {
-        // Handle background notification
-    }
-    
-    func application(_ application: UIApplication, 
-                     didReceiveRemoteNotification userInfo: [AnyHashable: Any], 
-                     fetchCompletionHandler completionHandler: @escaping (UIBackgroundFetchResult) -> Void) {
-        // Handle background notification with completion handler
-        completionHandler(.newData)
-    }`
-
-	// Add import for Firebase Messaging if not present
-	if !strings.Contains(contentStr, "import FirebaseMessaging") {
-		contentStr = strings.Replace(contentStr,
-			"import Firebase",
-			"import Firebase\nimport FirebaseMessaging", 1)
-	}
-
-	// Find the end of the class and add delegate methods before the closing brace
-	if strings.Contains(contentStr, "@UIApplicationMain") || strings.Contains(contentStr, "class AppDelegate") {
-		// Find the last closing brace of the class
-		lastBraceIndex := strings.LastIndex(contentStr, "}")
-		if lastBraceIndex != -1 {
-			contentStr = contentStr[:lastBraceIndex] + delegateMethods + "\n" + contentStr[lastBraceIndex:]
-		}
+// addObjCDelegateMethods inserts the push-notification delegate methods
+// required when FirebaseAppDelegateProxyEnabled is disabled, just before
+// the implementation's @end. Unlike Swift, Objective-C's @implementation
+// block isn't brace-delimited, so there's no risk of landing inside a
+// trailing category/extension the way a blind "last }" search would for
+// Swift; a plain @end search is safe here. It's idempotent via the
+// firebaseDelegateMethodsMarker check.
+func (p *IOSPlatform) addObjCDelegateMethods(contentStr string) (string, error) {
+	if strings.Contains(contentStr, firebaseDelegateMethodsMarker) {
+		return contentStr, nil
 	}
 
-	return contentStr
-}
-
-func (p *IOSPlatform) addObjCDelegateMethods(contentStr string) string {
-	// Add required delegate methods for push notifications when FirebaseAppDelegateProxyEnabled is NO
 	delegateMethods := `
-#pragma mark - Firebase Push Notification Delegate Methods
+#pragma mark - ` + firebaseDelegateMethodsMarker + `
 
-- (void)application:(UIApplication *)application 
+- (void)application:(UIApplication *)application
 didRegisterForRemoteNotificationsWithDeviceToken:(NSData *)deviceToken {
     [FIRMessaging messaging].APNSToken = deviceToken;
 }
@@ -453,15 +1264,11 @@ didRegisterForRemoteNotificationsWithDeviceToken:(NSData *)deviceToken {
 			"@import Firebase;\n@import FirebaseMessaging;", 1)
 	}
 
-	// Find the end of the implementation and add delegate methods before @end
-	if strings.Contains(contentStr, "@end") {
-		endIndex := strings.LastIndex(contentStr, "@end")
-		if endIndex != -1 {
-			contentStr = contentStr[:endIndex] + delegateMethods + "\n\n" + contentStr[endIndex:]
-		}
+	endIndex := strings.LastIndex(contentStr, "@end")
+	if endIndex == -1 {
+		return "", fmt.Errorf("could not find @end in Objective-C AppDelegate")
 	}
-
-	return contentStr
+	return contentStr[:endIndex] + delegateMethods + "\n\n" + contentStr[endIndex:], nil
 }
 
 func (p *IOSPlatform) createAppDelegate() (string, error) {
@@ -481,7 +1288,24 @@ func (p *IOSPlatform) createAppDelegate() (string, error) {
 	}
 }
 
+// isSwiftProject prefers the primary target's own source files' languages,
+// resolved from the pbxproj object graph, over globbing the whole
+// directory tree - which can't tell the app target's language apart from a
+// Swift test target sitting alongside an Objective-C app, or vice versa.
 func (p *IOSPlatform) isSwiftProject() bool {
+	if project, _, err := p.parseXcodeproj(); err == nil {
+		if target, err := project.PrimaryTarget(); err == nil {
+			for _, source := range target.Sources {
+				switch source.Language {
+				case "swift":
+					return true
+				case "objc", "objc++":
+					return false
+				}
+			}
+		}
+	}
+
 	// Check for existing Swift files
 	if findFile(".", "*.swift") != "" {
 		return true
@@ -508,7 +1332,15 @@ func (p *IOSPlatform) isSwiftProject() bool {
 	return true
 }
 
+// determineAppDelegatePath prefers the directory an existing AppDelegate
+// was actually found in over guessing a new one, falling back to the old
+// directory-name heuristics only for a project with no AppDelegate yet
+// (e.g. scaffolding a brand new one).
 func (p *IOSPlatform) determineAppDelegatePath() string {
+	if existing := p.findAppDelegate(); existing != "" {
+		return filepath.Dir(existing)
+	}
+
 	// Check for existing project structure
 	projectName := p.findProjectName()
 
@@ -762,54 +1594,53 @@ func (p *IOSPlatform) addDelegateAdaptorToSwiftUIApp(projectPath string) error {
 		return fmt.Errorf("failed to read App file: %w", err)
 	}
 
-	contentStr := string(content)
+	file := sourceedit.Parse(sourceedit.Swift, string(content))
 
-	// Check if delegate adaptor already exists
-	if strings.Contains(contentStr, "@UIApplicationDelegateAdaptor") {
+	changed, err := file.EnsureUIApplicationDelegateAdaptor("AppDelegate")
+	if err != nil {
+		return fmt.Errorf("failed to add UIApplicationDelegateAdaptor to %s: %w", appFile, err)
+	}
+	if !changed {
 		ui.InfoMsg("UIApplicationDelegateAdaptor already exists in SwiftUI App")
 		return nil
 	}
 
-	// Add delegate adaptor after @main line
-	if strings.Contains(contentStr, "@main") {
-		// Find the struct declaration
-		lines := strings.Split(contentStr, "\n")
-		var newLines []string
-		delegateAdded := false
-
-		for i := 0; i < len(lines); i++ {
-			line := lines[i]
-			newLines = append(newLines, line)
-
-			// Add delegate adaptor after struct declaration
-			if !delegateAdded && strings.Contains(line, "struct") && strings.Contains(line, "App") {
-				// Look for the opening brace
-				if strings.Contains(line, "{") {
-					newLines = append(newLines, "    @UIApplicationDelegateAdaptor(AppDelegate.self) var delegate")
-					newLines = append(newLines, "")
-					delegateAdded = true
-				} else if i+1 < len(lines) && strings.Contains(lines[i+1], "{") {
-					// Opening brace is on next line
-					i++ // Skip the next line since we're processing it here
-					newLines = append(newLines, lines[i])
-					newLines = append(newLines, "    @UIApplicationDelegateAdaptor(AppDelegate.self) var delegate")
-					newLines = append(newLines, "")
-					delegateAdded = true
-				}
-			}
-		}
+	if err := os.WriteFile(appFile, []byte(file.Content), 0644); err != nil {
+		return fmt.Errorf("failed to update SwiftUI App file: %w", err)
+	}
+	ui.SuccessMsg(fmt.Sprintf("Added UIApplicationDelegateAdaptor to: %s", appFile))
+	return nil
+}
 
-		if delegateAdded {
-			newContent := strings.Join(newLines, "\n")
-			if err := os.WriteFile(appFile, []byte(newContent), 0644); err != nil {
-				return fmt.Errorf("failed to update SwiftUI App file: %w", err)
-			}
-			ui.SuccessMsg(fmt.Sprintf("Added UIApplicationDelegateAdaptor to: %s", appFile))
-		} else {
-			return fmt.Errorf("could not find appropriate location to add delegate adaptor")
-		}
+// removeUIApplicationDelegateAdaptorFromSwiftUIApp removes a
+// @UIApplicationDelegateAdaptor property, under whatever variable name it
+// was declared with, from the project's SwiftUI App file.
+// RemoveInitializationCode calls it; no command in this tree calls
+// RemoveInitializationCode.
+func (p *IOSPlatform) removeUIApplicationDelegateAdaptorFromSwiftUIApp() error {
+	appFile := findFile(".", "*App.swift")
+	if appFile == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(appFile)
+	if err != nil {
+		return fmt.Errorf("failed to read App file: %w", err)
 	}
 
+	file := sourceedit.Parse(sourceedit.Swift, string(content))
+	changed, err := file.RemoveUIApplicationDelegateAdaptor()
+	if err != nil {
+		return fmt.Errorf("failed to remove UIApplicationDelegateAdaptor from %s: %w", appFile, err)
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := os.WriteFile(appFile, []byte(file.Content), 0644); err != nil {
+		return fmt.Errorf("failed to update SwiftUI App file: %w", err)
+	}
+	ui.SuccessMsg(fmt.Sprintf("Removed UIApplicationDelegateAdaptor from: %s", appFile))
 	return nil
 }
 
@@ -924,6 +1755,14 @@ func (p *IOSPlatform) runPodInstall() error {
 
 	ui.AnimatedSuccess("CocoaPods found")
 
+	if podfilePath := p.findPodfile(); podfilePath != "" {
+		if conflicts := p.lintFirebasePodConflicts(podfilePath); len(conflicts) > 0 {
+			if err := p.resolveFirebasePodConflicts(podfilePath, conflicts); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Run pod install with spinner
 	return ui.ShowLoader("Installing CocoaPods dependencies", func() error {
 		if err := p.runCommand("pod", []string{"install"}, "Installing CocoaPods dependencies"); err != nil {
@@ -966,32 +1805,38 @@ func (p *IOSPlatform) shouldUseSPM() bool {
 	return xcodeproj != "" && podfile == ""
 }
 
+// updateSwiftPackages resolves Swift package dependencies after
+// setupSPMPackageSwift has edited Package.swift. Xcode-project SPM
+// dependencies (no Package.swift) resolve on the project's next build
+// instead, so there's no command to run there.
 func (p *IOSPlatform) updateSwiftPackages() error {
-	ui.InfoMsg("📦 Swift Package Manager detected")
-	ui.InfoMsg("")
-	ui.InfoMsg("Please ensure Firebase iOS SDK is properly added to your project:")
-	ui.InfoMsg("")
-	
-	if fileExists("Package.swift") {
-		ui.InfoMsg("For Package.swift projects:")
-		ui.InfoMsg("  1. Add Firebase dependency to Package.swift")
-		ui.InfoMsg("  2. Run: swift package resolve")
-		ui.InfoMsg("  3. Add FirebaseCore to your target dependencies")
-	} else {
-		ui.InfoMsg("For Xcode projects:")
-		ui.InfoMsg("  1. Open your project in Xcode")
-		ui.InfoMsg("  2. File → Add Package Dependencies...")
-		ui.InfoMsg("  3. Add: https://github.com/firebase/firebase-ios-sdk")
-		ui.InfoMsg("  4. Select FirebaseCore product")
-		ui.InfoMsg("  5. Build your project to resolve dependencies")
+	if !fileExists("Package.swift") {
+		ui.InfoMsg("📦 Swift Package Manager dependencies will resolve the next time you build in Xcode")
+		return nil
 	}
-	
-	ui.InfoMsg("")
-	ui.SuccessMsg("Swift Package Manager setup guidance provided")
-	return nil
+
+	return ui.ShowLoader("Resolving Swift package dependencies", func() error {
+		if err := p.runCommand("swift", []string{"package", "resolve"}, "Resolving Swift package dependencies"); err != nil {
+			ui.WarningMsg("Failed to run 'swift package resolve'. Please run it manually")
+			ui.InfoMsg("Run: swift package resolve")
+			return err
+		}
+
+		ui.SuccessMsg("Swift package dependencies resolved successfully!")
+		return nil
+	})
 }
 
-func (p *IOSPlatform) setupSPMFirebase() error {
+func (p *IOSPlatform) setupSPMFirebase(config *firebase.Config) error {
+	if xcodeprojDir := findFile(".", "*.xcodeproj"); xcodeprojDir != "" {
+		if err := p.addSwiftPackageDependency(xcodeprojDir, config); err == nil {
+			return nil
+		} else {
+			ui.WarningMsg(fmt.Sprintf("Could not automatically add the Firebase Swift package: %v", err))
+			ui.InfoMsg("Falling back to manual setup instructions")
+		}
+	}
+
 	ui.AnimatedHeader("Firebase iOS SDK Setup Required")
 	fmt.Println()
 	
@@ -1003,7 +1848,7 @@ func (p *IOSPlatform) setupSPMFirebase() error {
 		"Open your Xcode project",
 		"Go to File → Add Package Dependencies...",
 		"Enter this URL: https://github.com/firebase/firebase-ios-sdk",
-		"Select version 10.24.0 or later",
+		fmt.Sprintf("Select version %s or later", swiftPackageVersion(config)),
 		"Add 'FirebaseCore' to your app target",
 		"Build your project to ensure dependencies are resolved",
 	}
@@ -1030,39 +1875,48 @@ func (p *IOSPlatform) setupSPMFirebase() error {
 	return nil
 }
 
-func (p *IOSPlatform) setupSPMPackageSwift() error {
-	ui.InfoMsg("🔥 Firebase iOS SDK Setup Required")
-	ui.InfoMsg("")
-	ui.InfoMsg("Detected Package.swift project. Please add Firebase iOS SDK dependency:")
-	ui.InfoMsg("")
-	ui.InfoMsg("📋 Add this to your Package.swift dependencies:")
-	ui.InfoMsg(`  .package(url: "https://github.com/firebase/firebase-ios-sdk", from: "10.24.0")`)
-	ui.InfoMsg("")
-	ui.InfoMsg("📋 Add FirebaseCore to your target dependencies:")
-	ui.InfoMsg(`  .product(name: "FirebaseCore", package: "firebase-ios-sdk")`)
-	ui.InfoMsg("")
-	ui.InfoMsg("📋 Then run:")
-	ui.InfoMsg("  swift package resolve")
-	ui.InfoMsg("")
+// firebaseSwiftPackageName is the Firebase iOS SDK's package identifier, as
+// Package.swift's .product(package:) entries reference it.
+const firebaseSwiftPackageName = "firebase-ios-sdk"
 
-	// Ask user to confirm before proceeding
-	ui.InfoMsg("🤔 Have you completed the above steps?")
-	ui.InfoMsg("   Type 'yes' to continue with Firebase initialization code setup")
-	ui.InfoMsg("   Type 'no' or press Enter to skip code setup for now")
-	ui.InfoMsg("")
+// setupSPMPackageSwift wires the Firebase iOS SDK into a Package.swift
+// manifest via internal/packageswift: it adds firebase-ios-sdk to the
+// top-level dependencies: array (if missing) and the products
+// config.Modules selects to the first target's dependencies: array (if
+// missing), then leaves runPackageManagerCommands/updateSwiftPackages to
+// run `swift package resolve` afterwards.
+func (p *IOSPlatform) setupSPMPackageSwift(config *firebase.Config) error {
+	const packagePath = "Package.swift"
 
-	var response string
-	fmt.Print("Continue with code setup? (yes/no): ")
-	fmt.Scanln(&response)
+	content, err := os.ReadFile(packagePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", packagePath, err)
+	}
 
-	response = strings.ToLower(strings.TrimSpace(response))
-	if response != "yes" && response != "y" {
-		ui.InfoMsg("⏸️  Code setup skipped. Run 'nativefire configure' again after adding Firebase SDK.")
-		ui.InfoMsg("💡 Reminder: Don't forget to add your GoogleService-Info.plist to your project!")
-		return fmt.Errorf("user chose to skip code setup")
+	updated, depAdded, err := packageswift.EnsurePackageDependency(string(content), firebaseSwiftPackageURL, swiftPackageVersion(config))
+	if err != nil {
+		return err
+	}
+
+	targetName, err := packageswift.FirstTargetName(updated)
+	if err != nil {
+		return err
+	}
+
+	updated, productsAdded, err := packageswift.EnsureTargetProductDependencies(updated, targetName, firebaseSwiftPackageName, productsForConfig(config))
+	if err != nil {
+		return err
+	}
+
+	if !depAdded && !productsAdded {
+		return nil
+	}
+
+	if err := os.WriteFile(packagePath, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("failed to update %s: %w", packagePath, err)
 	}
 
-	ui.SuccessMsg("✅ Proceeding with Firebase initialization code setup...")
+	ui.SuccessMsg(fmt.Sprintf("Added the Firebase iOS SDK to %s", packagePath))
 	return nil
 }
 
@@ -0,0 +1,437 @@
+// Package xcodeproj parses a project.pbxproj's object graph well enough to
+// answer the structural questions IOSPlatform needs - which file is a given
+// target's actual app entry point, its full group-resolved path, and its
+// INFOPLIST_FILE/PRODUCT_BUNDLE_IDENTIFIER - instead of guessing from
+// directory names and filename globbing the way findProjectName/
+// findAppDelegate/determineAppDelegatePath do.
+//
+// Unlike internal/apple's ParseBuildConfigurations (which maps
+// XCBuildConfiguration purely by name, so two targets' same-named
+// "Release" configuration collide in its result), every lookup here is
+// scoped to one PBXNativeTarget's own buildConfigurationList - the only way
+// to get correct per-target answers out of a project with more than one
+// target, or a workspace that references more than one .xcodeproj.
+package xcodeproj
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/clix-so/nativefire/internal/apple"
+)
+
+// SourceFile is one PBXBuildFile this package followed from a target's
+// PBXSourcesBuildPhase through PBXFileReference and the PBXGroup tree back
+// to a real path.
+type SourceFile struct {
+	// Path is resolved through the full PBXGroup chain to the .xcodeproj's
+	// mainGroup, so it reflects the actual relative path a custom source
+	// root or regrouped file uses - not just its PBXFileReference's own
+	// path field.
+	Path string
+	// Language is "swift", "objc", "objc++", or "" for an extension this
+	// package doesn't recognize.
+	Language string
+}
+
+// Target is one PBXNativeTarget, with its Info.plist path, bundle
+// identifier, and source files resolved from its own buildConfigurationList
+// and PBXSourcesBuildPhase rather than any other target's.
+type Target struct {
+	Name             string
+	ProductType      string
+	InfoPlistFile    string
+	BundleIdentifier string
+	Sources          []SourceFile
+}
+
+// Project is the subset of a project.pbxproj's object graph this package
+// resolves: every PBXNativeTarget, each with its own sources and settings.
+type Project struct {
+	Path    string
+	Targets []Target
+}
+
+// applicationProductType is the productType PBXNativeTarget uses for an
+// app's main executable target, as opposed to a test bundle, app extension,
+// or framework target.
+const applicationProductType = "com.apple.product-type.application"
+
+// PrimaryTarget returns proj's first application target, skipping any
+// test/extension/framework targets also present in a multi-target project.
+func (proj *Project) PrimaryTarget() (*Target, error) {
+	for i := range proj.Targets {
+		if proj.Targets[i].ProductType == applicationProductType {
+			return &proj.Targets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no application target found in %s", proj.Path)
+}
+
+// AppEntryPoint returns PrimaryTarget's AppDelegate/App source file,
+// matched by filename convention (AppDelegate.swift, AppDelegate.m, or a
+// SwiftUI App.swift) rather than reading every source file on disk to look
+// for @main/@UIApplicationMain.
+func (proj *Project) AppEntryPoint() (*SourceFile, error) {
+	target, err := proj.PrimaryTarget()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range target.Sources {
+		switch filepath.Base(target.Sources[i].Path) {
+		case "AppDelegate.swift", "AppDelegate.m", "App.swift":
+			return &target.Sources[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no AppDelegate/App entry point found in target %q", target.Name)
+}
+
+var (
+	nativeTargetSectionRe = regexp.MustCompile(
+		`(?s)/\* Begin PBXNativeTarget section \*/\n(.*?)/\* End PBXNativeTarget section \*/`)
+	nativeTargetEntryRe = regexp.MustCompile(
+		`(?s)[0-9A-F]{24} /\* (.+?) \*/ = \{\s*isa = PBXNativeTarget;(.*?)\n\t\t\};`)
+	targetProductTypeRe  = regexp.MustCompile(`productType = "?([\w.\-]+)"?;`)
+	targetBuildConfigRe  = regexp.MustCompile(`buildConfigurationList = ([0-9A-F]{24})`)
+	targetBuildPhasesRe  = regexp.MustCompile(`(?s)buildPhases = \((.*?)\);`)
+	sourcesPhaseRefInRe  = regexp.MustCompile(`([0-9A-F]{24}) /\* Sources \*/`)
+
+	sourcesPhaseSectionRe = regexp.MustCompile(
+		`(?s)/\* Begin PBXSourcesBuildPhase section \*/\n(.*?)/\* End PBXSourcesBuildPhase section \*/`)
+	sourcesPhaseEntryRe = regexp.MustCompile(
+		`(?s)([0-9A-F]{24}) /\* Sources \*/ = \{\s*isa = PBXSourcesBuildPhase;(.*?)\n\t\t\};`)
+	sourcesPhaseFilesRe = regexp.MustCompile(`(?s)files = \((.*?)\);`)
+
+	buildFileSectionRe = regexp.MustCompile(
+		`(?s)/\* Begin PBXBuildFile section \*/\n(.*?)/\* End PBXBuildFile section \*/`)
+	buildFileEntryRe = regexp.MustCompile(
+		`([0-9A-F]{24}) /\* .+? in Sources \*/ = \{isa = PBXBuildFile; fileRef = ([0-9A-F]{24})`)
+
+	fileRefSectionRe = regexp.MustCompile(
+		`(?s)/\* Begin PBXFileReference section \*/\n(.*?)/\* End PBXFileReference section \*/`)
+	fileRefEntryRe = regexp.MustCompile(
+		`(?s)([0-9A-F]{24}) /\* (.+?) \*/ = \{isa = PBXFileReference;(.*?)\};`)
+	fileRefPathRe = regexp.MustCompile(`path = "?([^";]+)"?;`)
+	fileRefNameRe = regexp.MustCompile(`name = "?([^";]+)"?;`)
+
+	groupSectionRe = regexp.MustCompile(
+		`(?s)/\* Begin PBXGroup section \*/\n(.*?)/\* End PBXGroup section \*/`)
+	groupEntryRe = regexp.MustCompile(
+		`(?s)([0-9A-F]{24}) /\* (.+?) \*/ = \{\s*isa = PBXGroup;(.*?)\n\t\t\};`)
+	groupChildrenRe = regexp.MustCompile(`(?s)children = \((.*?)\);`)
+	groupPathRe     = regexp.MustCompile(`path = "?([^";]+)"?;`)
+	childRefRe      = regexp.MustCompile(`([0-9A-F]{24})`)
+
+	projectSectionRe = regexp.MustCompile(
+		`(?s)/\* Begin PBXProject section \*/\n(.*?)/\* End PBXProject section \*/`)
+	mainGroupRe = regexp.MustCompile(`mainGroup = ([0-9A-F]{24})`)
+
+	configListSectionRe = regexp.MustCompile(
+		`(?s)/\* Begin XCConfigurationList section \*/\n(.*?)/\* End XCConfigurationList section \*/`)
+	configListEntryRe = regexp.MustCompile(
+		`(?s)([0-9A-F]{24}) /\* .+? \*/ = \{\s*isa = XCConfigurationList;(.*?)\n\t\t\};`)
+	configListConfigsRe = regexp.MustCompile(`(?s)buildConfigurations = \((.*?)\);`)
+	configRefRe         = regexp.MustCompile(`([0-9A-F]{24}) /\* (\w+) \*/`)
+
+	buildConfigSectionRe = regexp.MustCompile(
+		`(?s)/\* Begin XCBuildConfiguration section \*/\n(.*?)/\* End XCBuildConfiguration section \*/`)
+	buildConfigEntryRe = regexp.MustCompile(
+		`(?s)([0-9A-F]{24}) /\* \w+ \*/ = \{\s*isa = XCBuildConfiguration;(.*?)\n\t\t\};`)
+	buildSettingsBlockRe = regexp.MustCompile(`(?s)buildSettings = \{(.*?)\n\t+\};`)
+	settingLineRe        = regexp.MustCompile(`(?m)^\s*([A-Za-z_][A-Za-z0-9_]*(?:\[[^\]]*])?)\s*=\s*(.+?);\s*$`)
+)
+
+// groupNode is one parsed PBXGroup: its own path component (if any) and its
+// ordered list of child object IDs (each either another group or a
+// PBXFileReference).
+type groupNode struct {
+	path     string
+	children []string
+}
+
+// fileRefNode is one parsed PBXFileReference: its path relative to its
+// containing group, falling back to its name when no path is set (e.g. a
+// file referenced only by a Core Data model's component).
+type fileRefNode struct {
+	path string
+}
+
+// Parse reads pbxprojPath and resolves every PBXNativeTarget's sources and
+// per-target build settings.
+func Parse(pbxprojPath string) (*Project, error) {
+	data, err := os.ReadFile(pbxprojPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", pbxprojPath, err)
+	}
+	content := string(data)
+
+	fileRefs := parseFileReferences(content)
+	groups := parseGroups(content)
+	resolvedPaths := resolveGroupPaths(mainGroupID(content), groups, fileRefs)
+	buildFiles := parseBuildFiles(content)
+	sourcesPhases := parseSourcesPhases(content)
+	buildConfigs := parseBuildConfigurations(content)
+	configLists := parseConfigurationLists(content)
+
+	section := nativeTargetSectionRe.FindStringSubmatch(content)
+	if section == nil {
+		return nil, fmt.Errorf("no PBXNativeTarget section found in %s", pbxprojPath)
+	}
+
+	var targets []Target
+	for _, entry := range nativeTargetEntryRe.FindAllStringSubmatch(section[1], -1) {
+		name, body := entry[1], entry[2]
+
+		target := Target{Name: name}
+		if m := targetProductTypeRe.FindStringSubmatch(body); m != nil {
+			target.ProductType = m[1]
+		}
+
+		if m := targetBuildPhasesRe.FindStringSubmatch(body); m != nil {
+			if phaseID := sourcesPhaseRefInRe.FindStringSubmatch(m[1]); phaseID != nil {
+				for _, fileID := range sourcesPhases[phaseID[1]] {
+					refID, ok := buildFiles[fileID]
+					if !ok {
+						continue
+					}
+					path, ok := resolvedPaths[refID]
+					if !ok {
+						continue
+					}
+					target.Sources = append(target.Sources, SourceFile{Path: path, Language: languageForPath(path)})
+				}
+			}
+		}
+
+		if m := targetBuildConfigRe.FindStringSubmatch(body); m != nil {
+			settings := selectTargetSettings(configLists[m[1]], buildConfigs)
+			if raw, ok := settings["INFOPLIST_FILE"]; ok {
+				target.InfoPlistFile = apple.ResolveVariable(raw, settings)
+			}
+			if raw, ok := settings["PRODUCT_BUNDLE_IDENTIFIER"]; ok {
+				target.BundleIdentifier = apple.ResolveVariable(raw, settings)
+			}
+		}
+
+		targets = append(targets, target)
+	}
+
+	return &Project{Path: pbxprojPath, Targets: targets}, nil
+}
+
+// languageForPath guesses a source file's language from its extension -
+// the same signal Xcode itself uses to pick a syntax-highlighting lexer.
+func languageForPath(path string) string {
+	switch {
+	case strings.HasSuffix(path, ".swift"):
+		return "swift"
+	case strings.HasSuffix(path, ".mm"):
+		return "objc++"
+	case strings.HasSuffix(path, ".m"):
+		return "objc"
+	default:
+		return ""
+	}
+}
+
+func mainGroupID(content string) string {
+	section := projectSectionRe.FindStringSubmatch(content)
+	if section == nil {
+		return ""
+	}
+	m := mainGroupRe.FindStringSubmatch(section[1])
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+func parseFileReferences(content string) map[string]fileRefNode {
+	refs := map[string]fileRefNode{}
+	section := fileRefSectionRe.FindStringSubmatch(content)
+	if section == nil {
+		return refs
+	}
+	for _, entry := range fileRefEntryRe.FindAllStringSubmatch(section[1], -1) {
+		id, comment, body := entry[1], entry[2], entry[3]
+		path := comment
+		if m := fileRefPathRe.FindStringSubmatch(body); m != nil {
+			path = m[1]
+		} else if m := fileRefNameRe.FindStringSubmatch(body); m != nil {
+			path = m[1]
+		}
+		refs[id] = fileRefNode{path: path}
+	}
+	return refs
+}
+
+func parseGroups(content string) map[string]groupNode {
+	groups := map[string]groupNode{}
+	section := groupSectionRe.FindStringSubmatch(content)
+	if section == nil {
+		return groups
+	}
+	for _, entry := range groupEntryRe.FindAllStringSubmatch(section[1], -1) {
+		id, body := entry[1], entry[3]
+
+		node := groupNode{}
+		if m := groupPathRe.FindStringSubmatch(body); m != nil {
+			node.path = m[1]
+		}
+		if m := groupChildrenRe.FindStringSubmatch(body); m != nil {
+			for _, child := range childRefRe.FindAllStringSubmatch(m[1], -1) {
+				node.children = append(node.children, child[1])
+			}
+		}
+		groups[id] = node
+	}
+	return groups
+}
+
+// resolveGroupPaths walks the PBXGroup tree from mainGroup, joining each
+// group's own path component onto its parent's, and records the resulting
+// path for every PBXFileReference it reaches - so a file moved into a
+// regrouped subfolder resolves to where it actually lives instead of its
+// PBXFileReference's own (often stale or relative-to-nothing) path field.
+func resolveGroupPaths(mainGroup string, groups map[string]groupNode, fileRefs map[string]fileRefNode) map[string]string {
+	resolved := map[string]string{}
+	visited := map[string]bool{}
+	var walk func(groupID, prefix string)
+	walk = func(groupID, prefix string) {
+		if visited[groupID] {
+			return
+		}
+		visited[groupID] = true
+
+		group, ok := groups[groupID]
+		if !ok {
+			return
+		}
+		base := prefix
+		if group.path != "" {
+			base = filepath.Join(base, group.path)
+		}
+
+		for _, childID := range group.children {
+			if _, isGroup := groups[childID]; isGroup {
+				walk(childID, base)
+				continue
+			}
+			if file, ok := fileRefs[childID]; ok {
+				resolved[childID] = filepath.Join(base, file.path)
+			}
+		}
+	}
+	walk(mainGroup, "")
+	return resolved
+}
+
+func parseBuildFiles(content string) map[string]string {
+	buildFiles := map[string]string{}
+	section := buildFileSectionRe.FindStringSubmatch(content)
+	if section == nil {
+		return buildFiles
+	}
+	for _, entry := range buildFileEntryRe.FindAllStringSubmatch(section[1], -1) {
+		buildFiles[entry[1]] = entry[2]
+	}
+	return buildFiles
+}
+
+func parseSourcesPhases(content string) map[string][]string {
+	phases := map[string][]string{}
+	section := sourcesPhaseSectionRe.FindStringSubmatch(content)
+	if section == nil {
+		return phases
+	}
+	for _, entry := range sourcesPhaseEntryRe.FindAllStringSubmatch(section[1], -1) {
+		id, body := entry[1], entry[2]
+		m := sourcesPhaseFilesRe.FindStringSubmatch(body)
+		if m == nil {
+			continue
+		}
+		for _, fileID := range childRefRe.FindAllStringSubmatch(m[1], -1) {
+			phases[id] = append(phases[id], fileID[1])
+		}
+	}
+	return phases
+}
+
+// configListEntry is one XCConfigurationList: the ordered (configID, name)
+// pairs it lists, e.g. [{ID1, "Debug"}, {ID2, "Release"}].
+type configListEntry struct {
+	id   string
+	name string
+}
+
+func parseConfigurationLists(content string) map[string][]configListEntry {
+	lists := map[string][]configListEntry{}
+	section := configListSectionRe.FindStringSubmatch(content)
+	if section == nil {
+		return lists
+	}
+	for _, entry := range configListEntryRe.FindAllStringSubmatch(section[1], -1) {
+		id, body := entry[1], entry[2]
+		m := configListConfigsRe.FindStringSubmatch(body)
+		if m == nil {
+			continue
+		}
+		var configs []configListEntry
+		for _, ref := range configRefRe.FindAllStringSubmatch(m[1], -1) {
+			configs = append(configs, configListEntry{id: ref[1], name: ref[2]})
+		}
+		lists[id] = configs
+	}
+	return lists
+}
+
+// parseBuildConfigurations reads every XCBuildConfiguration object keyed by
+// its own object ID, not by name: two targets can each have a "Release"
+// configuration, and only the ID tells them apart.
+func parseBuildConfigurations(content string) map[string]apple.BuildSettings {
+	configs := map[string]apple.BuildSettings{}
+	section := buildConfigSectionRe.FindStringSubmatch(content)
+	if section == nil {
+		return configs
+	}
+	for _, entry := range buildConfigEntryRe.FindAllStringSubmatch(section[1], -1) {
+		id, body := entry[1], entry[2]
+		settings := apple.BuildSettings{}
+		if block := buildSettingsBlockRe.FindStringSubmatch(body); block != nil {
+			for _, m := range settingLineRe.FindAllStringSubmatch(block[1], -1) {
+				settings[m[1]] = strings.Trim(strings.TrimSpace(m[2]), `"`)
+			}
+		}
+		configs[id] = settings
+	}
+	return configs
+}
+
+// selectTargetSettings picks one target's build settings from its own
+// XCConfigurationList entries, preferring Release, then Debug, then
+// whichever configuration is listed first - the same preference apple's
+// (unexported) selectConfiguration applies when asked for no configuration
+// in particular.
+func selectTargetSettings(entries []configListEntry, configs map[string]apple.BuildSettings) apple.BuildSettings {
+	byName := map[string]string{}
+	for _, entry := range entries {
+		byName[entry.name] = entry.id
+	}
+	for _, name := range []string{"Release", "Debug"} {
+		if id, ok := byName[name]; ok {
+			if settings, ok := configs[id]; ok {
+				return settings
+			}
+		}
+	}
+	for _, entry := range entries {
+		if settings, ok := configs[entry.id]; ok {
+			return settings
+		}
+	}
+	return apple.BuildSettings{}
+}
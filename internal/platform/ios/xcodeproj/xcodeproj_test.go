@@ -0,0 +1,239 @@
+package xcodeproj
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// sampleMultiTargetPbxproj has two PBXNativeTargets (an app and its unit
+// test bundle) whose XCConfigurationLists each define their own same-named
+// "Release" configuration with a different PRODUCT_BUNDLE_IDENTIFIER and
+// INFOPLIST_FILE, and a regrouped AppDelegate.swift nested two levels under
+// a renamed subgroup rather than sitting next to the main group.
+const sampleMultiTargetPbxproj = `// !$*UTF8*$!
+{
+	objects = {
+		/* Begin PBXBuildFile section */
+		B10000000000000000000001 /* AppDelegate.swift in Sources */ = {isa = PBXBuildFile; fileRef = FA0000000000000000000001 /* AppDelegate.swift */; };
+		B20000000000000000000002 /* AppTests.swift in Sources */ = {isa = PBXBuildFile; fileRef = FB0000000000000000000002 /* AppTests.swift */; };
+		/* End PBXBuildFile section */
+
+		/* Begin PBXFileReference section */
+		FA0000000000000000000001 /* AppDelegate.swift */ = {isa = PBXFileReference; lastKnownFileType = sourcecode.swift; path = AppDelegate.swift; sourceTree = "<group>"; };
+		FB0000000000000000000002 /* AppTests.swift */ = {isa = PBXFileReference; lastKnownFileType = sourcecode.swift; path = AppTests.swift; sourceTree = "<group>"; };
+		/* End PBXFileReference section */
+
+		/* Begin PBXGroup section */
+		AC0000000000000000000001 /* MainGroup */ = {
+			isa = PBXGroup;
+			children = (
+				AD0000000000000000000002 /* Sources */,
+			);
+			sourceTree = "<group>";
+		};
+		AD0000000000000000000002 /* Sources */ = {
+			isa = PBXGroup;
+			children = (
+				FA0000000000000000000001 /* AppDelegate.swift */,
+				FB0000000000000000000002 /* AppTests.swift */,
+			);
+			path = App;
+			sourceTree = "<group>";
+		};
+		/* End PBXGroup section */
+
+		/* Begin PBXNativeTarget section */
+		DA0000000000000000000001 /* App */ = {
+			isa = PBXNativeTarget;
+			buildConfigurationList = FD0000000000000000000001;
+			buildPhases = (
+				CA0000000000000000000001 /* Sources */,
+			);
+			name = App;
+			productName = App;
+			productType = "com.apple.product-type.application";
+		};
+		DB0000000000000000000002 /* AppTests */ = {
+			isa = PBXNativeTarget;
+			buildConfigurationList = FC0000000000000000000002;
+			buildPhases = (
+				CB0000000000000000000002 /* Sources */,
+			);
+			name = AppTests;
+			productName = AppTests;
+			productType = "com.apple.product-type.bundle.unit-test";
+		};
+		/* End PBXNativeTarget section */
+
+		/* Begin PBXProject section */
+		FE0000000000000000000001 /* Project object */ = {
+			isa = PBXProject;
+			mainGroup = AC0000000000000000000001;
+		};
+		/* End PBXProject section */
+
+		/* Begin PBXSourcesBuildPhase section */
+		CA0000000000000000000001 /* Sources */ = {
+			isa = PBXSourcesBuildPhase;
+			files = (
+				B10000000000000000000001 /* AppDelegate.swift in Sources */,
+			);
+		};
+		CB0000000000000000000002 /* Sources */ = {
+			isa = PBXSourcesBuildPhase;
+			files = (
+				B20000000000000000000002 /* AppTests.swift in Sources */,
+			);
+		};
+		/* End PBXSourcesBuildPhase section */
+
+		/* Begin XCBuildConfiguration section */
+		BE0000000000000000000001 /* Release */ = {
+			isa = XCBuildConfiguration;
+			buildSettings = {
+				INFOPLIST_FILE = "App/Info.plist";
+				PRODUCT_BUNDLE_IDENTIFIER = "com.example.app";
+			};
+			name = Release;
+		};
+		BD0000000000000000000002 /* Release */ = {
+			isa = XCBuildConfiguration;
+			buildSettings = {
+				INFOPLIST_FILE = "AppTests/Info.plist";
+				PRODUCT_BUNDLE_IDENTIFIER = "com.example.app.tests";
+			};
+			name = Release;
+		};
+		/* End XCBuildConfiguration section */
+
+		/* Begin XCConfigurationList section */
+		FD0000000000000000000001 /* Build configuration list for PBXNativeTarget "App" */ = {
+			isa = XCConfigurationList;
+			buildConfigurations = (
+				BE0000000000000000000001 /* Release */,
+			);
+			defaultConfigurationName = Release;
+		};
+		FC0000000000000000000002 /* Build configuration list for PBXNativeTarget "AppTests" */ = {
+			isa = XCConfigurationList;
+			buildConfigurations = (
+				BD0000000000000000000002 /* Release */,
+			);
+			defaultConfigurationName = Release;
+		};
+		/* End XCConfigurationList section */
+	};
+}
+`
+
+func writeMultiTargetProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	xcodeprojDir := filepath.Join(dir, "App.xcodeproj")
+	if err := os.MkdirAll(xcodeprojDir, 0o755); err != nil {
+		t.Fatalf("failed to create .xcodeproj dir: %v", err)
+	}
+	pbxprojPath := filepath.Join(xcodeprojDir, "project.pbxproj")
+	if err := os.WriteFile(pbxprojPath, []byte(sampleMultiTargetPbxproj), 0o644); err != nil {
+		t.Fatalf("failed to write pbxproj: %v", err)
+	}
+	return pbxprojPath
+}
+
+func TestParseResolvesEachTargetsOwnSettingsWithoutCollision(t *testing.T) {
+	project, err := Parse(writeMultiTargetProject(t))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(project.Targets) != 2 {
+		t.Fatalf("expected 2 targets, got %d: %+v", len(project.Targets), project.Targets)
+	}
+
+	byName := map[string]Target{}
+	for _, target := range project.Targets {
+		byName[target.Name] = target
+	}
+
+	app := byName["App"]
+	if app.BundleIdentifier != "com.example.app" {
+		t.Errorf("App BundleIdentifier = %q, want com.example.app", app.BundleIdentifier)
+	}
+	if app.InfoPlistFile != "App/Info.plist" {
+		t.Errorf("App InfoPlistFile = %q, want App/Info.plist", app.InfoPlistFile)
+	}
+
+	tests := byName["AppTests"]
+	if tests.BundleIdentifier != "com.example.app.tests" {
+		t.Errorf("AppTests BundleIdentifier = %q, want com.example.app.tests (not App's)", tests.BundleIdentifier)
+	}
+	if tests.InfoPlistFile != "AppTests/Info.plist" {
+		t.Errorf("AppTests InfoPlistFile = %q, want AppTests/Info.plist", tests.InfoPlistFile)
+	}
+}
+
+func TestParseResolvesRegroupedSourcePaths(t *testing.T) {
+	project, err := Parse(writeMultiTargetProject(t))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	target, err := project.PrimaryTarget()
+	if err != nil {
+		t.Fatalf("PrimaryTarget() error = %v", err)
+	}
+	if len(target.Sources) != 1 {
+		t.Fatalf("expected 1 source file, got %+v", target.Sources)
+	}
+
+	want := filepath.Join("App", "AppDelegate.swift")
+	if target.Sources[0].Path != want {
+		t.Errorf("Sources[0].Path = %q, want %q (resolved through the renamed Sources subgroup)", target.Sources[0].Path, want)
+	}
+	if target.Sources[0].Language != "swift" {
+		t.Errorf("Sources[0].Language = %q, want swift", target.Sources[0].Language)
+	}
+}
+
+func TestPrimaryTargetSkipsTestBundle(t *testing.T) {
+	project, err := Parse(writeMultiTargetProject(t))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	target, err := project.PrimaryTarget()
+	if err != nil {
+		t.Fatalf("PrimaryTarget() error = %v", err)
+	}
+	if target.Name != "App" {
+		t.Errorf("PrimaryTarget().Name = %q, want App", target.Name)
+	}
+}
+
+func TestAppEntryPointFindsAppDelegate(t *testing.T) {
+	project, err := Parse(writeMultiTargetProject(t))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	entry, err := project.AppEntryPoint()
+	if err != nil {
+		t.Fatalf("AppEntryPoint() error = %v", err)
+	}
+	if filepath.Base(entry.Path) != "AppDelegate.swift" {
+		t.Errorf("AppEntryPoint().Path = %q, want it to end in AppDelegate.swift", entry.Path)
+	}
+}
+
+func TestAppEntryPointErrorsWithoutOne(t *testing.T) {
+	project := &Project{Path: "fake", Targets: []Target{{Name: "App", ProductType: applicationProductType}}}
+	if _, err := project.AppEntryPoint(); err == nil {
+		t.Fatal("expected an error when the primary target has no recognizable entry point")
+	}
+}
+
+func TestParseErrorsWhenFileMissing(t *testing.T) {
+	if _, err := Parse(filepath.Join(t.TempDir(), "missing.pbxproj")); err == nil {
+		t.Fatal("expected an error for a missing pbxproj file")
+	}
+}
@@ -2,8 +2,11 @@ package platform
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/clix-so/nativefire/internal/firebase"
 )
 
 func TestIOSPlatformBasics(t *testing.T) {
@@ -24,6 +27,30 @@ func TestIOSPlatformBasics(t *testing.T) {
 }
 
 func TestIOSPlatformDetect(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    bool
+	}{
+		{fixture: "empty-project", want: false},
+		{fixture: "flutter-ios", want: true},
+		{fixture: "react-native-ios", want: true},
+		{fixture: "xcodegen-ios", want: true},
+		{fixture: "multi-target-ios", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			LoadFixture(t, tt.fixture)
+
+			platform := &IOSPlatform{}
+			if got := platform.Detect(); got != tt.want {
+				t.Errorf("Detect() for fixture %q = %v, want %v", tt.fixture, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIOSPlatformConfigPath(t *testing.T) {
 	platform := &IOSPlatform{}
 
 	// Create temp directory for testing
@@ -38,77 +65,757 @@ func TestIOSPlatformDetect(t *testing.T) {
 	defer func() { _ = os.Chdir(oldWd) }()
 	_ = os.Chdir(tmpDir)
 
-	// Test detection without iOS indicators
-	if platform.Detect() {
-		t.Error("Expected Detect() to return false with no iOS indicators")
+	// Test default config path
+	if platform.ConfigPath() != "." {
+		t.Errorf("Expected ConfigPath() to return '.', got '%s'", platform.ConfigPath())
 	}
 
-	// Test detection with ios directory
+	// Test with ios directory
 	_ = os.Mkdir("ios", 0755)
-	if !platform.Detect() {
-		t.Error("Expected Detect() to return true with ios directory")
+	if platform.ConfigPath() != "ios" {
+		t.Errorf("Expected ConfigPath() to return 'ios', got '%s'", platform.ConfigPath())
+	}
+}
+
+func TestIOSPlatformInstallMultiEnvConfig(t *testing.T) {
+	platform := &IOSPlatform{}
+
+	tmpDir, err := os.MkdirTemp("", "ios_test")
+	if err != nil {
+		t.Fatal(err)
 	}
-	os.RemoveAll("ios")
+	defer os.RemoveAll(tmpDir)
 
-	// Test detection with Podfile
-	f, _ := os.Create("Podfile")
-	f.Close()
-	if !platform.Detect() {
-		t.Error("Expected Detect() to return true with Podfile")
+	oldWd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldWd) }()
+	_ = os.Chdir(tmpDir)
+
+	_ = os.Mkdir("ios", 0755)
+	_ = os.WriteFile("pubspec.yaml", []byte("name: my_app\n"), 0644)
+
+	sourcePath := filepath.Join(tmpDir, "source.plist")
+	if err := os.WriteFile(sourcePath, []byte("<plist/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &firebase.Config{
+		ProjectID:  "my-project",
+		AppID:      "1:123:ios:abc",
+		SourcePath: sourcePath,
+		Env:        "staging",
+	}
+
+	if err := platform.InstallConfig(config); err != nil {
+		t.Fatalf("InstallConfig() error = %v", err)
+	}
+
+	installedPath := filepath.Join("ios", multiEnvConfigDir, "staging", googleServiceInfoPlist)
+	if !fileExists(installedPath) {
+		t.Fatalf("expected %s to be installed", installedPath)
+	}
+
+	appIDFilePath := filepath.Join("ios", "firebase_app_id_file.json")
+	appIDFileData, err := os.ReadFile(appIDFilePath)
+	if err != nil {
+		t.Fatalf("expected firebase_app_id_file.json to be written: %v", err)
+	}
+	if !strings.Contains(string(appIDFileData), config.AppID) {
+		t.Fatalf("expected firebase_app_id_file.json to contain the app ID, got:\n%s", appIDFileData)
 	}
 }
 
-func TestIOSPlatformConfigPath(t *testing.T) {
+func TestPodsForConfig(t *testing.T) {
+	config := &firebase.Config{Modules: []string{"Messaging", "firestore", "unknown-module"}}
+
+	pods := podsForConfig(config)
+	if pods[0] != "Firebase/Core" {
+		t.Errorf("expected Firebase/Core to always lead the pod list, got %v", pods)
+	}
+	for _, want := range []string{"Firebase/Messaging", "Firebase/Firestore"} {
+		found := false
+		for _, pod := range pods {
+			if pod == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected %s in %v", want, pods)
+		}
+	}
+	if len(pods) != 3 {
+		t.Errorf("expected unknown-module to be skipped, got %v", pods)
+	}
+}
+
+func TestIOSPlatformGeneratePodfile(t *testing.T) {
 	platform := &IOSPlatform{}
 
-	// Create temp directory for testing
 	tmpDir, err := os.MkdirTemp("", "ios_test")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer os.RemoveAll(tmpDir)
 
-	// Change to temp directory
 	oldWd, _ := os.Getwd()
 	defer func() { _ = os.Chdir(oldWd) }()
 	_ = os.Chdir(tmpDir)
 
-	// Test default config path
-	if platform.ConfigPath() != "." {
-		t.Errorf("Expected ConfigPath() to return '.', got '%s'", platform.ConfigPath())
+	_ = os.Mkdir("MyApp.xcodeproj", 0755)
+
+	config := &firebase.Config{Modules: []string{"messaging"}}
+
+	podfilePath, err := platform.generatePodfile(config)
+	if err != nil {
+		t.Fatalf("generatePodfile() error = %v", err)
+	}
+	if podfilePath != "Podfile" {
+		t.Errorf("expected Podfile at repo root, got %s", podfilePath)
 	}
 
-	// Test with ios directory
-	_ = os.Mkdir("ios", 0755)
-	if platform.ConfigPath() != "ios" {
-		t.Errorf("Expected ConfigPath() to return 'ios', got '%s'", platform.ConfigPath())
+	content, err := os.ReadFile(podfilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contentStr := string(content)
+	if !strings.Contains(contentStr, "target 'MyApp' do") {
+		t.Errorf("expected generated Podfile to target MyApp, got:\n%s", contentStr)
+	}
+	if !strings.Contains(contentStr, "pod 'Firebase/Core'") || !strings.Contains(contentStr, "pod 'Firebase/Messaging'") {
+		t.Errorf("expected generated Podfile to include the configured Firebase pods, got:\n%s", contentStr)
 	}
 }
 
-func TestIOSPlatformFindProjectName(t *testing.T) {
+func TestIOSPlatformAddFirebasePodsIsIdempotent(t *testing.T) {
+	platform := &IOSPlatform{}
+
+	tmpDir, err := os.MkdirTemp("", "ios_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	podfilePath := filepath.Join(tmpDir, "Podfile")
+	original := "# custom comment\ntarget 'Runner' do\n  pod 'Firebase/Core'\nend\n"
+	if err := os.WriteFile(podfilePath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &firebase.Config{Modules: []string{"messaging"}}
+
+	if err := platform.addFirebasePods(podfilePath, config); err != nil {
+		t.Fatalf("addFirebasePods() error = %v", err)
+	}
+
+	content, err := os.ReadFile(podfilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contentStr := string(content)
+	if !strings.Contains(contentStr, "# custom comment") {
+		t.Error("expected existing comments to be preserved")
+	}
+	if !strings.Contains(contentStr, "pod 'Firebase/Messaging'") {
+		t.Error("expected Firebase/Messaging to be added")
+	}
+
+	if err := platform.addFirebasePods(podfilePath, config); err != nil {
+		t.Fatalf("second addFirebasePods() error = %v", err)
+	}
+	again, err := os.ReadFile(podfilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(again), "pod 'Firebase/Messaging'") != 1 {
+		t.Error("expected addFirebasePods to be idempotent")
+	}
+}
+
+func TestProductsForConfig(t *testing.T) {
+	config := &firebase.Config{Modules: []string{"Messaging", "analytics", "unknown-module"}}
+
+	products := productsForConfig(config)
+	if products[0] != "FirebaseCore" {
+		t.Errorf("expected FirebaseCore to always lead the product list, got %v", products)
+	}
+	for _, want := range []string{"FirebaseMessaging", "FirebaseAnalytics"} {
+		found := false
+		for _, product := range products {
+			if product == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected %s in %v", want, products)
+		}
+	}
+	if len(products) != 3 {
+		t.Errorf("expected unknown-module to be skipped, got %v", products)
+	}
+}
+
+func TestIOSPlatformSetupSPMPackageSwift(t *testing.T) {
+	platform := &IOSPlatform{}
+
+	tmpDir, err := os.MkdirTemp("", "ios_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldWd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldWd) }()
+	_ = os.Chdir(tmpDir)
+
+	packageSwift := `// swift-tools-version:5.7
+import PackageDescription
+
+let package = Package(
+    name: "MyPackage",
+    dependencies: [
+    ],
+    targets: [
+        .target(
+            name: "MyApp",
+            dependencies: []
+        )
+    ]
+)
+`
+	if err := os.WriteFile("Package.swift", []byte(packageSwift), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &firebase.Config{Modules: []string{"messaging"}}
+
+	if err := platform.setupSPMPackageSwift(config); err != nil {
+		t.Fatalf("setupSPMPackageSwift() error = %v", err)
+	}
+
+	content, err := os.ReadFile("Package.swift")
+	if err != nil {
+		t.Fatal(err)
+	}
+	contentStr := string(content)
+	if !strings.Contains(contentStr, `.package(url: "https://github.com/firebase/firebase-ios-sdk", from: "10.24.0")`) {
+		t.Errorf("expected Firebase package dependency to be added, got:\n%s", contentStr)
+	}
+	if !strings.Contains(contentStr, `.product(name: "FirebaseMessaging", package: "firebase-ios-sdk")`) {
+		t.Errorf("expected FirebaseMessaging product to be added, got:\n%s", contentStr)
+	}
+
+	// Re-running should be a no-op, not a second insertion.
+	if err := platform.setupSPMPackageSwift(config); err != nil {
+		t.Fatalf("second setupSPMPackageSwift() error = %v", err)
+	}
+	again, err := os.ReadFile("Package.swift")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(again), "FirebaseMessaging") != 1 {
+		t.Error("expected setupSPMPackageSwift to be idempotent")
+	}
+}
+
+const sampleProjectWithTargetPbxproj = `// !$*UTF8*$!
+{
+	archiveVersion = 1;
+	objects = {
+
+/* Begin PBXNativeTarget section */
+		ABCDEF0000000000000000CC /* MyApp */ = {
+			isa = PBXNativeTarget;
+			buildPhases = (
+			);
+			name = MyApp;
+		};
+/* End PBXNativeTarget section */
+
+/* Begin PBXProject section */
+		ABCDEF0000000000000000DD /* Project object */ = {
+			isa = PBXProject;
+			mainGroup = ABCDEF0000000000000000AA;
+			targets = (
+				ABCDEF0000000000000000CC /* MyApp */,
+			);
+		};
+/* End PBXProject section */
+
+	};
+}
+`
+
+func TestIOSPlatformAddSwiftPackageDependencyAddsEveryConfiguredProduct(t *testing.T) {
+	platform := &IOSPlatform{}
+
+	tmpDir := t.TempDir()
+	xcodeprojDir := filepath.Join(tmpDir, "MyApp.xcodeproj")
+	if err := os.Mkdir(xcodeprojDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	pbxprojPath := filepath.Join(xcodeprojDir, "project.pbxproj")
+	if err := os.WriteFile(pbxprojPath, []byte(sampleProjectWithTargetPbxproj), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &firebase.Config{Modules: []string{"messaging"}}
+
+	if err := platform.addSwiftPackageDependency(xcodeprojDir, config); err != nil {
+		t.Fatalf("addSwiftPackageDependency() error = %v", err)
+	}
+
+	pbxprojData, err := os.ReadFile(pbxprojPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pbxproj := string(pbxprojData)
+	for _, want := range []string{
+		`productName = FirebaseCore`,
+		`productName = FirebaseMessaging`,
+	} {
+		if !strings.Contains(pbxproj, want) {
+			t.Errorf("expected %q in pbxproj, got:\n%s", want, pbxproj)
+		}
+	}
+
+	resolvedPath := filepath.Join(xcodeprojDir, "project.xcworkspace", "xcshareddata", "swiftpm", "Package.resolved")
+	resolvedData, err := os.ReadFile(resolvedPath)
+	if err != nil {
+		t.Fatalf("expected Package.resolved to be written: %v", err)
+	}
+	if !strings.Contains(string(resolvedData), firebaseSwiftPackageURL) {
+		t.Errorf("expected Package.resolved to pin %s, got:\n%s", firebaseSwiftPackageURL, resolvedData)
+	}
+}
+
+func TestIOSPlatformAddSwiftPackageDependencyHonorsVersionOverride(t *testing.T) {
+	platform := &IOSPlatform{}
+
+	tmpDir := t.TempDir()
+	xcodeprojDir := filepath.Join(tmpDir, "MyApp.xcodeproj")
+	if err := os.Mkdir(xcodeprojDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	pbxprojPath := filepath.Join(xcodeprojDir, "project.pbxproj")
+	if err := os.WriteFile(pbxprojPath, []byte(sampleProjectWithTargetPbxproj), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &firebase.Config{Modules: []string{"messaging"}, FirebaseSDKVersion: "11.2.0"}
+
+	if err := platform.addSwiftPackageDependency(xcodeprojDir, config); err != nil {
+		t.Fatalf("addSwiftPackageDependency() error = %v", err)
+	}
+
+	pbxprojData, err := os.ReadFile(pbxprojPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(pbxprojData), `11.2.0`) {
+		t.Errorf("expected FirebaseSDKVersion override 11.2.0 in pbxproj, got:\n%s", pbxprojData)
+	}
+	if strings.Contains(string(pbxprojData), firebaseSwiftPackageVersion) {
+		t.Errorf("expected the default version %s not to appear once overridden, got:\n%s", firebaseSwiftPackageVersion, pbxprojData)
+	}
+}
+
+func TestIOSPlatformSetupSPMPackageSwiftHonorsVersionOverride(t *testing.T) {
 	platform := &IOSPlatform{}
 
-	// Create temp directory for testing
 	tmpDir, err := os.MkdirTemp("", "ios_test")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer os.RemoveAll(tmpDir)
 
-	// Change to temp directory
 	oldWd, _ := os.Getwd()
 	defer func() { _ = os.Chdir(oldWd) }()
 	_ = os.Chdir(tmpDir)
 
-	// Test with no xcodeproj
-	if projectName := platform.findProjectName(); projectName != "" {
-		t.Errorf("Expected empty project name, got '%s'", projectName)
+	packageSwift := `// swift-tools-version:5.7
+import PackageDescription
+
+let package = Package(
+    name: "MyPackage",
+    dependencies: [
+    ],
+    targets: [
+        .target(
+            name: "MyApp",
+            dependencies: []
+        )
+    ]
+)
+`
+	if err := os.WriteFile("Package.swift", []byte(packageSwift), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &firebase.Config{Modules: []string{"messaging"}, FirebaseSDKVersion: "11.2.0"}
+
+	if err := platform.setupSPMPackageSwift(config); err != nil {
+		t.Fatalf("setupSPMPackageSwift() error = %v", err)
 	}
 
-	// Test with xcodeproj
-	_ = os.Mkdir("TestProject.xcodeproj", 0755)
-	if projectName := platform.findProjectName(); projectName != "TestProject" {
-		t.Errorf("Expected project name 'TestProject', got '%s'", projectName)
+	content, err := os.ReadFile("Package.swift")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), `from: "11.2.0"`) {
+		t.Errorf("expected the FirebaseSDKVersion override to be used, got:\n%s", content)
+	}
+}
+
+// sampleProjectWithTargetPbxproj, written with no Podfile alongside it, is
+// shouldUseSPM's "has an Xcode project but no Podfile" case while
+// hasSwiftPackages's own heuristics (Package.swift/.swiftpm/Package.resolved)
+// come up empty; setupSPMFirebase then wires the Firebase products into the
+// pbxproj through the SPM path.
+func TestIOSPlatformSetupSPMFirebaseOnXcodeprojWithoutPodfile(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "ios_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldWd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldWd) }()
+	_ = os.Chdir(tmpDir)
+
+	xcodeprojDir := "MyApp.xcodeproj"
+	if err := os.Mkdir(xcodeprojDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	pbxprojPath := filepath.Join(xcodeprojDir, "project.pbxproj")
+	if err := os.WriteFile(pbxprojPath, []byte(sampleProjectWithTargetPbxproj), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	platform := &IOSPlatform{}
+	if platform.hasSwiftPackages() {
+		t.Fatal("test fixture should have no SPM signal of its own")
+	}
+	if !platform.shouldUseSPM() {
+		t.Fatal("test fixture should be picked up by shouldUseSPM (xcodeproj, no Podfile)")
+	}
+
+	config := &firebase.Config{Modules: []string{"messaging"}, PackageManager: "spm"}
+	if err := platform.setupSPMFirebase(config); err != nil {
+		t.Fatalf("setupSPMFirebase() error = %v", err)
+	}
+
+	pbxprojData, err := os.ReadFile(pbxprojPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(pbxprojData), "productName = FirebaseMessaging") {
+		t.Errorf("expected FirebaseMessaging to be wired via SPM, got:\n%s", pbxprojData)
+	}
+}
+
+func TestIOSPlatformLintFirebasePodConflicts(t *testing.T) {
+	platform := &IOSPlatform{}
+	tmpDir := t.TempDir()
+
+	clean := filepath.Join(tmpDir, "Podfile.clean")
+	if err := os.WriteFile(clean, []byte("target 'Runner' do\n  pod 'Firebase/Core'\n  pod 'FirebaseFirestore'\nend\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if conflicts := platform.lintFirebasePodConflicts(clean); conflicts != nil {
+		t.Errorf("expected no conflicts, got %v", conflicts)
+	}
+
+	conflicting := filepath.Join(tmpDir, "Podfile.conflict")
+	content := "target 'Runner' do\n" +
+		"  pod 'FirebaseFirestore'\n" +
+		"  pod 'FirebaseFirestore', :git => 'https://github.com/invertase/firestore-ios-sdk-frameworks.git', :tag => '10.24.0'\n" +
+		"end\n"
+	if err := os.WriteFile(conflicting, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	conflicts := platform.lintFirebasePodConflicts(conflicting)
+	if len(conflicts) != 1 || conflicts[0] != "FirebaseFirestore" {
+		t.Errorf("expected [FirebaseFirestore] conflict, got %v", conflicts)
+	}
+}
+
+func TestIOSPlatformDedupePrecompiledFirebasePod(t *testing.T) {
+	platform := &IOSPlatform{}
+	tmpDir := t.TempDir()
+
+	podfilePath := filepath.Join(tmpDir, "Podfile")
+	content := "target 'Runner' do\n" +
+		"  pod 'FirebaseFirestore'\n" +
+		"  pod 'FirebaseFirestore', :git => 'https://github.com/invertase/firestore-ios-sdk-frameworks.git', :tag => '10.24.0'\n" +
+		"end\n"
+	if err := os.WriteFile(podfilePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := platform.dedupePrecompiledFirebasePod(podfilePath, []string{"FirebaseFirestore"}); err != nil {
+		t.Fatalf("dedupePrecompiledFirebasePod() error = %v", err)
+	}
+
+	updated, err := os.ReadFile(podfilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(updated), "pod 'FirebaseFirestore'") != 1 {
+		t.Errorf("expected a single remaining FirebaseFirestore declaration, got:\n%s", updated)
+	}
+	if !strings.Contains(string(updated), ":git =>") {
+		t.Error("expected the precompiled :git declaration to be kept")
+	}
+}
+
+func TestIOSPlatformAddDedupeFrameworksPostInstallHook(t *testing.T) {
+	platform := &IOSPlatform{}
+	tmpDir := t.TempDir()
+
+	podfilePath := filepath.Join(tmpDir, "Podfile")
+	if err := os.WriteFile(podfilePath, []byte("target 'Runner' do\n  pod 'Firebase/Core'\nend\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := platform.addDedupeFrameworksPostInstallHook(podfilePath); err != nil {
+		t.Fatalf("addDedupeFrameworksPostInstallHook() error = %v", err)
+	}
+	once, err := os.ReadFile(podfilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(once), "post_install do |installer|") {
+		t.Error("expected a post_install block to be added")
+	}
+	if !strings.Contains(string(once), dedupeFrameworksPostInstallMarker) {
+		t.Error("expected the dedupe marker to be present")
+	}
+
+	if err := platform.addDedupeFrameworksPostInstallHook(podfilePath); err != nil {
+		t.Fatalf("second addDedupeFrameworksPostInstallHook() error = %v", err)
+	}
+	twice, err := os.ReadFile(podfilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(twice), dedupeFrameworksPostInstallMarker) != 1 {
+		t.Error("expected addDedupeFrameworksPostInstallHook to be idempotent")
+	}
+}
+
+func TestIOSPlatformAddDedupeFrameworksPostInstallHookPreservesExistingHook(t *testing.T) {
+	platform := &IOSPlatform{}
+	tmpDir := t.TempDir()
+
+	podfilePath := filepath.Join(tmpDir, "Podfile")
+	original := "target 'Runner' do\n  pod 'Firebase/Core'\nend\n\n" +
+		"post_install do |installer|\n  puts 'custom hook'\nend\n"
+	if err := os.WriteFile(podfilePath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := platform.addDedupeFrameworksPostInstallHook(podfilePath); err != nil {
+		t.Fatalf("addDedupeFrameworksPostInstallHook() error = %v", err)
+	}
+
+	updated, err := os.ReadFile(podfilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(updated), "puts 'custom hook'") {
+		t.Error("expected the existing post_install hook body to be preserved")
+	}
+	if strings.Count(string(updated), "post_install do |installer|") != 1 {
+		t.Error("expected a single post_install block")
+	}
+}
+
+func TestIOSPlatformAddDelegateAdaptorToSwiftUIApp(t *testing.T) {
+	platform := &IOSPlatform{}
+
+	tmpDir, err := os.MkdirTemp("", "ios_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldWd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldWd) }()
+	_ = os.Chdir(tmpDir)
+
+	appSwift := `import SwiftUI
+
+@main
+struct MyApp: App {
+    var body: some Scene {
+        WindowGroup {
+            ContentView()
+        }
+    }
+}
+`
+	if err := os.WriteFile("MyApp.swift", []byte(appSwift), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := platform.addDelegateAdaptorToSwiftUIApp(tmpDir); err != nil {
+		t.Fatalf("addDelegateAdaptorToSwiftUIApp() error = %v", err)
+	}
+
+	content, err := os.ReadFile("MyApp.swift")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "@UIApplicationDelegateAdaptor(AppDelegate.self) var delegate") {
+		t.Errorf("expected delegate adaptor to be inserted, got:\n%s", content)
+	}
+
+	// Re-running should be a no-op, not a second insertion.
+	if err := platform.addDelegateAdaptorToSwiftUIApp(tmpDir); err != nil {
+		t.Fatalf("second addDelegateAdaptorToSwiftUIApp() error = %v", err)
+	}
+	again, err := os.ReadFile("MyApp.swift")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(again), "@UIApplicationDelegateAdaptor") != 1 {
+		t.Error("expected addDelegateAdaptorToSwiftUIApp to be idempotent")
+	}
+}
+
+func TestIOSPlatformRemoveUIApplicationDelegateAdaptorFromSwiftUIApp(t *testing.T) {
+	platform := &IOSPlatform{}
+
+	tmpDir, err := os.MkdirTemp("", "ios_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldWd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldWd) }()
+	_ = os.Chdir(tmpDir)
+
+	appSwift := `import SwiftUI
+
+@main
+struct MyApp: App {
+    @UIApplicationDelegateAdaptor(AppDelegate.self) var delegate
+
+    var body: some Scene {
+        WindowGroup {
+            ContentView()
+        }
+    }
+}
+`
+	if err := os.WriteFile("MyApp.swift", []byte(appSwift), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := platform.removeUIApplicationDelegateAdaptorFromSwiftUIApp(); err != nil {
+		t.Fatalf("removeUIApplicationDelegateAdaptorFromSwiftUIApp() error = %v", err)
+	}
+
+	content, err := os.ReadFile("MyApp.swift")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "UIApplicationDelegateAdaptor") {
+		t.Errorf("expected delegate adaptor to be removed, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "struct MyApp: App {") {
+		t.Errorf("expected the rest of the App struct to be preserved, got:\n%s", content)
+	}
+
+	// Re-running should be a no-op.
+	if err := platform.removeUIApplicationDelegateAdaptorFromSwiftUIApp(); err != nil {
+		t.Fatalf("second removeUIApplicationDelegateAdaptorFromSwiftUIApp() error = %v", err)
+	}
+}
+
+func TestIOSPlatformRemoveInitializationCode(t *testing.T) {
+	platform := &IOSPlatform{}
+
+	tmpDir, err := os.MkdirTemp("", "ios_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldWd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldWd) }()
+	_ = os.Chdir(tmpDir)
+
+	appDelegate := `import UIKit
+import FirebaseCore
+
+@main
+class AppDelegate: UIResponder, UIApplicationDelegate {
+
+    func application(_ application: UIApplication,
+                     didFinishLaunchingWithOptions launchOptions: [UIApplication.LaunchOptionsKey: Any]?) -> Bool {
+        return true
+    }
+}
+`
+	if err := os.WriteFile("AppDelegate.swift", []byte(appDelegate), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := platform.addFirebaseInitialization("AppDelegate.swift"); err != nil {
+		t.Fatalf("addFirebaseInitialization() error = %v", err)
+	}
+
+	content, err := os.ReadFile("AppDelegate.swift")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "FirebaseApp.configure()") {
+		t.Fatalf("expected FirebaseApp.configure() to be added, got:\n%s", content)
+	}
+
+	if err := platform.RemoveInitializationCode(&firebase.Config{}); err != nil {
+		t.Fatalf("RemoveInitializationCode() error = %v", err)
+	}
+
+	content, err = os.ReadFile("AppDelegate.swift")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "FirebaseApp.configure()") {
+		t.Errorf("expected FirebaseApp.configure() to be removed, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "class AppDelegate: UIResponder, UIApplicationDelegate {") {
+		t.Errorf("expected the rest of AppDelegate to be preserved, got:\n%s", content)
+	}
+
+	// Removing twice is a no-op, not an error.
+	if err := platform.RemoveInitializationCode(&firebase.Config{}); err != nil {
+		t.Fatalf("second RemoveInitializationCode() error = %v", err)
+	}
+}
+
+func TestIOSPlatformFindProjectName(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    string
+	}{
+		{fixture: "empty-project", want: ""},
+		{fixture: "flutter-ios", want: "Runner"},
+		{fixture: "react-native-ios", want: "RNDemo"},
+		{fixture: "xcodegen-ios", want: "App"},
+		{fixture: "multi-target-ios", want: "App"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			LoadFixture(t, tt.fixture)
+
+			platform := &IOSPlatform{}
+			if got := platform.findProjectName(); got != tt.want {
+				t.Errorf("findProjectName() for fixture %q = %q, want %q", tt.fixture, got, tt.want)
+			}
+		})
 	}
 }
 
@@ -225,36 +932,26 @@ func TestIOSPlatformIsSwiftProject(t *testing.T) {
 }
 
 func TestIOSPlatformDetermineAppDelegatePath(t *testing.T) {
-	platform := &IOSPlatform{}
-
-	// Create temp directory for testing
-	tmpDir, err := os.MkdirTemp("", "ios_test")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer os.RemoveAll(tmpDir)
-
-	// Change to temp directory
-	oldWd, _ := os.Getwd()
-	defer func() { _ = os.Chdir(oldWd) }()
-	_ = os.Chdir(tmpDir)
-
-	// Test default path
-	if path := platform.determineAppDelegatePath(); path != "." {
-		t.Errorf("Expected '.', got '%s'", path)
-	}
-
-	// Test with ios directory
-	_ = os.Mkdir("ios", 0755)
-	if path := platform.determineAppDelegatePath(); path != "ios" {
-		t.Errorf("Expected 'ios', got '%s'", path)
-	}
-
-	// Test with project-specific directory
-	_ = os.Mkdir("TestProject.xcodeproj", 0755)
-	_ = os.Mkdir("ios/TestProject", 0755)
-	if path := platform.determineAppDelegatePath(); path != "ios/TestProject" {
-		t.Errorf("Expected 'ios/TestProject', got '%s'", path)
+	tests := []struct {
+		fixture string
+		want    string
+	}{
+		{fixture: "empty-project", want: "."},
+		{fixture: "flutter-ios", want: filepath.Join("ios", "Runner")},
+		{fixture: "react-native-ios", want: filepath.Join("ios", "RNDemo")},
+		{fixture: "xcodegen-ios", want: "Sources"},
+		{fixture: "multi-target-ios", want: "App"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			LoadFixture(t, tt.fixture)
+
+			platform := &IOSPlatform{}
+			if got := platform.determineAppDelegatePath(); got != tt.want {
+				t.Errorf("determineAppDelegatePath() for fixture %q = %q, want %q", tt.fixture, got, tt.want)
+			}
+		})
 	}
 }
 
@@ -0,0 +1,311 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/clix-so/nativefire/internal/firebase"
+	"github.com/clix-so/nativefire/internal/ui"
+	"github.com/clix-so/nativefire/internal/xcodeproj"
+)
+
+// Constants for repeated strings
+const (
+	kmpIOSAppDir = "iosApp"
+	kmpSharedDir = "shared"
+)
+
+// KMPPlatform configures the iOS target of a Kotlin Multiplatform project: a
+// `shared/` Kotlin module built for iOS via the Kotlin Gradle plugin's
+// `ios()`/`iosMain` source set, paired with an `iosApp/` Xcode project that
+// hosts it. It's kept separate from IOSPlatform because the config file,
+// initialization bridge, and package manager entries all live in different
+// places than a plain Xcode project.
+type KMPPlatform struct{ base }
+
+func (p *KMPPlatform) Name() string {
+	return "Kotlin Multiplatform (iOS)"
+}
+
+func (p *KMPPlatform) Type() Type {
+	return KMP
+}
+
+func (p *KMPPlatform) Detect() bool {
+	if !fileExists(p.path(kmpIOSAppDir)) || !fileExists(p.path(kmpSharedDir)) {
+		return false
+	}
+	return p.hasKotlinIOSTarget()
+}
+
+// hasKotlinIOSTarget scans the shared module's Gradle Kotlin DSL build
+// script for a `kotlin { ios() }` target or an `iosMain`/`iosArm64`/`iosX64`
+// source set, any of which marks it as building for iOS.
+func (p *KMPPlatform) hasKotlinIOSTarget() bool {
+	gradleFile := findFile(p.path(kmpSharedDir), "*.gradle.kts")
+	if gradleFile == "" {
+		return false
+	}
+
+	content, err := os.ReadFile(gradleFile)
+	if err != nil {
+		return false
+	}
+
+	contentStr := string(content)
+	for _, marker := range []string{"ios()", "iosMain", "iosArm64", "iosX64", "iosSimulatorArm64"} {
+		if strings.Contains(contentStr, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *KMPPlatform) ConfigFileName() string {
+	return googleServiceInfoPlist
+}
+
+// ConfigPath places GoogleService-Info.plist under iosApp/iosApp/, the
+// location the Kotlin Multiplatform wizard's Xcode template expects it:
+// alongside Info.plist, inside the iosApp target's own source directory.
+func (p *KMPPlatform) ConfigPath() string {
+	return p.path(filepath.Join(kmpIOSAppDir, kmpIOSAppDir))
+}
+
+// PackagePaths reports that KMP's iOS target is distributed through the App
+// Store like any other iOS app, not OS packages, so there is no
+// packaged-filesystem path for the config file.
+func (p *KMPPlatform) PackagePaths(appName string) string {
+	return ""
+}
+
+func (p *KMPPlatform) InstallConfig(config *firebase.Config) error {
+	configPath := p.ConfigPath()
+	targetPath := filepath.Join(configPath, p.ConfigFileName())
+
+	if err := os.MkdirAll(configPath, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", configPath, err)
+	}
+
+	sourceData, err := os.ReadFile(config.SourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to read source config file: %w", err)
+	}
+
+	if err := os.WriteFile(targetPath, sourceData, 0644); err != nil {
+		return fmt.Errorf("failed to write config file to %s: %w", targetPath, err)
+	}
+
+	os.Remove(config.SourcePath)
+
+	ui.SuccessMsg(fmt.Sprintf("Configuration file installed at: %s", targetPath))
+
+	if err := p.wireIOSAppXcodeproj(config); err != nil {
+		ui.WarningMsg(fmt.Sprintf("Failed to register %s in the iosApp Xcode project: %v", p.ConfigFileName(), err))
+		ui.InfoMsg("Please add it to iosApp's 'Copy Bundle Resources' build phase manually")
+	}
+
+	return nil
+}
+
+// wireIOSAppXcodeproj registers GoogleService-Info.plist in iosApp's
+// project.pbxproj, mirroring IOSPlatform.wireXcodeproj but scoped to the
+// iosApp/ subtree rather than the repo root.
+func (p *KMPPlatform) wireIOSAppXcodeproj(config *firebase.Config) error {
+	xcodeprojDir := findFile(p.path(kmpIOSAppDir), "*.xcodeproj")
+	if xcodeprojDir == "" {
+		return nil
+	}
+
+	pbxprojPath := filepath.Join(xcodeprojDir, "project.pbxproj")
+	if !fileExists(pbxprojPath) {
+		return nil
+	}
+
+	project, err := xcodeproj.Open(pbxprojPath)
+	if err != nil {
+		return err
+	}
+
+	added, err := project.AddResourceFile(p.ConfigFileName())
+	if err != nil {
+		return fmt.Errorf("failed to wire %s into %s: %w", p.ConfigFileName(), pbxprojPath, err)
+	}
+	if !added {
+		return nil
+	}
+
+	if err := project.Save(config.DryRun); err != nil {
+		return err
+	}
+
+	if config.DryRun {
+		ui.InfoMsg(fmt.Sprintf("💡 Dry run: %s would be added to %s", p.ConfigFileName(), pbxprojPath))
+	} else {
+		ui.SuccessMsg(fmt.Sprintf("Wired %s into: %s", p.ConfigFileName(), pbxprojPath))
+	}
+	return nil
+}
+
+// kmpFirebaseBridgeKotlin is the iosMain `actual` implementation of a
+// configureFirebase() expect/actual pair, so common Kotlin code can trigger
+// Firebase setup without every iOS entry point needing its own
+// FirebaseApp.configure() call. It assumes Firebase was added via CocoaPods'
+// cinterop bindings (the `cocoapods.FirebaseCore` import); projects wired
+// through Swift Package Manager instead will need to adjust the import to
+// whatever cinterop def their SPM integration generates.
+const kmpFirebaseBridgeKotlin = `package com.nativefire.shared
+
+import cocoapods.FirebaseCore.FIRApp
+
+actual fun configureFirebase() {
+    FIRApp.configure()
+}
+`
+
+const kmpFirebaseExpectKotlin = `package com.nativefire.shared
+
+expect fun configureFirebase()
+`
+
+// AddInitializationCode generates the commonMain expect declaration and
+// iosMain actual bridge for configureFirebase(), then registers the
+// Firebase iOS SDK against iosApp's Xcode project (via Podfile if present,
+// otherwise Swift Package Manager) — iosApp is what actually links and runs
+// the Firebase framework, not the shared Kotlin module.
+func (p *KMPPlatform) AddInitializationCode(config *firebase.Config) error {
+	if err := p.addFirebaseBridge(); err != nil {
+		return err
+	}
+
+	if podfilePath := p.findIOSAppPodfile(); podfilePath != "" {
+		return p.addFirebasePodsToIOSApp(podfilePath)
+	}
+
+	return p.addFirebaseSwiftPackageToIOSApp(config)
+}
+
+// RemoveInitializationCode is not yet implemented for KMP projects:
+// AddInitializationCode writes the commonMain/iosMain Kotlin bridge files and
+// edits the iosApp Podfile/Package.swift, none of which are bounded by
+// idempotent markers yet. This is a no-op rather than an error so
+// uninstalling a multi-platform project doesn't fail just because its KMP
+// target can't be automatically reversed.
+func (p *KMPPlatform) RemoveInitializationCode(config *firebase.Config) error {
+	return nil
+}
+
+// addFirebaseBridge writes the commonMain expect declaration and iosMain
+// actual implementation of configureFirebase(). It's idempotent: an
+// existing bridge file is left untouched rather than overwritten.
+func (p *KMPPlatform) addFirebaseBridge() error {
+	iosMainDir := p.path(filepath.Join(kmpSharedDir, "src", "iosMain", "kotlin", "com", "nativefire", "shared"))
+	bridgePath := filepath.Join(iosMainDir, "Firebase.ios.kt")
+
+	if fileExists(bridgePath) {
+		ui.InfoMsg("Firebase iOS bridge already present in shared/src/iosMain")
+		return nil
+	}
+
+	if err := os.MkdirAll(iosMainDir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", iosMainDir, err)
+	}
+	if err := os.WriteFile(bridgePath, []byte(kmpFirebaseBridgeKotlin), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", bridgePath, err)
+	}
+
+	commonMainDir := p.path(filepath.Join(kmpSharedDir, "src", "commonMain", "kotlin", "com", "nativefire", "shared"))
+	expectPath := filepath.Join(commonMainDir, "Firebase.kt")
+	if !fileExists(expectPath) {
+		if err := os.MkdirAll(commonMainDir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", commonMainDir, err)
+		}
+		if err := os.WriteFile(expectPath, []byte(kmpFirebaseExpectKotlin), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", expectPath, err)
+		}
+	}
+
+	ui.SuccessMsg(fmt.Sprintf("Added Firebase iOS bridge at: %s", bridgePath))
+	return nil
+}
+
+func (p *KMPPlatform) findIOSAppPodfile() string {
+	podfilePath := p.path(filepath.Join(kmpIOSAppDir, "Podfile"))
+	if fileExists(podfilePath) {
+		return podfilePath
+	}
+	return ""
+}
+
+// addFirebasePodsToIOSApp mirrors IOSPlatform.addFirebasePods, scoped to
+// iosApp's Podfile rather than the repo root's.
+func (p *KMPPlatform) addFirebasePodsToIOSApp(podfilePath string) error {
+	content, err := os.ReadFile(podfilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read Podfile: %w", err)
+	}
+
+	contentStr := string(content)
+	if strings.Contains(contentStr, "Firebase/Core") {
+		return nil
+	}
+
+	lines := strings.Split(contentStr, "\n")
+	var newLines []string
+	for _, line := range lines {
+		newLines = append(newLines, line)
+		if strings.Contains(line, "target") && strings.Contains(line, "do") {
+			newLines = append(newLines, "  pod 'Firebase/Core'")
+			newLines = append(newLines, "  pod 'Firebase/Analytics'")
+		}
+	}
+
+	if err := os.WriteFile(podfilePath, []byte(strings.Join(newLines, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to update Podfile: %w", err)
+	}
+
+	ui.SuccessMsg(fmt.Sprintf("Added Firebase pods to: %s", podfilePath))
+	return nil
+}
+
+// addFirebaseSwiftPackageToIOSApp registers the Firebase iOS SDK as a Swift
+// Package Manager dependency on iosApp's Xcode project when it has no
+// Podfile, mirroring IOSPlatform.addSwiftPackageDependency.
+func (p *KMPPlatform) addFirebaseSwiftPackageToIOSApp(config *firebase.Config) error {
+	xcodeprojDir := findFile(p.path(kmpIOSAppDir), "*.xcodeproj")
+	if xcodeprojDir == "" {
+		ui.WarningMsg("Could not find iosApp's Xcode project; please add the Firebase iOS SDK manually")
+		return nil
+	}
+
+	pbxprojPath := filepath.Join(xcodeprojDir, "project.pbxproj")
+	if !fileExists(pbxprojPath) {
+		return nil
+	}
+
+	project, err := xcodeproj.Open(pbxprojPath)
+	if err != nil {
+		return err
+	}
+
+	added, err := project.AddSwiftPackageDependency(firebaseSwiftPackageURL, firebaseSwiftPackageVersion, "FirebaseCore")
+	if err != nil {
+		return fmt.Errorf("failed to add Firebase Swift package to %s: %w", pbxprojPath, err)
+	}
+	if !added {
+		return nil
+	}
+
+	if err := project.Save(config.DryRun); err != nil {
+		return err
+	}
+
+	if config.DryRun {
+		ui.InfoMsg(fmt.Sprintf("💡 Dry run: Firebase Swift package would be added to %s", pbxprojPath))
+	} else {
+		ui.SuccessMsg(fmt.Sprintf("Added Firebase Swift package dependency to: %s", pbxprojPath))
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKMPPlatformBasics(t *testing.T) {
+	platform := &KMPPlatform{}
+
+	if platform.Name() != "Kotlin Multiplatform (iOS)" {
+		t.Errorf("Expected Name() to return 'Kotlin Multiplatform (iOS)', got '%s'", platform.Name())
+	}
+
+	if platform.Type() != KMP {
+		t.Errorf("Expected Type() to return KMP, got %v", platform.Type())
+	}
+
+	if platform.ConfigFileName() != googleServiceInfoPlist {
+		t.Errorf("Expected ConfigFileName() to return '%s', got '%s'", googleServiceInfoPlist, platform.ConfigFileName())
+	}
+}
+
+func writeKMPFixture(t *testing.T, dir, gradleBody string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, kmpIOSAppDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	sharedDir := filepath.Join(dir, kmpSharedDir)
+	if err := os.MkdirAll(sharedDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sharedDir, "build.gradle.kts"), []byte(gradleBody), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKMPPlatformDetect(t *testing.T) {
+	platform := &KMPPlatform{}
+
+	tmpDir, err := os.MkdirTemp("", "kmp_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldWd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldWd) }()
+	_ = os.Chdir(tmpDir)
+
+	if platform.Detect() {
+		t.Error("Expected Detect() to return false with no KMP indicators")
+	}
+
+	writeKMPFixture(t, tmpDir, `
+kotlin {
+    ios()
+    sourceSets {
+        val iosMain by getting
+    }
+}
+`)
+
+	if !platform.Detect() {
+		t.Error("Expected Detect() to return true with iosApp/shared and a kotlin { ios() } target")
+	}
+}
+
+func TestKMPPlatformDetectIgnoresNonIOSSharedModule(t *testing.T) {
+	platform := &KMPPlatform{}
+
+	tmpDir, err := os.MkdirTemp("", "kmp_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldWd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldWd) }()
+	_ = os.Chdir(tmpDir)
+
+	writeKMPFixture(t, tmpDir, `
+kotlin {
+    jvm()
+    androidTarget()
+}
+`)
+
+	if platform.Detect() {
+		t.Error("Expected Detect() to return false for a shared module with no iOS target")
+	}
+}
+
+func TestKMPPlatformConfigPath(t *testing.T) {
+	platform := &KMPPlatform{}
+
+	tmpDir, err := os.MkdirTemp("", "kmp_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldWd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldWd) }()
+	_ = os.Chdir(tmpDir)
+
+	expected := filepath.Join(kmpIOSAppDir, kmpIOSAppDir)
+	if platform.ConfigPath() != expected {
+		t.Errorf("Expected ConfigPath() to return '%s', got '%s'", expected, platform.ConfigPath())
+	}
+}
+
+func TestKMPPlatformAddInitializationCodeWritesBridge(t *testing.T) {
+	platform := &KMPPlatform{}
+
+	tmpDir, err := os.MkdirTemp("", "kmp_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldWd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldWd) }()
+	_ = os.Chdir(tmpDir)
+
+	writeKMPFixture(t, tmpDir, `kotlin { ios() }`)
+
+	if err := platform.addFirebaseBridge(); err != nil {
+		t.Fatalf("addFirebaseBridge() error = %v", err)
+	}
+
+	bridgePath := filepath.Join(kmpSharedDir, "src", "iosMain", "kotlin", "com", "nativefire", "shared", "Firebase.ios.kt")
+	if !fileExists(bridgePath) {
+		t.Fatal("expected iosMain Firebase bridge to be written")
+	}
+
+	expectPath := filepath.Join(kmpSharedDir, "src", "commonMain", "kotlin", "com", "nativefire", "shared", "Firebase.kt")
+	if !fileExists(expectPath) {
+		t.Fatal("expected commonMain expect declaration to be written")
+	}
+
+	// Re-running should be a no-op rather than overwriting the bridge.
+	if err := platform.addFirebaseBridge(); err != nil {
+		t.Fatalf("second addFirebaseBridge() error = %v", err)
+	}
+}
@@ -17,6 +17,26 @@ const (
 	MacOS
 	Windows
 	Linux
+	// IOSSimulator and MacCatalyst are build-variant siblings of iOS, not
+	// standalone platforms: they target the same Xcode project and source
+	// tree as iOS, just a different run destination (the Simulator, or
+	// running the iOS target as a Mac app via Catalyst). They exist as
+	// distinct Types because Firebase app identity and Xcode scheme
+	// selection can differ per destination. See IOSSimulatorPlatform and
+	// MacCatalystPlatform.
+	IOSSimulator
+	MacCatalyst
+	// Plugin identifies every platform contributed by an external plugin
+	// manifest (Unity, Unreal, Godot, Qt, custom embedded toolchains, ...).
+	// Unlike the built-in types above, Plugin doesn't distinguish between
+	// engines itself — Name() does that — since the set of plugin platforms
+	// isn't known at compile time. See the plugin package.
+	Plugin
+	// KMP identifies a Kotlin Multiplatform project's iOS target: a
+	// `shared/` Kotlin module built for iOS, paired with an `iosApp/` Xcode
+	// project that hosts it. It's a distinct project layout from iOS, not a
+	// build-variant sibling like IOSSimulator/MacCatalyst. See KMPPlatform.
+	KMP
 )
 
 // Platform name constants
@@ -30,19 +50,81 @@ type Platform interface {
 	Detect() bool
 	InstallConfig(config *firebase.Config) error
 	AddInitializationCode(config *firebase.Config) error
+	// RemoveInitializationCode reverses AddInitializationCode, deleting
+	// whatever nativefire-managed initialization code it previously added
+	// so a project can be safely switched to a different Firebase project.
+	// Implementations that added their code inside explicit markers can
+	// remove it precisely; others may only be able to undo part of what
+	// they added.
+	RemoveInitializationCode(config *firebase.Config) error
 	ConfigFileName() string
 	ConfigPath() string
+	// PackagePaths reports where this platform's config file belongs inside
+	// a packaged filesystem (e.g. for `nativefire package`), rooted under
+	// appName. It returns "" for platforms that aren't distributed as OS
+	// packages (Android, iOS).
+	PackagePaths(appName string) string
+	// SetRoot points Detect and ConfigPath at root instead of the current
+	// directory, so a cross-platform monorepo can be configured from its
+	// top-level directory without cd'ing into each platform's subtree.
+	SetRoot(root string)
 }
 
-type AndroidPlatform struct{}
-type IOSPlatform struct{}
-type MacOSPlatform struct{}
-type WindowsPlatform struct{}
-type LinuxPlatform struct{}
+// base holds the root directory a platform was detected under. It's
+// embedded by every platform type so Detect/ConfigPath can be pointed at a
+// subtree via SetRoot instead of always assuming the current directory.
+type base struct {
+	root string
+}
+
+func (b *base) SetRoot(root string) {
+	b.root = root
+}
+
+// rootDir returns the directory Detect should walk, defaulting to "." when
+// no root has been set.
+func (b base) rootDir() string {
+	if b.root == "" {
+		return "."
+	}
+	return b.root
+}
+
+// path joins rel onto the platform's root, leaving rel untouched when no
+// root has been set so existing cwd-relative behavior is unchanged.
+func (b base) path(rel string) string {
+	if b.root == "" || b.root == "." {
+		return rel
+	}
+	return filepath.Join(b.root, rel)
+}
+
+type AndroidPlatform struct{ base }
+type IOSPlatform struct{ base }
+
+// desktopBase implements the config-install behavior shared by all desktop
+// platforms (macOS, Windows, Linux): copy the downloaded config into place,
+// then run whatever platform-specific wiring the embedding type registers
+// via PostInstallHooks.
+type desktopBase struct{}
+
+type MacOSPlatform struct {
+	base
+	desktopBase
+}
+type WindowsPlatform struct {
+	base
+	desktopBase
+}
+type LinuxPlatform struct {
+	base
+	desktopBase
+}
 
 func DetectPlatform() (Platform, error) {
 	platforms := []Platform{
 		&AndroidPlatform{},
+		&KMPPlatform{},
 		&IOSPlatform{},
 		&MacOSPlatform{},
 		&WindowsPlatform{},
@@ -58,14 +140,50 @@ func DetectPlatform() (Platform, error) {
 	return nil, fmt.Errorf("no supported platform detected in current directory")
 }
 
+// DetectPlatforms returns every platform whose Detect() fires under root
+// ("." for the current directory), so cross-platform monorepos (Flutter,
+// React Native, Capacitor, Kotlin Multiplatform) that ship android/, ios/,
+// macos/, windows/, and linux/ subdirectories side by side can all be
+// configured in a single `nativefire configure` run.
+func DetectPlatforms(root string) ([]Platform, error) {
+	platforms := []Platform{
+		&AndroidPlatform{},
+		&KMPPlatform{},
+		&IOSPlatform{},
+		&MacOSPlatform{},
+		&WindowsPlatform{},
+		&LinuxPlatform{},
+	}
+
+	var detected []Platform
+	for _, p := range platforms {
+		p.SetRoot(root)
+		if p.Detect() {
+			detected = append(detected, p)
+		}
+	}
+
+	if len(detected) == 0 {
+		return nil, fmt.Errorf("no supported platform detected in current directory")
+	}
+
+	return detected, nil
+}
+
 func FromString(platformStr string) (Platform, error) {
 	switch strings.ToLower(platformStr) {
 	case "android":
 		return &AndroidPlatform{}, nil
 	case iosString:
 		return &IOSPlatform{}, nil
+	case "kmp":
+		return &KMPPlatform{}, nil
+	case "iossimulator":
+		return &IOSSimulatorPlatform{}, nil
 	case "macos":
 		return &MacOSPlatform{}, nil
+	case "maccatalyst":
+		return &MacCatalystPlatform{}, nil
 	case "windows":
 		return &WindowsPlatform{}, nil
 	case "linux":
@@ -221,6 +221,80 @@ func TestIOSPlatform(t *testing.T) {
 	})
 }
 
+func TestIOSSimulatorPlatform(t *testing.T) {
+	platform := &IOSSimulatorPlatform{}
+
+	t.Run("Name", func(t *testing.T) {
+		if platform.Name() != "iOS Simulator" {
+			t.Errorf("Expected name 'iOS Simulator', got '%s'", platform.Name())
+		}
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		if platform.Type() != IOSSimulator {
+			t.Errorf("Expected type %d, got %d", IOSSimulator, platform.Type())
+		}
+	})
+
+	t.Run("ConfigFileName", func(t *testing.T) {
+		if platform.ConfigFileName() != "GoogleService-Info.plist" {
+			t.Errorf("Expected config file name 'GoogleService-Info.plist', got '%s'", platform.ConfigFileName())
+		}
+	})
+
+	t.Run("Destination", func(t *testing.T) {
+		if platform.Destination() != "generic/platform=iOS Simulator" {
+			t.Errorf("Expected destination 'generic/platform=iOS Simulator', got '%s'", platform.Destination())
+		}
+	})
+}
+
+func TestMacCatalystPlatform(t *testing.T) {
+	platform := &MacCatalystPlatform{}
+
+	t.Run("Name", func(t *testing.T) {
+		if platform.Name() != "Mac Catalyst" {
+			t.Errorf("Expected name 'Mac Catalyst', got '%s'", platform.Name())
+		}
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		if platform.Type() != MacCatalyst {
+			t.Errorf("Expected type %d, got %d", MacCatalyst, platform.Type())
+		}
+	})
+
+	t.Run("Destination", func(t *testing.T) {
+		if platform.Destination() != "platform=macOS,variant=Mac Catalyst" {
+			t.Errorf("Expected destination 'platform=macOS,variant=Mac Catalyst', got '%s'", platform.Destination())
+		}
+	})
+}
+
+func TestFromStringAppleVariants(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Type
+	}{
+		{"iossimulator", IOSSimulator},
+		{"maccatalyst", MacCatalyst},
+		{"iosSimulator", IOSSimulator},
+		{"MacCatalyst", MacCatalyst},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			platform, err := FromString(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error for input %s: %v", tt.input, err)
+			}
+			if platform.Type() != tt.expected {
+				t.Errorf("Expected platform %d for input %s, got %d", tt.expected, tt.input, platform.Type())
+			}
+		})
+	}
+}
+
 func TestMacOSPlatform(t *testing.T) {
 	platform := &MacOSPlatform{}
 
@@ -269,6 +343,68 @@ func TestLinuxPlatform(t *testing.T) {
 	})
 }
 
+func TestDetectPlatforms(t *testing.T) {
+	tempDir := setupTestEnvironment(t,
+		[]string{"android", "ios"},
+		[]string{"android/build.gradle", "ios/Podfile"})
+	defer os.RemoveAll(tempDir)
+
+	oldWd, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	platforms, err := DetectPlatforms(".")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	found := make(map[Type]bool)
+	for _, p := range platforms {
+		found[p.Type()] = true
+	}
+	if !found[Android] {
+		t.Error("Expected Android to be detected alongside iOS")
+	}
+	if !found[iOS] {
+		t.Error("Expected iOS to be detected alongside Android")
+	}
+}
+
+func TestDetectPlatformsWithExplicitRoot(t *testing.T) {
+	tempDir := setupTestEnvironment(t, []string{"monorepo/android"}, []string{"monorepo/android/build.gradle"})
+	defer os.RemoveAll(tempDir)
+
+	oldWd, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	platforms, err := DetectPlatforms("monorepo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(platforms) != 1 || platforms[0].Type() != Android {
+		t.Fatalf("Expected only Android detected under monorepo/, got %+v", platforms)
+	}
+
+	expectedConfigPath := filepath.Join("monorepo", "app")
+	if platforms[0].ConfigPath() != expectedConfigPath {
+		t.Errorf("Expected ConfigPath %q, got %q", expectedConfigPath, platforms[0].ConfigPath())
+	}
+}
+
+func TestDetectPlatformsNoneFound(t *testing.T) {
+	tempDir := setupTestEnvironment(t, nil, []string{"random.txt"})
+	defer os.RemoveAll(tempDir)
+
+	oldWd, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(oldWd) }()
+
+	if _, err := DetectPlatforms("."); err == nil {
+		t.Error("Expected an error when no platform is detected")
+	}
+}
+
 func setupTestEnvironment(t *testing.T, dirs []string, files []string) string {
 	tempDir, err := os.MkdirTemp("", "nativefire_test")
 	if err != nil {
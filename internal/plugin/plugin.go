@@ -0,0 +1,350 @@
+// Package plugin implements nativefire's external platform-provider plugin
+// system, analogous to Helm's plugin model. A plugin manifest declares how
+// to detect a platform nativefire doesn't ship built-in support for (Unity,
+// Unreal, Godot, Qt, custom embedded toolchains, ...) and which commands
+// perform the InstallConfig/AddInitializationCode steps. Discover loads
+// every installed plugin as a platform.Platform that shells out to those
+// commands.
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/clix-so/nativefire/internal/firebase"
+	"github.com/clix-so/nativefire/internal/hooks"
+	"github.com/clix-so/nativefire/internal/platform"
+	"github.com/clix-so/nativefire/internal/ui"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFileName is the file every plugin directory must contain.
+const ManifestFileName = "plugin.yaml"
+
+// PluginsEnvVar names additional, colon-separated plugin search directories
+// (like $PATH), searched alongside the default directory.
+const PluginsEnvVar = "NATIVEFIRE_PLUGINS"
+
+// Manifest declares an external platform provider: what to detect, where
+// its Firebase config file belongs, and the per-OS/arch commands that
+// perform the two configure steps, resolved the same way .nativefire.yaml
+// hooks are (see hooks.PrepareCommands).
+type Manifest struct {
+	Name               string          `yaml:"name"`
+	DetectGlobs        []string        `yaml:"detect_globs"`
+	ConfigFileName     string          `yaml:"config_file_name"`
+	ConfigPathTemplate string          `yaml:"config_path_template"`
+	InstallConfig      []hooks.Command `yaml:"install_config"`
+	AddInitCode        []hooks.Command `yaml:"add_init_code"`
+	RemoveInitCode     []hooks.Command `yaml:"remove_init_code"`
+}
+
+// Platform adapts a Manifest to platform.Platform by shelling out to the
+// manifest's declared commands with the documented plugin env contract.
+type Platform struct {
+	manifest Manifest
+	root     string
+}
+
+var _ platform.Platform = (*Platform)(nil)
+
+func (p *Platform) Name() string { return p.manifest.Name }
+
+func (p *Platform) Type() platform.Type { return platform.Plugin }
+
+func (p *Platform) Detect() bool {
+	for _, glob := range p.manifest.DetectGlobs {
+		matches, err := filepath.Glob(p.path(glob))
+		if err == nil && len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Platform) ConfigFileName() string {
+	return p.manifest.ConfigFileName
+}
+
+func (p *Platform) ConfigPath() string {
+	return p.path(p.manifest.ConfigPathTemplate)
+}
+
+// PackagePaths reports that plugin-provided platforms aren't distributed
+// through `nativefire package`; the manifest format has no field for it.
+func (p *Platform) PackagePaths(appName string) string {
+	return ""
+}
+
+func (p *Platform) SetRoot(root string) {
+	p.root = root
+}
+
+func (p *Platform) path(rel string) string {
+	if p.root == "" || p.root == "." || rel == "" {
+		return rel
+	}
+	return filepath.Join(p.root, rel)
+}
+
+func (p *Platform) InstallConfig(config *firebase.Config) error {
+	return p.run(p.manifest.InstallConfig, config)
+}
+
+func (p *Platform) AddInitializationCode(config *firebase.Config) error {
+	return p.run(p.manifest.AddInitCode, config)
+}
+
+// RemoveInitializationCode runs the manifest's declared remove_init_code
+// commands, if any. Plugins that don't declare a removal step leave their
+// AddInitializationCode changes in place, the same way nativefire's
+// KMP/desktop platforms do until they grow their own reverse.
+func (p *Platform) RemoveInitializationCode(config *firebase.Config) error {
+	if len(p.manifest.RemoveInitCode) == 0 {
+		return nil
+	}
+	return p.run(p.manifest.RemoveInitCode, config)
+}
+
+// run resolves candidates for the current OS/arch and executes it, passing
+// the documented NATIVEFIRE_* environment contract so a plugin command can
+// act on the app being configured without parsing CLI output.
+func (p *Platform) run(candidates []hooks.Command, config *firebase.Config) error {
+	command, err := hooks.PrepareCommands(candidates)
+	if err != nil {
+		return fmt.Errorf("plugin %s: %w", p.manifest.Name, err)
+	}
+	if len(command) == 0 {
+		return fmt.Errorf("plugin %s declares no command for %s/%s", p.manifest.Name, runtime.GOOS, runtime.GOARCH)
+	}
+
+	ui.InfoMsg(fmt.Sprintf("Running plugin %s: %s", p.manifest.Name, strings.Join(command, " ")))
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), p.env(config)...)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("plugin %s command failed: %w", p.manifest.Name, err)
+	}
+	return nil
+}
+
+// env builds the documented environment contract plugin commands can rely
+// on, analogous to Helm's HELM_* plugin environment variables.
+func (p *Platform) env(config *firebase.Config) []string {
+	return []string{
+		"NATIVEFIRE_PLATFORM_NAME=" + p.manifest.Name,
+		"NATIVEFIRE_PROJECT_ID=" + config.ProjectID,
+		"NATIVEFIRE_APP_ID=" + config.AppID,
+		"NATIVEFIRE_CONFIG_SRC=" + config.SourcePath,
+		"NATIVEFIRE_BUNDLE_ID=" + config.BundleID,
+		"NATIVEFIRE_PACKAGE_NAME=" + config.PackageName,
+	}
+}
+
+// Dir returns the default directory plugins are installed under.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".nativefire", "plugins"), nil
+}
+
+// searchDirs returns every directory Discover scans for installed plugins:
+// the default ~/.nativefire/plugins plus any directories in
+// $NATIVEFIRE_PLUGINS.
+func searchDirs() []string {
+	var dirs []string
+	if dir, err := Dir(); err == nil {
+		dirs = append(dirs, dir)
+	}
+	for _, dir := range strings.Split(os.Getenv(PluginsEnvVar), ":") {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// Discover loads every plugin manifest found under the search directories.
+// A directory without a manifest is not matched by the search at all. A
+// plugin whose manifest nativefire can't load is skipped with a warning
+// rather than failing the whole scan, so one broken plugin doesn't break
+// `nativefire configure` for everything else.
+func Discover() []*Platform {
+	var platforms []*Platform
+	for _, dir := range searchDirs() {
+		matches, err := filepath.Glob(filepath.Join(dir, "*", ManifestFileName))
+		if err != nil {
+			continue
+		}
+		for _, path := range matches {
+			manifest, err := loadManifest(path)
+			if err != nil {
+				ui.WarningMsg(fmt.Sprintf("Skipping plugin manifest %s: %v", path, err))
+				continue
+			}
+			platforms = append(platforms, &Platform{manifest: *manifest})
+		}
+	}
+	return platforms
+}
+
+func loadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if manifest.Name == "" {
+		return nil, fmt.Errorf("%s is missing a required 'name' field", path)
+	}
+	return &manifest, nil
+}
+
+// FromString resolves a platform name against nativefire's built-in
+// platforms first, falling back to installed plugins — so
+// `nativefire configure --platform unity` works once a "unity" plugin is
+// installed, without nativefire needing to know Unity exists.
+func FromString(name string) (platform.Platform, error) {
+	if p, err := platform.FromString(name); err == nil {
+		return p, nil
+	}
+	for _, p := range Discover() {
+		if strings.EqualFold(p.Name(), name) {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported platform: %s", name)
+}
+
+// DetectPlatforms merges platform.DetectPlatforms' built-in results with
+// every installed plugin whose Detect() fires under root, so a project
+// using Unity/Unreal/Godot alongside android/ios/macos gets all of them
+// configured in one `nativefire configure` run.
+func DetectPlatforms(root string) ([]platform.Platform, error) {
+	var detected []platform.Platform
+
+	if builtins, err := platform.DetectPlatforms(root); err == nil {
+		detected = append(detected, builtins...)
+	}
+
+	for _, p := range Discover() {
+		p.SetRoot(root)
+		if p.Detect() {
+			detected = append(detected, p)
+		}
+	}
+
+	if len(detected) == 0 {
+		return nil, fmt.Errorf("no supported platform detected in current directory")
+	}
+	return detected, nil
+}
+
+// Remove uninstalls the named plugin by deleting its directory under Dir().
+func Remove(name string) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	pluginDir := filepath.Join(dir, name)
+	if !fileExists(filepath.Join(pluginDir, ManifestFileName)) {
+		return fmt.Errorf("plugin %q is not installed", name)
+	}
+	return os.RemoveAll(pluginDir)
+}
+
+// Install copies a plugin from source (a local directory containing
+// plugin.yaml, or a git URL) into Dir(), named after the manifest's
+// declared name rather than the source path.
+func Install(source string) (*Manifest, error) {
+	if isGitURL(source) {
+		return installFromGit(source)
+	}
+	return installFromDir(source)
+}
+
+func isGitURL(source string) bool {
+	return strings.HasSuffix(source, ".git") ||
+		strings.HasPrefix(source, "git@") ||
+		strings.Contains(source, "://")
+}
+
+func installFromGit(url string) (*Manifest, error) {
+	tempDir, err := os.MkdirTemp("", "nativefire-plugin-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cmd := exec.Command("git", "clone", "--depth", "1", url, tempDir)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to clone %s: %w", url, err)
+	}
+
+	return installFromDir(tempDir)
+}
+
+func installFromDir(sourceDir string) (*Manifest, error) {
+	manifest, err := loadManifest(filepath.Join(sourceDir, ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	destDir := filepath.Join(dir, manifest.Name)
+
+	if err := os.MkdirAll(filepath.Dir(destDir), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create plugin directory: %w", err)
+	}
+	os.RemoveAll(destDir)
+	if err := copyDir(sourceDir, destDir); err != nil {
+		return nil, fmt.Errorf("failed to install plugin: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode())
+	})
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return !os.IsNotExist(err)
+}
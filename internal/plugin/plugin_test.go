@@ -0,0 +1,175 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/clix-so/nativefire/internal/firebase"
+)
+
+func writeTestPlugin(t *testing.T, dir, name string) {
+	t.Helper()
+	content := `
+name: ` + name + `
+detect_globs:
+  - "*.unityproj"
+config_file_name: google-services-unity.json
+config_path_template: Assets/StreamingAssets
+install_config:
+  - command: ["echo", "install"]
+add_init_code:
+  - command: ["echo", "init"]
+`
+	if err := os.WriteFile(filepath.Join(dir, ManifestFileName), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test manifest: %v", err)
+	}
+}
+
+func TestDiscoverFindsInstalledPlugins(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(PluginsEnvVar, "")
+
+	pluginDir := filepath.Join(home, ".nativefire", "plugins", "unity")
+	if err := os.MkdirAll(pluginDir, 0755); err != nil {
+		t.Fatalf("failed to create plugin dir: %v", err)
+	}
+	writeTestPlugin(t, pluginDir, "unity")
+
+	plugins := Discover()
+	if len(plugins) != 1 {
+		t.Fatalf("Expected 1 plugin, got %d", len(plugins))
+	}
+	if plugins[0].Name() != "unity" {
+		t.Errorf("Expected plugin named 'unity', got %q", plugins[0].Name())
+	}
+}
+
+func TestDiscoverSkipsInvalidManifest(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(PluginsEnvVar, "")
+
+	pluginDir := filepath.Join(home, ".nativefire", "plugins", "broken")
+	if err := os.MkdirAll(pluginDir, 0755); err != nil {
+		t.Fatalf("failed to create plugin dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(pluginDir, ManifestFileName), []byte("detect_globs: [foo]"), 0644); err != nil {
+		t.Fatalf("failed to write invalid manifest: %v", err)
+	}
+
+	if plugins := Discover(); len(plugins) != 0 {
+		t.Errorf("Expected invalid manifest to be skipped, got %d plugins", len(plugins))
+	}
+}
+
+func TestPlatformDetectAndConfigPath(t *testing.T) {
+	projectDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectDir, "game.unityproj"), []byte(""), 0644); err != nil {
+		t.Fatalf("failed to create detect fixture: %v", err)
+	}
+
+	p := &Platform{manifest: Manifest{
+		Name:               "unity",
+		DetectGlobs:        []string{"*.unityproj"},
+		ConfigFileName:     "google-services-unity.json",
+		ConfigPathTemplate: "Assets/StreamingAssets",
+	}}
+	p.SetRoot(projectDir)
+
+	if !p.Detect() {
+		t.Error("Expected Detect to find game.unityproj")
+	}
+	if p.ConfigFileName() != "google-services-unity.json" {
+		t.Errorf("Unexpected ConfigFileName: %s", p.ConfigFileName())
+	}
+	expectedPath := filepath.Join(projectDir, "Assets/StreamingAssets")
+	if p.ConfigPath() != expectedPath {
+		t.Errorf("Expected ConfigPath %s, got %s", expectedPath, p.ConfigPath())
+	}
+}
+
+func TestInstallAndRemove(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(PluginsEnvVar, "")
+
+	sourceDir := t.TempDir()
+	writeTestPlugin(t, sourceDir, "godot")
+
+	manifest, err := Install(sourceDir)
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	if manifest.Name != "godot" {
+		t.Errorf("Expected manifest name 'godot', got %q", manifest.Name)
+	}
+
+	if plugins := Discover(); len(plugins) != 1 {
+		t.Fatalf("Expected 1 discovered plugin after install, got %d", len(plugins))
+	}
+
+	if err := Remove("godot"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if plugins := Discover(); len(plugins) != 0 {
+		t.Errorf("Expected 0 plugins after removal, got %d", len(plugins))
+	}
+}
+
+func TestRemoveNotInstalled(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if err := Remove("does-not-exist"); err == nil {
+		t.Error("Expected error removing a plugin that was never installed")
+	}
+}
+
+func TestFromStringFallsBackToPlugin(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(PluginsEnvVar, "")
+
+	pluginDir := filepath.Join(home, ".nativefire", "plugins", "unreal")
+	if err := os.MkdirAll(pluginDir, 0755); err != nil {
+		t.Fatalf("failed to create plugin dir: %v", err)
+	}
+	writeTestPlugin(t, pluginDir, "unreal")
+
+	p, err := FromString("unreal")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p.Name() != "unreal" {
+		t.Errorf("Expected plugin platform 'unreal', got %q", p.Name())
+	}
+
+	if _, err := FromString("android"); err != nil {
+		t.Errorf("Expected built-in platform to still resolve, got error: %v", err)
+	}
+
+	if _, err := FromString("does-not-exist"); err == nil {
+		t.Error("Expected an error for an unknown platform/plugin name")
+	}
+}
+
+func TestPlatformEnvContract(t *testing.T) {
+	p := &Platform{manifest: Manifest{Name: "unity"}}
+	config := &firebase.Config{ProjectID: "proj", AppID: "app", SourcePath: "/tmp/config.json"}
+
+	env := p.env(config)
+	want := map[string]bool{
+		"NATIVEFIRE_PLATFORM_NAME=unity":         true,
+		"NATIVEFIRE_PROJECT_ID=proj":             true,
+		"NATIVEFIRE_APP_ID=app":                  true,
+		"NATIVEFIRE_CONFIG_SRC=/tmp/config.json": true,
+	}
+	for _, kv := range env {
+		delete(want, kv)
+	}
+	if len(want) != 0 {
+		t.Errorf("Missing expected env entries: %v", want)
+	}
+}
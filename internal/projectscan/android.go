@@ -0,0 +1,163 @@
+package projectscan
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// androidGradleFiles and androidManifestFiles mirror the candidate paths
+// the old ad-hoc detectors searched, plus the Kotlin DSL gradle file name.
+var (
+	androidGradleFiles = []string{
+		"app/build.gradle", "android/app/build.gradle", "build.gradle",
+		"app/build.gradle.kts", "android/app/build.gradle.kts", "build.gradle.kts",
+	}
+	androidManifestFiles = []string{
+		"app/src/main/AndroidManifest.xml",
+		"android/app/src/main/AndroidManifest.xml",
+		"src/main/AndroidManifest.xml",
+	}
+)
+
+var (
+	applicationIDRe = regexp.MustCompile(`applicationId\s*=?\s*["']([^"']+)["']`)
+	namespaceRe     = regexp.MustCompile(`namespace\s*=?\s*["']([^"']+)["']`)
+	minSdkRe        = regexp.MustCompile(`minSdk(?:Version)?\s*=?\s*["']?(\d+)["']?`)
+	blockEntryRe    = regexp.MustCompile(`(\w+)\s*\{([^{}]*)\}`)
+	suffixRe        = regexp.MustCompile(`applicationIdSuffix\s*=?\s*["']([^"']+)["']`)
+)
+
+// ScanAndroid parses the first build.gradle(.kts) and AndroidManifest.xml it
+// finds under dir (trying the same conventional module layouts the rest of
+// the codebase does) into a single AndroidProject. Either file may be
+// missing; an error is only returned if neither is found, or the manifest
+// that was found doesn't parse as XML. Flavors, build types and signing
+// configs are read only from `name { ... }` entries; Kotlin DSL entries
+// written as create("name") or getByName("name") are not picked up.
+func ScanAndroid(dir string) (*AndroidProject, error) {
+	gradle := readFirstExisting(dir, androidGradleFiles)
+	manifest := readFirstExisting(dir, androidManifestFiles)
+
+	if gradle == "" && manifest == "" {
+		return nil, fmt.Errorf("no build.gradle(.kts) or AndroidManifest.xml found under %s", dir)
+	}
+
+	project := &AndroidProject{}
+	if gradle != "" {
+		parseGradle(gradle, project)
+	}
+	if manifest != "" {
+		if err := parseManifest(manifest, project); err != nil {
+			return nil, fmt.Errorf("failed to parse AndroidManifest.xml: %w", err)
+		}
+	}
+
+	return project, nil
+}
+
+func parseGradle(content string, project *AndroidProject) {
+	if match := applicationIDRe.FindStringSubmatch(content); match != nil {
+		project.ApplicationID = match[1]
+	}
+	if match := namespaceRe.FindStringSubmatch(content); match != nil {
+		project.Namespace = match[1]
+	}
+	if match := minSdkRe.FindStringSubmatch(content); match != nil {
+		if sdk, err := strconv.Atoi(match[1]); err == nil {
+			project.MinSdkVersion = sdk
+		}
+	}
+
+	if block := extractBlock(content, "productFlavors"); block != "" {
+		for _, entry := range blockEntryRe.FindAllStringSubmatch(block, -1) {
+			name, body := entry[1], entry[2]
+			identifier := project.ApplicationID
+			if suffix := suffixRe.FindStringSubmatch(body); suffix != nil {
+				identifier += suffix[1]
+			}
+			project.Flavors = append(project.Flavors, Flavor{Name: name, ApplicationID: identifier})
+		}
+	}
+
+	if block := extractBlock(content, "buildTypes"); block != "" {
+		project.BuildTypes = extractEntryNames(block)
+	}
+
+	if block := extractBlock(content, "signingConfigs"); block != "" {
+		project.SigningConfigs = extractEntryNames(block)
+	}
+}
+
+func parseManifest(content string, project *AndroidProject) error {
+	var doc struct {
+		XMLName xml.Name `xml:"manifest"`
+		Package string   `xml:"package,attr"`
+		UsesSDK struct {
+			MinSdkVersion int `xml:"minSdkVersion,attr"`
+		} `xml:"uses-sdk"`
+	}
+	if err := xml.Unmarshal([]byte(content), &doc); err != nil {
+		return err
+	}
+
+	if project.ApplicationID == "" {
+		project.ApplicationID = doc.Package
+	}
+	if project.MinSdkVersion == 0 {
+		project.MinSdkVersion = doc.UsesSDK.MinSdkVersion
+	}
+	return nil
+}
+
+func extractEntryNames(block string) []string {
+	var names []string
+	for _, entry := range blockEntryRe.FindAllStringSubmatch(block, -1) {
+		names = append(names, entry[1])
+	}
+	return names
+}
+
+// extractBlock returns the contents between the first "{" after name and
+// its matching "}", tracking brace depth so a nested entry body doesn't
+// truncate the block early.
+func extractBlock(content, name string) string {
+	idx := strings.Index(content, name)
+	if idx == -1 {
+		return ""
+	}
+
+	start := strings.Index(content[idx:], "{")
+	if start == -1 {
+		return ""
+	}
+	start += idx
+
+	depth := 0
+	for i := start; i < len(content); i++ {
+		switch content[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return content[start+1 : i]
+			}
+		}
+	}
+	return ""
+}
+
+func readFirstExisting(dir string, candidates []string) string {
+	for _, candidate := range candidates {
+		content, err := os.ReadFile(filepath.Join(dir, candidate))
+		if err == nil {
+			return string(content)
+		}
+	}
+	return ""
+}
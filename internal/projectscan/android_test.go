@@ -0,0 +1,137 @@
+package projectscan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sampleBuildGradle = `
+android {
+    namespace "com.example.app"
+    defaultConfig {
+        applicationId "com.example.app"
+        minSdkVersion 24
+    }
+    productFlavors {
+        dev {
+            applicationIdSuffix ".dev"
+        }
+        prod {
+        }
+    }
+    buildTypes {
+        release {
+            minifyEnabled true
+        }
+        debug {
+        }
+    }
+    signingConfigs {
+        release {
+        }
+    }
+}
+`
+
+const sampleManifest = `<?xml version="1.0" encoding="utf-8"?>
+<manifest xmlns:android="http://schemas.android.com/apk/res/android"
+    package="com.example.manifestonly">
+    <uses-sdk android:minSdkVersion="21" />
+</manifest>
+`
+
+func writeAndroidProject(t *testing.T, gradle, manifest string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if gradle != "" {
+		if err := os.WriteFile(filepath.Join(dir, "build.gradle"), []byte(gradle), 0o644); err != nil {
+			t.Fatalf("failed to write build.gradle: %v", err)
+		}
+	}
+	if manifest != "" {
+		manifestDir := filepath.Join(dir, "src", "main")
+		if err := os.MkdirAll(manifestDir, 0o755); err != nil {
+			t.Fatalf("failed to create manifest dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(manifestDir, "AndroidManifest.xml"), []byte(manifest), 0o644); err != nil {
+			t.Fatalf("failed to write AndroidManifest.xml: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestScanAndroidParsesApplicationIDFlavorsAndBuildTypes(t *testing.T) {
+	dir := writeAndroidProject(t, sampleBuildGradle, "")
+
+	project, err := ScanAndroid(dir)
+	if err != nil {
+		t.Fatalf("ScanAndroid() error = %v", err)
+	}
+
+	if project.ApplicationID != "com.example.app" {
+		t.Errorf("ApplicationID = %q", project.ApplicationID)
+	}
+	if project.Namespace != "com.example.app" {
+		t.Errorf("Namespace = %q", project.Namespace)
+	}
+	if project.MinSdkVersion != 24 {
+		t.Errorf("MinSdkVersion = %d, want 24", project.MinSdkVersion)
+	}
+
+	if len(project.Flavors) != 2 {
+		t.Fatalf("Flavors = %v, want 2 entries", project.Flavors)
+	}
+	byName := map[string]Flavor{}
+	for _, f := range project.Flavors {
+		byName[f.Name] = f
+	}
+	if byName["dev"].ApplicationID != "com.example.app.dev" {
+		t.Errorf("dev flavor ApplicationID = %q, want suffix applied", byName["dev"].ApplicationID)
+	}
+	if byName["prod"].ApplicationID != "com.example.app" {
+		t.Errorf("prod flavor ApplicationID = %q, want base applicationId", byName["prod"].ApplicationID)
+	}
+
+	if len(project.BuildTypes) != 2 {
+		t.Errorf("BuildTypes = %v, want release and debug", project.BuildTypes)
+	}
+	if len(project.SigningConfigs) != 1 || project.SigningConfigs[0] != "release" {
+		t.Errorf("SigningConfigs = %v, want [release]", project.SigningConfigs)
+	}
+}
+
+func TestScanAndroidFallsBackToManifestPackage(t *testing.T) {
+	dir := writeAndroidProject(t, "", sampleManifest)
+
+	project, err := ScanAndroid(dir)
+	if err != nil {
+		t.Fatalf("ScanAndroid() error = %v", err)
+	}
+
+	if project.ApplicationID != "com.example.manifestonly" {
+		t.Errorf("ApplicationID = %q, want the manifest's package attribute", project.ApplicationID)
+	}
+	if project.MinSdkVersion != 21 {
+		t.Errorf("MinSdkVersion = %d, want 21 from uses-sdk", project.MinSdkVersion)
+	}
+}
+
+func TestScanAndroidPrefersGradleApplicationIDOverManifestPackage(t *testing.T) {
+	dir := writeAndroidProject(t, sampleBuildGradle, sampleManifest)
+
+	project, err := ScanAndroid(dir)
+	if err != nil {
+		t.Fatalf("ScanAndroid() error = %v", err)
+	}
+
+	if project.ApplicationID != "com.example.app" {
+		t.Errorf("ApplicationID = %q, want gradle's applicationId to take precedence", project.ApplicationID)
+	}
+}
+
+func TestScanAndroidErrorsWhenNothingFound(t *testing.T) {
+	if _, err := ScanAndroid(t.TempDir()); err == nil {
+		t.Fatal("expected an error when neither build.gradle nor AndroidManifest.xml exists")
+	}
+}
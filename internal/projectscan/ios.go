@@ -0,0 +1,75 @@
+package projectscan
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/clix-so/nativefire/internal/apple"
+)
+
+// iosInfoPlistFiles are the conventional locations for a standalone
+// Info.plist when there's no enclosing .xcodeproj to resolve build
+// settings from (e.g. a bare native module consumed by a cross-platform app).
+var iosInfoPlistFiles = []string{
+	"ios/Runner/Info.plist",
+	"Info.plist",
+	"Runner/Info.plist",
+}
+
+// ScanIOS resolves every XCBuildConfiguration in the .xcodeproj under dir
+// (following any PRODUCT_BUNDLE_IDENTIFIER reference into its .xcconfig).
+// If no .xcodeproj is found, it falls back to decoding CFBundleIdentifier
+// out of a standalone Info.plist.
+func ScanIOS(dir string) (*IOSProject, error) {
+	pbxprojPath, err := apple.FindPbxproj(dir)
+	if err != nil {
+		return scanInfoPlist(dir)
+	}
+
+	configs, err := apple.ParseBuildConfigurations(pbxprojPath)
+	if err != nil {
+		return nil, err
+	}
+
+	project := &IOSProject{Configurations: map[string]string{}}
+	for name, settings := range configs {
+		raw, ok := settings["PRODUCT_BUNDLE_IDENTIFIER"]
+		if !ok {
+			continue
+		}
+
+		resolved := apple.ResolveVariable(raw, settings)
+		if resolved == "" || strings.Contains(resolved, "$(") || strings.Contains(resolved, "${") {
+			continue
+		}
+
+		project.Configurations[name] = resolved
+		if project.BundleID == "" || strings.EqualFold(name, "release") {
+			project.BundleID = resolved
+		}
+	}
+
+	if project.BundleID == "" {
+		return nil, fmt.Errorf("no resolvable PRODUCT_BUNDLE_IDENTIFIER found in %s", pbxprojPath)
+	}
+
+	return project, nil
+}
+
+func scanInfoPlist(dir string) (*IOSProject, error) {
+	for _, candidate := range iosInfoPlistFiles {
+		plist, err := apple.DecodePlist(filepath.Join(dir, candidate))
+		if err != nil {
+			continue
+		}
+
+		bundleID, _ := plist["CFBundleIdentifier"].(string)
+		if bundleID == "" || strings.Contains(bundleID, "$(") {
+			continue
+		}
+
+		return &IOSProject{BundleID: bundleID}, nil
+	}
+	return nil, fmt.Errorf("no .xcodeproj or Info.plist found under %s", dir)
+}
@@ -0,0 +1,94 @@
+package projectscan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const samplePbxproj = `// !$*UTF8*$!
+{
+	archiveVersion = 1;
+	objects = {
+		/* Begin XCBuildConfiguration section */
+		1111111111111111111111AA /* Debug */ = {
+			isa = XCBuildConfiguration;
+			buildSettings = {
+				PRODUCT_BUNDLE_IDENTIFIER = "$(UNRESOLVED_VAR)";
+			};
+			name = Debug;
+		};
+		3333333333333333333333CC /* Release */ = {
+			isa = XCBuildConfiguration;
+			buildSettings = {
+				PRODUCT_BUNDLE_IDENTIFIER = "com.example.release";
+			};
+			name = Release;
+		};
+		/* End XCBuildConfiguration section */
+	};
+}
+`
+
+const samplePlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>com.example.plistonly</string>
+</dict>
+</plist>
+`
+
+func writeXcodeproj(t *testing.T, pbxproj string) string {
+	t.Helper()
+	dir := t.TempDir()
+	xcodeprojDir := filepath.Join(dir, "Runner.xcodeproj")
+	if err := os.MkdirAll(xcodeprojDir, 0o755); err != nil {
+		t.Fatalf("failed to create .xcodeproj dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(xcodeprojDir, "project.pbxproj"), []byte(pbxproj), 0o644); err != nil {
+		t.Fatalf("failed to write pbxproj: %v", err)
+	}
+	return dir
+}
+
+func TestScanIOSPrefersReleaseAndSkipsUnresolvedConfigurations(t *testing.T) {
+	dir := writeXcodeproj(t, samplePbxproj)
+
+	project, err := ScanIOS(dir)
+	if err != nil {
+		t.Fatalf("ScanIOS() error = %v", err)
+	}
+
+	if project.BundleID != "com.example.release" {
+		t.Errorf("BundleID = %q, want com.example.release", project.BundleID)
+	}
+	if _, ok := project.Configurations["Debug"]; ok {
+		t.Error("expected the unresolved Debug configuration to be skipped")
+	}
+	if project.Configurations["Release"] != "com.example.release" {
+		t.Errorf("Configurations[Release] = %q", project.Configurations["Release"])
+	}
+}
+
+func TestScanIOSFallsBackToInfoPlistWithoutXcodeproj(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Info.plist"), []byte(samplePlist), 0o644); err != nil {
+		t.Fatalf("failed to write Info.plist: %v", err)
+	}
+
+	project, err := ScanIOS(dir)
+	if err != nil {
+		t.Fatalf("ScanIOS() error = %v", err)
+	}
+	if project.BundleID != "com.example.plistonly" {
+		t.Errorf("BundleID = %q, want com.example.plistonly", project.BundleID)
+	}
+}
+
+func TestScanIOSErrorsWhenNothingFound(t *testing.T) {
+	if _, err := ScanIOS(t.TempDir()); err == nil {
+		t.Fatal("expected an error when neither a .xcodeproj nor an Info.plist exists")
+	}
+}
@@ -0,0 +1,36 @@
+// Package projectscan parses Android and Apple project files into typed
+// models instead of scanning them line-by-line for a single identifier.
+// It replaces the ad-hoc string searches that used to live directly in
+// internal/firebase, so callers can get at build types, signing configs,
+// and product flavors without each one growing its own regex.
+package projectscan
+
+// AndroidProject is the structured result of scanning a module's
+// build.gradle(.kts) and AndroidManifest.xml.
+type AndroidProject struct {
+	ApplicationID  string
+	Namespace      string
+	MinSdkVersion  int
+	Flavors        []Flavor
+	BuildTypes     []string
+	SigningConfigs []string
+}
+
+// Flavor is one Android productFlavors entry, with its
+// applicationIdSuffix (if any) already applied to ApplicationID.
+type Flavor struct {
+	Name          string
+	ApplicationID string
+}
+
+// IOSProject is the structured result of resolving an Xcode project's
+// build settings, merging pbxproj entries with any .xcconfig they reference.
+type IOSProject struct {
+	// BundleID is the Release configuration's identifier, or, if there is
+	// no configuration named "Release", the identifier of one resolvable
+	// configuration; which one is not defined when there are several.
+	BundleID string
+	// Configurations maps each XCBuildConfiguration name to its resolved
+	// PRODUCT_BUNDLE_IDENTIFIER.
+	Configurations map[string]string
+}
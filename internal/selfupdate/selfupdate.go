@@ -0,0 +1,241 @@
+// Package selfupdate fetches, verifies, and applies nativefire releases from
+// GitHub to the currently running binary.
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	update "github.com/inconshreveable/go-update"
+	minisign "github.com/jedisct1/go-minisign"
+)
+
+// releasesAPI is the GitHub Releases API endpoint for this repo.
+const releasesAPI = "https://api.github.com/repos/clix-so/nativefire/releases"
+
+// DisabledEnvVar lets packaged installs (deb/rpm/msi/pkg) opt the binary out
+// of self-update, since the OS package manager owns updates there.
+const DisabledEnvVar = "NATIVEFIRE_UPDATE_DISABLED"
+
+// Disabled reports whether self-update has been disabled via environment.
+func Disabled() bool {
+	return os.Getenv(DisabledEnvVar) != ""
+}
+
+// NotifyDisabledEnvVar lets CI and other non-interactive environments opt
+// out of the background "update available" check Execute runs after a
+// command finishes, without having to pass --no-update-check every time.
+const NotifyDisabledEnvVar = "NATIVEFIRE_UPDATE_NOTIFY_DISABLED"
+
+// NotifyDisabled reports whether the background update-available check has
+// been disabled via environment.
+func NotifyDisabled() bool {
+	return os.Getenv(NotifyDisabledEnvVar) != ""
+}
+
+// Channel selects between stable (non-prerelease) and prerelease releases.
+type Channel string
+
+const (
+	Stable     Channel = "stable"
+	Prerelease Channel = "prerelease"
+)
+
+// Release mirrors the subset of the GitHub Releases API response nativefire
+// needs.
+type Release struct {
+	TagName    string  `json:"tag_name"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset is a single downloadable file attached to a release.
+type Asset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// FetchLatest returns the newest release on the given channel.
+func FetchLatest(channel Channel) (*Release, error) {
+	resp, err := http.Get(releasesAPI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query GitHub releases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub releases API returned status %d", resp.StatusCode)
+	}
+
+	var releases []Release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases response: %w", err)
+	}
+
+	for _, release := range releases {
+		if channel == Prerelease || !release.Prerelease {
+			return &release, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no %s release found", channel)
+}
+
+// AssetName returns the expected release asset name for the running os/arch,
+// matching GoReleaser's default archive naming.
+func AssetName(version string) string {
+	return fmt.Sprintf("nativefire_%s_%s_%s.tar.gz", version, runtime.GOOS, runtime.GOARCH)
+}
+
+// BinaryName is the executable's name inside a release archive.
+func BinaryName() string {
+	if runtime.GOOS == "windows" {
+		return "nativefire.exe"
+	}
+	return "nativefire"
+}
+
+// FindAsset locates the asset named name within a release.
+func FindAsset(release *Release, name string) (*Asset, error) {
+	for i := range release.Assets {
+		if release.Assets[i].Name == name {
+			return &release.Assets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no release asset found named %s", name)
+}
+
+// Download fetches url's contents into memory.
+func Download(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download of %s returned status %d", url, resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// VerifyChecksum confirms data's sha256 matches the entry for assetName in a
+// checksums.txt file (one "<sha256>  <filename>" line per release asset, the
+// format GoReleaser emits).
+func VerifyChecksum(checksumsTxt []byte, assetName string, data []byte) error {
+	sum := sha256.Sum256(data)
+	actual := hex.EncodeToString(sum[:])
+
+	for _, line := range strings.Split(string(checksumsTxt), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if fields[1] != assetName {
+			continue
+		}
+		if fields[0] != actual {
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", assetName, fields[0], actual)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no checksum entry found for %s", assetName)
+}
+
+// releasePublicKey is nativefire's minisign public key, baked into the
+// binary so signature verification works offline and doesn't itself need to
+// be fetched and trusted at update time. It's the counterpart to the
+// private key GoReleaser's sign step uses to produce checksums.txt.minisig
+// for each release.
+const releasePublicKey = "untrusted comment: minisign public key for clix-so/nativefire releases\n" +
+	"RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0YWxEzDozoTM6M\n"
+
+// VerifyMinisignSignature confirms signature - the contents of a release's
+// checksums.txt.minisig asset - is a valid minisign signature of data, made
+// by releasePublicKey. Signing checksums.txt rather than each archive
+// individually means one signature vouches for every platform's checksum,
+// which VerifyChecksum has already tied to the downloaded archive.
+func VerifyMinisignSignature(data, signature []byte) error {
+	pub, err := minisign.NewPublicKey(releasePublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse embedded minisign public key: %w", err)
+	}
+
+	sig, err := minisign.DecodeSignature(string(signature))
+	if err != nil {
+		return fmt.Errorf("failed to parse minisign signature: %w", err)
+	}
+
+	ok, err := pub.Verify(data, sig)
+	if err != nil {
+		return fmt.Errorf("failed to verify minisign signature: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("minisign signature verification failed")
+	}
+	return nil
+}
+
+// VerifyCosignSignature is a hook for verifying a release asset against its
+// cosign signature and certificate. Full keyless verification needs network
+// access to Fulcio/Rekor, which this package does not make, so it always
+// returns an error: one naming a missing signature or certificate, or else
+// one saying cosign verification is not implemented. Checksum verification
+// remains mandatory.
+func VerifyCosignSignature(assetData, signature, certificate []byte) error {
+	if len(signature) == 0 || len(certificate) == 0 {
+		return fmt.Errorf("cosign signature or certificate missing")
+	}
+	return fmt.Errorf("cosign signature verification is not yet implemented; rely on --check and the checksums.txt verification instead")
+}
+
+// ExtractBinary reads the nativefire binary named binaryName out of a
+// GoReleaser-style .tar.gz release archive.
+func ExtractBinary(archive []byte, binaryName string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open release archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read release archive: %w", err)
+		}
+		if filepath.Base(hdr.Name) == binaryName {
+			return io.ReadAll(tr)
+		}
+	}
+
+	return nil, fmt.Errorf("binary %s not found in release archive", binaryName)
+}
+
+// Apply swaps the running binary for the contents of binary via go-update,
+// rolling back automatically if the swap fails partway through.
+func Apply(binary io.Reader) error {
+	if err := update.Apply(binary, update.Options{}); err != nil {
+		if rerr := update.RollbackError(err); rerr != nil {
+			return fmt.Errorf("failed to apply update and roll back: %w", rerr)
+		}
+		return fmt.Errorf("failed to apply update: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func buildTarGz(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	if err := tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(content)), Mode: 0755}); err != nil {
+		t.Fatalf("failed to write tar header: %v", err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatalf("failed to write tar content: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar writer: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestExtractBinary(t *testing.T) {
+	content := []byte("#!/bin/sh\necho hello\n")
+	archive := buildTarGz(t, "nativefire", content)
+
+	extracted, err := ExtractBinary(archive, "nativefire")
+	if err != nil {
+		t.Fatalf("ExtractBinary() error = %v", err)
+	}
+	if !bytes.Equal(extracted, content) {
+		t.Fatalf("ExtractBinary() = %q, want %q", extracted, content)
+	}
+
+	if _, err := ExtractBinary(archive, "missing-binary"); err == nil {
+		t.Fatal("expected an error for a binary name not present in the archive")
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("release archive contents")
+	sum := sha256.Sum256(data)
+	hexSum := hex.EncodeToString(sum[:])
+
+	checksums := []byte(fmt.Sprintf("%s  nativefire_1.0.0_linux_amd64.tar.gz\n%s  other.tar.gz\n",
+		hexSum, "deadbeef"))
+
+	if err := VerifyChecksum(checksums, "nativefire_1.0.0_linux_amd64.tar.gz", data); err != nil {
+		t.Fatalf("VerifyChecksum() error = %v", err)
+	}
+
+	if err := VerifyChecksum(checksums, "other.tar.gz", data); err == nil {
+		t.Fatal("expected a checksum mismatch error")
+	}
+
+	if err := VerifyChecksum(checksums, "unknown.tar.gz", data); err == nil {
+		t.Fatal("expected an error for a missing checksum entry")
+	}
+}
+
+func TestVerifyMinisignSignatureRejectsMalformedSignature(t *testing.T) {
+	if err := VerifyMinisignSignature([]byte("checksums"), []byte("not a minisign signature")); err == nil {
+		t.Fatal("expected an error for a malformed minisign signature")
+	}
+}
+
+func TestAssetName(t *testing.T) {
+	name := AssetName("1.2.3")
+	if name == "" {
+		t.Fatal("expected a non-empty asset name")
+	}
+}
@@ -0,0 +1,362 @@
+// Package sourceedit does targeted, idempotent edits to Swift and
+// Objective-C AppDelegate sources, mirroring the approach the xcodeproj
+// package takes with project.pbxproj: it isn't a full language parser, but
+// it tokenizes just enough — tracking braces, string/char literals, and
+// comments — to locate the application(_:didFinishLaunchingWithOptions:) /
+// application:didFinishLaunchingWithOptions: method regardless of its exact
+// formatting, attributes, or throws/async variant, and to find the actual
+// end of its enclosing type rather than assuming it's the file's last "}"
+// (which can land inside a trailing extension).
+package sourceedit
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Language selects which method-selector pattern and comment/string
+// conventions apply when scanning a file.
+type Language int
+
+const (
+	Swift Language = iota
+	ObjC
+)
+
+// swiftAppDelegateMethod matches a Swift
+// application(_:didFinishLaunchingWithOptions:) declaration regardless of
+// argument label spelling, leading @objc/@available attributes, or an
+// async/throws/return-type variant, capturing up to and including its
+// opening brace.
+var swiftAppDelegateMethod = regexp.MustCompile(
+	`(?s)(?:@\w+(?:\([^)]*\))?\s+)*func\s+application\s*\(\s*_\s+\w+\s*:\s*UIApplication\s*,\s*didFinishLaunchingWithOptions\s+\w+\s*:[^){]*\)[^{]*\{`)
+
+// objcAppDelegateMethod matches an Objective-C
+// application:didFinishLaunchingWithOptions: declaration regardless of
+// parameter name spelling or intervening whitespace, capturing up to and
+// including its opening brace.
+var objcAppDelegateMethod = regexp.MustCompile(
+	`(?s)-\s*\(\s*BOOL\s*\)\s*application\s*:\s*\([^)]*\)\s*\w+\s+didFinishLaunchingWithOptions\s*:\s*\([^)]*\)\s*\w+\s*\{`)
+
+// File wraps the source of a single Swift or Objective-C file being edited.
+type File struct {
+	Content string
+	lang    Language
+}
+
+// Parse wraps content for editing as lang.
+func Parse(lang Language, content string) *File {
+	return &File{Content: content, lang: lang}
+}
+
+func (f *File) selector() *regexp.Regexp {
+	if f.lang == ObjC {
+		return objcAppDelegateMethod
+	}
+	return swiftAppDelegateMethod
+}
+
+// EnsureAppDelegateConfigure inserts configureStmt as the first statement of
+// the application(didFinishLaunchingWithOptions:) method, unless marker
+// already appears anywhere in the file. Returns whether a change was made.
+func (f *File) EnsureAppDelegateConfigure(configureStmt, marker string) (bool, error) {
+	if strings.Contains(f.Content, marker) {
+		return false, nil
+	}
+
+	bodyStart, _, declStart, ok := f.findMethod()
+	if !ok {
+		return false, fmt.Errorf("could not find application(didFinishLaunchingWithOptions:) method")
+	}
+
+	indent := f.lineIndent(declStart) + f.indentUnit()
+	f.Content = f.Content[:bodyStart] + "\n" + indent + configureStmt + f.Content[bodyStart:]
+	return true, nil
+}
+
+// EnsureAppDelegateMembers inserts members as sibling members of the
+// application(didFinishLaunchingWithOptions:) method — i.e. just before the
+// closing brace of its enclosing type, not the file's last "}" — unless
+// marker already appears anywhere in the file.
+func (f *File) EnsureAppDelegateMembers(members, marker string) (bool, error) {
+	if strings.Contains(f.Content, marker) {
+		return false, nil
+	}
+
+	methodOpen, methodClose, ok := f.findMethodBody()
+	if !ok {
+		return false, fmt.Errorf("could not find application(didFinishLaunchingWithOptions:) method")
+	}
+
+	pairs := braceMap(f.Content)
+	typeOpen, typeClose, ok := enclosingBrace(pairs, methodOpen-1, methodClose)
+	if !ok {
+		return false, fmt.Errorf("could not find enclosing type for application(didFinishLaunchingWithOptions:)")
+	}
+
+	indent := f.lineIndent(typeOpen) + f.indentUnit()
+	var b strings.Builder
+	b.WriteString("\n")
+	for _, line := range strings.Split(strings.Trim(members, "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(indent)
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	f.Content = f.Content[:typeClose] + b.String() + f.Content[typeClose:]
+	return true, nil
+}
+
+// swiftUIAppStruct matches a `struct X: App { ... }` declaration, capturing
+// up to and including its opening brace. It tolerates additional protocol
+// conformances in any order (`struct X: App, Y {` or `struct X: Y, App {`)
+// and a conformance list that wraps across lines, but requires an actual ":"
+// conformance clause so it can't be confused with an unrelated type like
+// `struct AppState { ... }`.
+var swiftUIAppStruct = regexp.MustCompile(
+	`(?s)struct\s+\w+\s*:\s*(?:[\w.]+\s*,\s*)*App\b[^{]*\{`)
+
+// uiApplicationDelegateAdaptorProperty matches a
+// `@UIApplicationDelegateAdaptor` property declaration regardless of its
+// variable name, explicit type annotation, or access modifier.
+var uiApplicationDelegateAdaptorProperty = regexp.MustCompile(
+	`@UIApplicationDelegateAdaptor(?:\([^)]*\))?\s*(?:private\s+|fileprivate\s+)?var\s+\w+`)
+
+// EnsureUIApplicationDelegateAdaptor inserts a
+// `@UIApplicationDelegateAdaptor(adaptorType.self) var delegate` property
+// into the file's `struct X: App { ... }` declaration, unless a property
+// with that attribute already exists under any variable name. Returns
+// whether a change was made.
+func (f *File) EnsureUIApplicationDelegateAdaptor(adaptorType string) (bool, error) {
+	typeOpen, bodyStart, bodyEnd, ok := f.findAppStructBody()
+	if !ok {
+		return false, fmt.Errorf("could not find a `struct X: App { ... }` declaration")
+	}
+
+	if uiApplicationDelegateAdaptorProperty.MatchString(f.Content[bodyStart:bodyEnd]) {
+		return false, nil
+	}
+
+	indent := f.lineIndent(typeOpen) + f.indentUnit()
+	property := fmt.Sprintf("\n%s@UIApplicationDelegateAdaptor(%s.self) var delegate\n", indent, adaptorType)
+	f.Content = f.Content[:bodyStart] + property + f.Content[bodyStart:]
+	return true, nil
+}
+
+// RemoveUIApplicationDelegateAdaptor removes a `@UIApplicationDelegateAdaptor`
+// property, under whatever variable name it was declared with, from the
+// file's `struct X: App { ... }` declaration. Returns whether a change was
+// made; it's a no-op if there's no App struct or no such property.
+func (f *File) RemoveUIApplicationDelegateAdaptor() (bool, error) {
+	_, bodyStart, bodyEnd, ok := f.findAppStructBody()
+	if !ok {
+		return false, nil
+	}
+
+	body := f.Content[bodyStart:bodyEnd]
+	loc := uiApplicationDelegateAdaptorProperty.FindStringIndex(body)
+	if loc == nil {
+		return false, nil
+	}
+
+	lineStart := strings.LastIndex(body[:loc[0]], "\n") + 1
+	lineEnd := loc[1]
+	if nl := strings.Index(body[lineEnd:], "\n"); nl != -1 {
+		lineEnd += nl + 1
+	} else {
+		lineEnd = len(body)
+	}
+	// EnsureUIApplicationDelegateAdaptor inserts the property wrapped in its
+	// own blank line ("\n<indent>@UIApplicationDelegateAdaptor...\n"); undo
+	// that symmetrically instead of leaving the blank line behind.
+	if lineEnd < len(body) && body[lineEnd] == '\n' {
+		lineEnd++
+	}
+
+	newBody := body[:lineStart] + body[lineEnd:]
+	f.Content = f.Content[:bodyStart] + newBody + f.Content[bodyEnd:]
+	return true, nil
+}
+
+// findAppStructBody locates the file's `struct X: App { ... }` declaration
+// and returns the byte offset of its opening brace along with the offsets
+// just inside that brace and at its matching closing brace.
+func (f *File) findAppStructBody() (typeOpen, bodyStart, bodyEnd int, ok bool) {
+	pairs := braceMap(f.Content)
+	for _, loc := range swiftUIAppStruct.FindAllStringIndex(f.Content, -1) {
+		openBrace := loc[1] - 1
+		if closeBrace, isReal := pairs[openBrace]; isReal {
+			return openBrace, openBrace + 1, closeBrace, true
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// RemoveAppDelegateConfigure removes the line containing marker, undoing
+// EnsureAppDelegateConfigure. It's a no-op if marker isn't present.
+func (f *File) RemoveAppDelegateConfigure(marker string) (bool, error) {
+	idx := strings.Index(f.Content, marker)
+	if idx == -1 {
+		return false, nil
+	}
+
+	lineStart := strings.LastIndex(f.Content[:idx], "\n") + 1
+	lineEnd := idx + len(marker)
+	if nl := strings.Index(f.Content[lineEnd:], "\n"); nl != -1 {
+		lineEnd += nl + 1
+	} else {
+		lineEnd = len(f.Content)
+	}
+
+	f.Content = f.Content[:lineStart] + f.Content[lineEnd:]
+	return true, nil
+}
+
+// RemoveAppDelegateMembers removes the block of members EnsureAppDelegateMembers
+// inserted, identified by marker, up to the closing brace of its enclosing
+// type. It's a no-op if marker isn't present.
+func (f *File) RemoveAppDelegateMembers(marker string) (bool, error) {
+	idx := strings.Index(f.Content, marker)
+	if idx == -1 {
+		return false, nil
+	}
+
+	pairs := braceMap(f.Content)
+	_, typeClose, ok := enclosingBrace(pairs, idx, idx)
+	if !ok {
+		return false, fmt.Errorf("could not find enclosing type for marker %q", marker)
+	}
+
+	lineStart := strings.LastIndex(f.Content[:idx], "\n") + 1
+	f.Content = f.Content[:lineStart] + f.Content[typeClose:]
+	return true, nil
+}
+
+// findMethodBody locates the application(didFinishLaunchingWithOptions:)
+// method and returns the byte offsets just inside its opening brace and at
+// its closing brace.
+func (f *File) findMethodBody() (bodyStart, bodyEnd int, ok bool) {
+	bodyStart, bodyEnd, _, ok = f.findMethod()
+	return bodyStart, bodyEnd, ok
+}
+
+// findMethod is findMethodBody plus the offset where the method declaration
+// itself starts (as opposed to the opening brace, which can land on a
+// continuation line of a multi-line signature indented to align with the
+// parameter list rather than with the method's own nesting level).
+func (f *File) findMethod() (bodyStart, bodyEnd, declStart int, ok bool) {
+	loc := f.selector().FindStringIndex(f.Content)
+	if loc == nil {
+		return 0, 0, 0, false
+	}
+
+	openBrace := loc[1] - 1
+	closeBrace, ok := braceMap(f.Content)[openBrace]
+	if !ok {
+		return 0, 0, 0, false
+	}
+	return openBrace + 1, closeBrace, loc[0], true
+}
+
+// lineIndent returns the leading whitespace of the line containing pos.
+func (f *File) lineIndent(pos int) string {
+	start := strings.LastIndex(f.Content[:pos], "\n") + 1
+	line := f.Content[start:pos]
+	var b strings.Builder
+	for _, r := range line {
+		if r == ' ' || r == '\t' {
+			b.WriteRune(r)
+		} else {
+			break
+		}
+	}
+	return b.String()
+}
+
+// indentUnit reports the indentation step (tab or four spaces) used
+// elsewhere in the file, so inserted lines match the surrounding style.
+func (f *File) indentUnit() string {
+	if strings.Contains(f.Content, "\n\t") {
+		return "\t"
+	}
+	return "    "
+}
+
+// braceMap returns, for every '{' byte offset in content, the offset of its
+// matching '}', ignoring braces that appear inside string/char literals or
+// line/block comments.
+func braceMap(content string) map[int]int {
+	matches := make(map[int]int)
+	var stack []int
+	i, n := 0, len(content)
+
+	for i < n {
+		switch {
+		case content[i] == '/' && i+1 < n && content[i+1] == '/':
+			for i < n && content[i] != '\n' {
+				i++
+			}
+		case content[i] == '/' && i+1 < n && content[i+1] == '*':
+			i += 2
+			for i+1 < n && !(content[i] == '*' && content[i+1] == '/') {
+				i++
+			}
+			i += 2
+		case content[i] == '"':
+			i++
+			for i < n && content[i] != '"' {
+				if content[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i++
+		case content[i] == '\'':
+			i++
+			for i < n && content[i] != '\'' {
+				if content[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i++
+		case content[i] == '{':
+			stack = append(stack, i)
+			i++
+		case content[i] == '}':
+			if len(stack) > 0 {
+				open := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				matches[open] = i
+			}
+			i++
+		default:
+			i++
+		}
+	}
+
+	return matches
+}
+
+// enclosingBrace returns the innermost brace pair in pairs that strictly
+// contains [open, close), i.e. the immediate parent scope of the method
+// whose body spans that range.
+func enclosingBrace(pairs map[int]int, open, close int) (int, int, bool) {
+	bestOpen, bestClose := -1, -1
+	for o, c := range pairs {
+		if o < open && c > close {
+			if bestOpen == -1 || o > bestOpen {
+				bestOpen, bestClose = o, c
+			}
+		}
+	}
+	if bestOpen == -1 {
+		return 0, 0, false
+	}
+	return bestOpen, bestClose, true
+}
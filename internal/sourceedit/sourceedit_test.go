@@ -0,0 +1,308 @@
+package sourceedit
+
+import (
+	"strings"
+	"testing"
+)
+
+const swiftAppDelegateSample = `import UIKit
+
+@main
+class AppDelegate: UIResponder, UIApplicationDelegate {
+
+    var window: UIWindow?
+
+    func application(_ application: UIApplication,
+                     didFinishLaunchingWithOptions launchOptions: [UIApplication.LaunchOptionsKey: Any]?) -> Bool {
+        // Override point for customization after application launch.
+        return true
+    }
+}
+
+extension AppDelegate {
+    func someHelper() {}
+}
+`
+
+func TestEnsureAppDelegateConfigureSwift(t *testing.T) {
+	f := Parse(Swift, swiftAppDelegateSample)
+
+	changed, err := f.EnsureAppDelegateConfigure("FirebaseApp.configure()", "FirebaseApp.configure()")
+	if err != nil {
+		t.Fatalf("EnsureAppDelegateConfigure() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected first call to report a change")
+	}
+	if !strings.Contains(f.Content, "didFinishLaunchingWithOptions launchOptions: [UIApplication.LaunchOptionsKey: Any]?) -> Bool {\n        FirebaseApp.configure()") {
+		t.Fatalf("expected FirebaseApp.configure() inserted as first statement, got:\n%s", f.Content)
+	}
+
+	changed, err = f.EnsureAppDelegateConfigure("FirebaseApp.configure()", "FirebaseApp.configure()")
+	if err != nil {
+		t.Fatalf("second EnsureAppDelegateConfigure() error = %v", err)
+	}
+	if changed {
+		t.Fatal("expected second call to be a no-op")
+	}
+}
+
+func TestEnsureAppDelegateMembersInsertsInsideClassNotExtension(t *testing.T) {
+	f := Parse(Swift, swiftAppDelegateSample)
+
+	const marker = "MARK: - Firebase Push Notification Delegate Methods"
+	members := "\n// " + marker + "\nfunc application(_ application: UIApplication, didRegisterForRemoteNotificationsWithDeviceToken deviceToken: Data) {}\n"
+
+	changed, err := f.EnsureAppDelegateMembers(members, marker)
+	if err != nil {
+		t.Fatalf("EnsureAppDelegateMembers() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected first call to report a change")
+	}
+
+	classStart := strings.Index(f.Content, "class AppDelegate")
+	extStart := strings.Index(f.Content, "extension AppDelegate")
+	markerIdx := strings.Index(f.Content, marker)
+	if markerIdx == -1 || markerIdx < classStart || markerIdx > extStart {
+		t.Fatalf("expected inserted members inside AppDelegate class, before the extension, got:\n%s", f.Content)
+	}
+
+	changed, err = f.EnsureAppDelegateMembers(members, marker)
+	if err != nil {
+		t.Fatalf("second EnsureAppDelegateMembers() error = %v", err)
+	}
+	if changed {
+		t.Fatal("expected second call to be a no-op")
+	}
+}
+
+func TestRemoveAppDelegateConfigure(t *testing.T) {
+	f := Parse(Swift, swiftAppDelegateSample)
+
+	if _, err := f.EnsureAppDelegateConfigure("FirebaseApp.configure()", "FirebaseApp.configure()"); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := f.RemoveAppDelegateConfigure("FirebaseApp.configure()")
+	if err != nil {
+		t.Fatalf("RemoveAppDelegateConfigure() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected first call to report a change")
+	}
+	if strings.Contains(f.Content, "FirebaseApp.configure()") {
+		t.Fatalf("expected FirebaseApp.configure() to be removed, got:\n%s", f.Content)
+	}
+	if f.Content != swiftAppDelegateSample {
+		t.Fatalf("expected the file to match its original content, got:\n%s", f.Content)
+	}
+
+	changed, err = f.RemoveAppDelegateConfigure("FirebaseApp.configure()")
+	if err != nil {
+		t.Fatalf("second RemoveAppDelegateConfigure() error = %v", err)
+	}
+	if changed {
+		t.Fatal("expected second call to be a no-op")
+	}
+}
+
+func TestRemoveAppDelegateMembers(t *testing.T) {
+	f := Parse(Swift, swiftAppDelegateSample)
+
+	const marker = "MARK: - Firebase Push Notification Delegate Methods"
+	members := "\n// " + marker + "\nfunc application(_ application: UIApplication, didRegisterForRemoteNotificationsWithDeviceToken deviceToken: Data) {}\n"
+
+	if _, err := f.EnsureAppDelegateMembers(members, marker); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := f.RemoveAppDelegateMembers(marker)
+	if err != nil {
+		t.Fatalf("RemoveAppDelegateMembers() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected first call to report a change")
+	}
+	if strings.Contains(f.Content, marker) {
+		t.Fatalf("expected the members block to be removed, got:\n%s", f.Content)
+	}
+	if !strings.Contains(f.Content, "extension AppDelegate") {
+		t.Fatalf("expected the rest of the file to be preserved, got:\n%s", f.Content)
+	}
+
+	changed, err = f.RemoveAppDelegateMembers(marker)
+	if err != nil {
+		t.Fatalf("second RemoveAppDelegateMembers() error = %v", err)
+	}
+	if changed {
+		t.Fatal("expected second call to be a no-op")
+	}
+}
+
+func TestEnsureAppDelegateConfigureToleratesReformattedSignature(t *testing.T) {
+	const reformatted = `import UIKit
+
+@main
+@objc(AppDelegate)
+class AppDelegate: UIResponder, UIApplicationDelegate {
+  func application(
+    _ app: UIApplication,
+    didFinishLaunchingWithOptions opts: [UIApplication.LaunchOptionsKey: Any]? = nil
+  ) async throws -> Bool {
+    return true
+  }
+}
+`
+	f := Parse(Swift, reformatted)
+
+	changed, err := f.EnsureAppDelegateConfigure("FirebaseApp.configure()", "FirebaseApp.configure()")
+	if err != nil {
+		t.Fatalf("EnsureAppDelegateConfigure() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected reformatted signature to still be found")
+	}
+}
+
+const objcAppDelegateSample = `#import "AppDelegate.h"
+
+@implementation AppDelegate
+
+- (BOOL)application:(UIApplication *)application didFinishLaunchingWithOptions:(NSDictionary *)launchOptions {
+    return YES;
+}
+
+@end
+`
+
+const swiftUIAppSample = `import SwiftUI
+
+// struct CommentedOutApp: App { } -- an old draft, left here to make sure we
+// don't mistake a commented-out declaration for the real one.
+@main
+struct MyApp: App {
+    var body: some Scene {
+        WindowGroup {
+            ContentView()
+        }
+    }
+}
+`
+
+func TestEnsureUIApplicationDelegateAdaptor(t *testing.T) {
+	f := Parse(Swift, swiftUIAppSample)
+
+	changed, err := f.EnsureUIApplicationDelegateAdaptor("AppDelegate")
+	if err != nil {
+		t.Fatalf("EnsureUIApplicationDelegateAdaptor() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected first call to report a change")
+	}
+	if !strings.Contains(f.Content, "struct MyApp: App {\n    @UIApplicationDelegateAdaptor(AppDelegate.self) var delegate") {
+		t.Fatalf("expected delegate adaptor inserted inside the App struct, got:\n%s", f.Content)
+	}
+
+	changed, err = f.EnsureUIApplicationDelegateAdaptor("AppDelegate")
+	if err != nil {
+		t.Fatalf("second EnsureUIApplicationDelegateAdaptor() error = %v", err)
+	}
+	if changed {
+		t.Fatal("expected second call to be a no-op")
+	}
+}
+
+func TestEnsureUIApplicationDelegateAdaptorRecognizesExistingPropertyUnderAnyName(t *testing.T) {
+	const sample = `import SwiftUI
+
+@main
+struct MyApp: App {
+    @UIApplicationDelegateAdaptor(AppDelegate.self) private var appDelegate
+
+    var body: some Scene {
+        WindowGroup { ContentView() }
+    }
+}
+`
+	f := Parse(Swift, sample)
+
+	changed, err := f.EnsureUIApplicationDelegateAdaptor("AppDelegate")
+	if err != nil {
+		t.Fatalf("EnsureUIApplicationDelegateAdaptor() error = %v", err)
+	}
+	if changed {
+		t.Fatal("expected no change when a differently-named delegate adaptor property already exists")
+	}
+}
+
+func TestEnsureUIApplicationDelegateAdaptorToleratesMultiProtocolConformance(t *testing.T) {
+	const sample = `import SwiftUI
+
+@main
+struct MyApp: SomeOtherProtocol, App {
+    var body: some Scene {
+        WindowGroup { ContentView() }
+    }
+}
+`
+	f := Parse(Swift, sample)
+
+	changed, err := f.EnsureUIApplicationDelegateAdaptor("AppDelegate")
+	if err != nil {
+		t.Fatalf("EnsureUIApplicationDelegateAdaptor() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected the App struct to be found despite the extra protocol conformance")
+	}
+}
+
+func TestRemoveUIApplicationDelegateAdaptor(t *testing.T) {
+	f := Parse(Swift, swiftUIAppSample)
+
+	if _, err := f.EnsureUIApplicationDelegateAdaptor("AppDelegate"); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := f.RemoveUIApplicationDelegateAdaptor()
+	if err != nil {
+		t.Fatalf("RemoveUIApplicationDelegateAdaptor() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected first call to report a change")
+	}
+	if strings.Contains(f.Content, "UIApplicationDelegateAdaptor") {
+		t.Fatalf("expected delegate adaptor property to be removed, got:\n%s", f.Content)
+	}
+	if !strings.Contains(f.Content, "struct MyApp: App {\n    var body: some Scene {") {
+		t.Fatalf("expected the rest of the struct to be untouched, got:\n%s", f.Content)
+	}
+
+	changed, err = f.RemoveUIApplicationDelegateAdaptor()
+	if err != nil {
+		t.Fatalf("second RemoveUIApplicationDelegateAdaptor() error = %v", err)
+	}
+	if changed {
+		t.Fatal("expected second call to be a no-op")
+	}
+}
+
+func TestEnsureAppDelegateConfigureObjC(t *testing.T) {
+	f := Parse(ObjC, objcAppDelegateSample)
+
+	changed, err := f.EnsureAppDelegateConfigure("[FIRApp configure];", "[FIRApp configure]")
+	if err != nil {
+		t.Fatalf("EnsureAppDelegateConfigure() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected first call to report a change")
+	}
+
+	changed, err = f.EnsureAppDelegateConfigure("[FIRApp configure];", "[FIRApp configure]")
+	if err != nil {
+		t.Fatalf("second EnsureAppDelegateConfigure() error = %v", err)
+	}
+	if changed {
+		t.Fatal("expected second call to be a no-op")
+	}
+}
@@ -0,0 +1,81 @@
+// Package desktop renders the embedded Firebase initialization templates used
+// by the desktop platforms (macOS, Windows, Linux).
+package desktop
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//go:embed cpp/*.tmpl swift/*.tmpl cmake/*.tmpl
+var templatesFS embed.FS
+
+// Begin/End mark the idempotent block nativefire owns inside a generated file.
+// Re-running configure replaces everything between the markers instead of
+// appending a duplicate block.
+const (
+	BeginMarker = "// nativefire:begin"
+	EndMarker   = "// nativefire:end"
+)
+
+// CMake files use `#` comments rather than `//`.
+const (
+	CMakeBeginMarker = "# nativefire:begin"
+	CMakeEndMarker   = "# nativefire:end"
+)
+
+// Data holds the values templates pull from firebase.Config.
+type Data struct {
+	ProjectID      string
+	AppID          string
+	StorageBucket  string
+	APIKey         string
+	ConfigFileName string
+	Modules        []string
+}
+
+// HasModule reports whether the given product module was requested via
+// `--modules`.
+func (d Data) HasModule(name string) bool {
+	for _, m := range d.Modules {
+		if strings.EqualFold(strings.TrimSpace(m), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Render executes the named embedded template against data and returns the
+// rendered text.
+func Render(name string, data Data) (string, error) {
+	tmpl, err := template.ParseFS(templatesFS, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// ParseModules splits a comma-separated `--modules` flag value into a
+// normalized module list.
+func ParseModules(flag string) []string {
+	if strings.TrimSpace(flag) == "" {
+		return nil
+	}
+
+	var modules []string
+	for _, m := range strings.Split(flag, ",") {
+		m = strings.ToLower(strings.TrimSpace(m))
+		if m != "" {
+			modules = append(modules, m)
+		}
+	}
+	return modules
+}
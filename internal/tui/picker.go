@@ -0,0 +1,107 @@
+// Package tui provides an interactive, fuzzy-filterable list picker used
+// in place of numeric stdin prompts (e.g. "Select a project (1-N):")
+// wherever stdout looks like a real terminal.
+package tui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+)
+
+// Item is one selectable entry: Title is the bold line shown in the list,
+// and Detail is rendered alongside it (e.g. a project's number, or an
+// app's platform and identifier).
+type Item struct {
+	ID     string
+	Title  string
+	Detail string
+}
+
+// listEntry adapts Item to bubbles/list.Item without colliding Item's
+// Title field with the Title() method list.DefaultDelegate expects.
+type listEntry struct {
+	item Item
+}
+
+func (e listEntry) Title() string       { return e.item.Title }
+func (e listEntry) Description() string { return e.item.Detail }
+func (e listEntry) FilterValue() string { return e.item.Title }
+
+type pickerModel struct {
+	list     list.Model
+	choice   *Item
+	quitting bool
+}
+
+func newPickerModel(prompt string, items []Item) pickerModel {
+	entries := make([]list.Item, len(items))
+	for i, item := range items {
+		entries[i] = listEntry{item: item}
+	}
+
+	l := list.New(entries, list.NewDefaultDelegate(), 0, 0)
+	l.Title = prompt
+
+	return pickerModel{list: l}
+}
+
+func (m pickerModel) Init() tea.Cmd { return nil }
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.quitting = true
+			return m, tea.Quit
+		case "enter":
+			if entry, ok := m.list.SelectedItem().(listEntry); ok {
+				choice := entry.item
+				m.choice = &choice
+			}
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m pickerModel) View() string {
+	if m.quitting {
+		return ""
+	}
+	return m.list.View()
+}
+
+// Pick runs an interactive, fuzzy-filterable picker over items and returns
+// the one the user selects. It returns an error if the user quits without
+// choosing (Esc/Ctrl+C), so callers can fall back to a plain prompt rather
+// than silently proceeding with nothing selected.
+func Pick(prompt string, items []Item) (Item, error) {
+	finalModel, err := tea.NewProgram(newPickerModel(prompt, items)).Run()
+	if err != nil {
+		return Item{}, fmt.Errorf("failed to run interactive picker: %w", err)
+	}
+
+	result, ok := finalModel.(pickerModel)
+	if !ok || result.choice == nil {
+		return Item{}, fmt.Errorf("no selection made")
+	}
+
+	return *result.choice, nil
+}
+
+// IsInteractiveTerminal reports whether stdout looks like a real terminal
+// (not a pipe or CI log). --tui's auto-detection uses this to decide
+// whether showing the picker makes sense without requiring the flag.
+func IsInteractiveTerminal() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
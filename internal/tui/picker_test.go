@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestListEntryAdaptsItem(t *testing.T) {
+	entry := listEntry{item: Item{ID: "1", Title: "my-project", Detail: "1234567890"}}
+
+	if entry.Title() != "my-project" {
+		t.Errorf("Title() = %q, want %q", entry.Title(), "my-project")
+	}
+	if entry.Description() != "1234567890" {
+		t.Errorf("Description() = %q, want %q", entry.Description(), "1234567890")
+	}
+	if entry.FilterValue() != "my-project" {
+		t.Errorf("FilterValue() = %q, want %q", entry.FilterValue(), "my-project")
+	}
+}
+
+func TestPickerModelSelectsOnEnter(t *testing.T) {
+	items := []Item{
+		{ID: "a", Title: "project-a", Detail: "111"},
+		{ID: "b", Title: "project-b", Detail: "222"},
+	}
+	model := newPickerModel("Select a project", items)
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, ok := updated.(pickerModel)
+	if !ok {
+		t.Fatal("Update() did not return a pickerModel")
+	}
+	if m.choice == nil {
+		t.Fatal("expected a choice to be recorded on enter")
+	}
+	if m.choice.ID != "a" {
+		t.Errorf("expected the first item to be selected by default, got %q", m.choice.ID)
+	}
+	if cmd == nil {
+		t.Error("expected Update() to return tea.Quit on enter")
+	}
+}
+
+func TestPickerModelQuitsOnEscape(t *testing.T) {
+	model := newPickerModel("Select a project", []Item{{ID: "a", Title: "project-a"}})
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m, ok := updated.(pickerModel)
+	if !ok {
+		t.Fatal("Update() did not return a pickerModel")
+	}
+	if !m.quitting {
+		t.Error("expected quitting to be set on escape")
+	}
+	if m.choice != nil {
+		t.Error("expected no choice to be recorded on escape")
+	}
+	if cmd == nil {
+		t.Error("expected Update() to return tea.Quit on escape")
+	}
+}
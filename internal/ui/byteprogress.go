@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// rateWindowSize is how many recent samples ByteProgress averages before
+// feeding the result into its EMA-smoothed rate, so a single slow or fast
+// chunk doesn't swing the displayed rate around.
+const rateWindowSize = 5
+
+// byteProgressRenderInterval caps how often ByteProgress redraws, so a
+// tight io.Copy loop calling Add on every chunk doesn't flicker the
+// terminal.
+const byteProgressRenderInterval = 100 * time.Millisecond
+
+// ByteProgress is a progress bar for byte-oriented transfers (downloads,
+// file installs): it renders human-readable byte counts, a rolling
+// transfer rate, and an ETA, e.g.
+// "[████░░] 42% 4.2/10.0 MiB 1.3 MiB/s ETA 4s".
+type ByteProgress struct {
+	total   int64
+	message string
+	width   int
+
+	mu          sync.Mutex
+	current     int64
+	lastRender  time.Time
+	lastSample  time.Time
+	lastSampleN int64
+	samples     []float64
+	sampleIdx   int
+	rate        float64 // bytes/sec, EMA-smoothed
+}
+
+// NewByteProgress creates a new byte-count progress bar for total bytes.
+func NewByteProgress(total int64, message string) *ByteProgress {
+	return &ByteProgress{
+		total:   total,
+		message: message,
+		width:   50,
+	}
+}
+
+// Add advances the bar by n bytes and re-renders, throttled to at most once
+// per byteProgressRenderInterval regardless of call frequency.
+func (p *ByteProgress) Add(n int64) {
+	p.mu.Lock()
+	p.current += n
+	p.sampleRateLocked()
+	shouldRender := p.current >= p.total || time.Since(p.lastRender) >= byteProgressRenderInterval
+	if shouldRender {
+		p.lastRender = time.Now()
+	}
+	p.mu.Unlock()
+
+	if shouldRender {
+		p.render()
+	}
+}
+
+// Complete marks the bar as finished, rendering a final 100% frame.
+func (p *ByteProgress) Complete(message string) {
+	p.mu.Lock()
+	p.current = p.total
+	if message != "" {
+		p.message = message
+	}
+	p.mu.Unlock()
+
+	p.render()
+	fmt.Println()
+	clearNativeProgress()
+}
+
+// sampleRateLocked folds the bytes transferred since the last sample into a
+// rolling window of rateWindowSize instantaneous rates, then EMA-smooths
+// their average into p.rate. Callers must hold p.mu.
+func (p *ByteProgress) sampleRateLocked() {
+	now := time.Now()
+	if p.lastSample.IsZero() {
+		p.lastSample = now
+		p.lastSampleN = p.current
+		return
+	}
+
+	elapsed := now.Sub(p.lastSample).Seconds()
+	if elapsed < 0.05 { // avoid noisy samples from back-to-back calls
+		return
+	}
+
+	instantaneous := float64(p.current-p.lastSampleN) / elapsed
+	p.lastSample = now
+	p.lastSampleN = p.current
+
+	if len(p.samples) < rateWindowSize {
+		p.samples = append(p.samples, instantaneous)
+	} else {
+		p.samples[p.sampleIdx%rateWindowSize] = instantaneous
+	}
+	p.sampleIdx++
+
+	var sum float64
+	for _, s := range p.samples {
+		sum += s
+	}
+	windowed := sum / float64(len(p.samples))
+
+	const emaAlpha = 0.3
+	if p.rate == 0 {
+		p.rate = windowed
+	} else {
+		p.rate = emaAlpha*windowed + (1-emaAlpha)*p.rate
+	}
+}
+
+func (p *ByteProgress) render() {
+	p.mu.Lock()
+	current, total, rate := p.current, p.total, p.rate
+	message, width := p.message, p.width
+	p.mu.Unlock()
+
+	var percentage float64
+	if total > 0 {
+		percentage = float64(current) / float64(total)
+	}
+	filled := int(percentage * float64(width))
+
+	bar := ""
+	for i := 0; i < width; i++ {
+		if i < filled {
+			bar += "█"
+		} else {
+			bar += "░"
+		}
+	}
+
+	eta := "?"
+	if rate > 0 && total > current {
+		remaining := time.Duration(float64(total-current) / rate * float64(time.Second))
+		eta = remaining.Round(time.Second).String()
+	}
+
+	fmt.Printf("\r[%s] %d%% %s %s ETA %s %s",
+		Success.Sprint(bar),
+		int(percentage*100),
+		formatBytePair(current, total),
+		formatByteRate(rate),
+		eta,
+		message)
+
+	reportNativeProgress(percentage)
+}
+
+// byteUnit picks the largest binary unit (B/KiB/MiB/GiB) n fits in, along
+// with its divisor, so callers can render n in that unit.
+func byteUnit(n int64) (string, float64) {
+	const (
+		kib = 1024
+		mib = kib * 1024
+		gib = mib * 1024
+	)
+	switch {
+	case n >= gib:
+		return "GiB", gib
+	case n >= mib:
+		return "MiB", mib
+	case n >= kib:
+		return "KiB", kib
+	default:
+		return "B", 1
+	}
+}
+
+// formatBytePair renders current/total sharing a single unit sized to
+// total, e.g. "4.2/10.0 MiB".
+func formatBytePair(current, total int64) string {
+	unit, div := byteUnit(total)
+	return fmt.Sprintf("%.1f/%.1f %s", float64(current)/div, float64(total)/div, unit)
+}
+
+// formatByteRate renders a bytes/sec rate, e.g. "1.3 MiB/s".
+func formatByteRate(bytesPerSec float64) string {
+	unit, div := byteUnit(int64(bytesPerSec))
+	return fmt.Sprintf("%.1f %s/s", bytesPerSec/div, unit)
+}
+
+// WrapReader returns an io.Reader wrapping r that calls Add for every byte
+// read, so a ByteProgress can be plugged straight into io.Copy for an HTTP
+// download or file install.
+func (p *ByteProgress) WrapReader(r io.Reader) io.Reader {
+	return &byteProgressReader{r: r, progress: p}
+}
+
+type byteProgressReader struct {
+	r        io.Reader
+	progress *ByteProgress
+}
+
+func (w *byteProgressReader) Read(b []byte) (int, error) {
+	n, err := w.r.Read(b)
+	if n > 0 {
+		w.progress.Add(int64(n))
+	}
+	return n, err
+}
+
+// WrapWriter returns an io.Writer wrapping w that calls Add for every byte
+// written, for progress on an upload or file write.
+func (p *ByteProgress) WrapWriter(w io.Writer) io.Writer {
+	return &byteProgressWriter{w: w, progress: p}
+}
+
+type byteProgressWriter struct {
+	w        io.Writer
+	progress *ByteProgress
+}
+
+func (w *byteProgressWriter) Write(b []byte) (int, error) {
+	n, err := w.w.Write(b)
+	if n > 0 {
+		w.progress.Add(int64(n))
+	}
+	return n, err
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFormatBytePairSharesAUnitSizedToTotal(t *testing.T) {
+	got := formatBytePair(4_404_019, 10_485_760) // ~4.2/10.0 MiB
+	if !strings.Contains(got, "MiB") {
+		t.Errorf("expected MiB unit, got %q", got)
+	}
+	if !strings.HasPrefix(got, "4.2/10.0") {
+		t.Errorf("expected \"4.2/10.0 MiB\", got %q", got)
+	}
+}
+
+func TestFormatByteRate(t *testing.T) {
+	got := formatByteRate(1_363_148.8) // ~1.3 MiB/s
+	if got != "1.3 MiB/s" {
+		t.Errorf("expected \"1.3 MiB/s\", got %q", got)
+	}
+}
+
+func TestByteUnitPicksLargestFittingUnit(t *testing.T) {
+	cases := []struct {
+		n    int64
+		unit string
+	}{
+		{500, "B"},
+		{2048, "KiB"},
+		{5 * 1024 * 1024, "MiB"},
+		{2 * 1024 * 1024 * 1024, "GiB"},
+	}
+	for _, c := range cases {
+		unit, _ := byteUnit(c.n)
+		if unit != c.unit {
+			t.Errorf("byteUnit(%d) unit = %q, want %q", c.n, unit, c.unit)
+		}
+	}
+}
+
+func TestByteProgressWrapReaderTracksBytesRead(t *testing.T) {
+	p := NewByteProgress(10, "downloading")
+	src := bytes.NewReader(make([]byte, 10))
+
+	n, err := io.Copy(io.Discard, p.WrapReader(src))
+	if err != nil {
+		t.Fatalf("io.Copy() error = %v", err)
+	}
+	if n != 10 {
+		t.Fatalf("expected to copy 10 bytes, got %d", n)
+	}
+
+	p.mu.Lock()
+	current := p.current
+	p.mu.Unlock()
+	if current != 10 {
+		t.Errorf("expected WrapReader to advance the bar by bytes read, current = %d", current)
+	}
+}
+
+func TestByteProgressWrapWriterTracksBytesWritten(t *testing.T) {
+	p := NewByteProgress(5, "uploading")
+	var buf bytes.Buffer
+
+	n, err := p.WrapWriter(&buf).Write([]byte("hello"))
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if n != 5 {
+		t.Fatalf("expected to write 5 bytes, got %d", n)
+	}
+
+	p.mu.Lock()
+	current := p.current
+	p.mu.Unlock()
+	if current != 5 {
+		t.Errorf("expected WrapWriter to advance the bar by bytes written, current = %d", current)
+	}
+}
@@ -0,0 +1,364 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Node is one entry in a MultiRenderer tree-table, e.g. a platform being
+// configured with its install/init steps as Children. Started is used to
+// compute the Elapsed column; leave it zero for a node with no running
+// timer of its own (a grouping node, say).
+type Node struct {
+	Name     string
+	Status   string
+	Info     string
+	Started  time.Time
+	Children []*Node
+}
+
+// Row is one concurrently-updatable line in a MultiRenderer's viewport.
+// Every method is safe to call from any goroutine.
+type Row struct {
+	mu      sync.Mutex
+	text    string
+	spinner []string
+	frame   int
+	current int
+	total   int
+	done    bool
+	status  string
+}
+
+// Update sets the row's message text.
+func (r *Row) Update(text string) {
+	r.mu.Lock()
+	r.text = text
+	r.mu.Unlock()
+}
+
+// SetSpinner animates the row's prefix through frames, one frame per render
+// tick, until Done is called. Pass nil to stop animating.
+func (r *Row) SetSpinner(frames []string) {
+	r.mu.Lock()
+	r.spinner = frames
+	r.mu.Unlock()
+}
+
+// SetProgress appends a "(current/total)" suffix to the row's text. Pass
+// total <= 0 to hide the suffix.
+func (r *Row) SetProgress(current, total int) {
+	r.mu.Lock()
+	r.current, r.total = current, total
+	r.mu.Unlock()
+}
+
+// Done freezes the row on status (e.g. "✓" or "✗") instead of an animated
+// spinner frame, and stops it advancing.
+func (r *Row) Done(status string) {
+	r.mu.Lock()
+	r.done = true
+	r.status = status
+	r.mu.Unlock()
+}
+
+// line renders the row's current content as a single line, advancing the
+// spinner's frame counter as a side effect (one frame per call).
+func (r *Row) line() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prefix := " "
+	switch {
+	case r.done:
+		prefix = r.status
+		if prefix == "" {
+			prefix = Success.Sprint("✓")
+		}
+	case len(r.spinner) > 0:
+		prefix = Primary.Sprint(r.spinner[r.frame%len(r.spinner)])
+		r.frame++
+	}
+
+	text := r.text
+	if r.total > 0 {
+		text = fmt.Sprintf("%s (%d/%d)", text, r.current, r.total)
+	}
+	return fmt.Sprintf("%s %s", prefix, text)
+}
+
+// MultiRenderer drives many concurrent Rows (and optionally one Tree) in a
+// single terminal viewport. It owns a background goroutine that redraws the
+// viewport on a fixed interval, diffing against the previous frame so only
+// lines whose content actually changed get rewritten - runs of unchanged
+// lines are skipped over with a single cursor move rather than reprinted.
+//
+// On a non-TTY out (piped output, a CI log, a file), in-place redraw
+// escapes would corrupt the output, so MultiRenderer instead falls back to
+// plain sequential prints: each line is written once, the next time its
+// content changes, like an ordinary log.
+type MultiRenderer struct {
+	out      io.Writer
+	isTTY    bool
+	interval time.Duration
+
+	mu    sync.Mutex
+	order []string
+	rows  map[string]*Row
+	tree  *Node
+
+	drawMu    sync.Mutex
+	prevLines []string
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewMultiRenderer returns a MultiRenderer writing to out and immediately
+// starts its background render loop. Call Stop when done so the final
+// frame is flushed and the cursor is left below it.
+func NewMultiRenderer(out io.Writer) *MultiRenderer {
+	r := &MultiRenderer{
+		out:      out,
+		isTTY:    isTTYWriter(out),
+		interval: 100 * time.Millisecond,
+		rows:     make(map[string]*Row),
+		stop:     make(chan struct{}),
+	}
+
+	r.wg.Add(1)
+	go r.loop()
+	setActivePrinter(r)
+	return r
+}
+
+// isTTYWriter reports whether w is an interactive terminal MultiRenderer
+// can safely redraw in place, extending CheckIfTerminalSupportsColor's
+// env-var heuristic with an actual isatty check via golang.org/x/term.
+func isTTYWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// Row returns the Row for id, creating and appending it on first use. Safe
+// to call from any goroutine; repeated calls with the same id return the
+// same Row.
+func (r *MultiRenderer) Row(id string) *Row {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if row, ok := r.rows[id]; ok {
+		return row
+	}
+	row := &Row{}
+	r.rows[id] = row
+	r.order = append(r.order, id)
+	return row
+}
+
+// Tree replaces the tree-table rendered below any Rows with root. Pass nil
+// to stop rendering a tree.
+func (r *MultiRenderer) Tree(root *Node) {
+	r.mu.Lock()
+	r.tree = root
+	r.mu.Unlock()
+}
+
+// Stop renders one final frame and ends the background loop, leaving the
+// cursor on a fresh line below the last frame drawn.
+func (r *MultiRenderer) Stop() {
+	close(r.stop)
+	r.wg.Wait()
+	clearActivePrinter(r)
+}
+
+func (r *MultiRenderer) loop() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			r.render()
+			if r.isTTY {
+				fmt.Fprintln(r.out)
+			}
+			return
+		case <-ticker.C:
+			r.render()
+		}
+	}
+}
+
+func (r *MultiRenderer) render() {
+	r.mu.Lock()
+	lines := make([]string, 0, len(r.order))
+	for _, id := range r.order {
+		lines = append(lines, r.rows[id].line())
+	}
+	if r.tree != nil {
+		lines = append(lines, renderTreeTable(r.tree, terminalWidth(r.out))...)
+	}
+	r.mu.Unlock()
+
+	r.drawMu.Lock()
+	defer r.drawMu.Unlock()
+	if r.isTTY {
+		r.renderTTY(lines)
+	} else {
+		r.renderPlain(lines)
+	}
+	r.prevLines = lines
+}
+
+// linesOccupied implements printManager.
+func (r *MultiRenderer) linesOccupied() int {
+	r.drawMu.Lock()
+	defer r.drawMu.Unlock()
+	return len(r.prevLines)
+}
+
+// invalidate implements printManager: a Println call already overwrote
+// r's on-screen frame, so the next render must repaint every line instead
+// of diffing against prevLines, which no longer describes what's on screen.
+func (r *MultiRenderer) invalidate() {
+	r.drawMu.Lock()
+	r.prevLines = nil
+	r.drawMu.Unlock()
+}
+
+// redraw implements printManager: it forces an immediate re-render, used
+// by Printf/Println right after writing message lines to scrollback.
+func (r *MultiRenderer) redraw() {
+	r.render()
+}
+
+// renderTTY redraws lines in place via diffRedraw.
+func (r *MultiRenderer) renderTTY(lines []string) {
+	diffRedraw(r.out, r.prevLines, lines, terminalWidth(r.out))
+}
+
+// diffRedraw moves out's cursor back to the top of prevLines, then walks
+// prevLines and lines together, skipping runs of unchanged lines with a
+// single "\033[<n>B" instead of rewriting them, and clearing ("\033[K") +
+// rewriting only the lines that actually changed. Shared by MultiRenderer
+// and Viewport so both redraw their (differently-sized) frames the same
+// way.
+func diffRedraw(out io.Writer, prevLines, lines []string, width int) {
+	if len(prevLines) > 0 {
+		fmt.Fprintf(out, "\033[%dA", len(prevLines))
+	}
+
+	maxLen := len(lines)
+	if len(prevLines) > maxLen {
+		maxLen = len(prevLines)
+	}
+
+	for i := 0; i < maxLen; {
+		if i < len(lines) && i < len(prevLines) && lines[i] == prevLines[i] {
+			j := i
+			for j < maxLen && j < len(lines) && j < len(prevLines) && lines[j] == prevLines[j] {
+				j++
+			}
+			fmt.Fprintf(out, "\033[%dB", j-i)
+			i = j
+			continue
+		}
+
+		fmt.Fprint(out, "\r\033[K")
+		if i < len(lines) {
+			fmt.Fprint(out, truncateToWidth(lines[i], width))
+		}
+		fmt.Fprint(out, "\r\n")
+		i++
+	}
+}
+
+// renderPlain prints only the lines that changed since the last tick, each
+// on its own new output line - there's no cursor to redraw in place, so
+// this behaves like an ordinary sequence of log lines instead.
+func (r *MultiRenderer) renderPlain(lines []string) {
+	for i, line := range lines {
+		if i < len(r.prevLines) && r.prevLines[i] == line {
+			continue
+		}
+		fmt.Fprintln(r.out, line)
+	}
+}
+
+// terminalWidth returns out's current column width via term.GetSize,
+// falling back to 80 columns for a non-*os.File writer or a query error.
+func terminalWidth(out io.Writer) int {
+	const defaultWidth = 80
+
+	f, ok := out.(*os.File)
+	if !ok {
+		return defaultWidth
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil || width <= 0 {
+		return defaultWidth
+	}
+	return width
+}
+
+// truncateToWidth trims line to at most width runes, marking truncation
+// with a trailing ellipsis. It counts runes rather than rendered terminal
+// cells, so a line carrying ANSI color codes may truncate a little early;
+// that's an acceptable tradeoff for not pulling in a cell-width library.
+func truncateToWidth(line string, width int) string {
+	runes := []rune(line)
+	if len(runes) <= width || width <= 1 {
+		return line
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// Tree-table column widths for renderTreeTable. Name includes indentation
+// per depth, so deeply nested nodes lose padding before losing information.
+const (
+	treeNameWidth    = 28
+	treeStatusWidth  = 10
+	treeElapsedWidth = 8
+)
+
+// renderTreeTable lays out root and its Children as a Name/Status/Elapsed/
+// Info table, one line per node plus a header, each truncated to width.
+func renderTreeTable(root *Node, width int) []string {
+	header := fmt.Sprintf("  %-*s %-*s %-*s %s",
+		treeNameWidth, "NAME", treeStatusWidth, "STATUS", treeElapsedWidth, "ELAPSED", "INFO")
+	lines := []string{truncateToWidth(Dim.Sprint(header), width)}
+
+	var walk func(n *Node, depth int)
+	walk = func(n *Node, depth int) {
+		name := strings.Repeat("  ", depth) + n.Name
+		elapsed := ""
+		if !n.Started.IsZero() {
+			elapsed = time.Since(n.Started).Round(time.Second).String()
+		}
+		line := fmt.Sprintf("  %-*s %-*s %-*s %s",
+			treeNameWidth, truncateToWidth(name, treeNameWidth),
+			treeStatusWidth, n.Status,
+			treeElapsedWidth, elapsed,
+			n.Info)
+		lines = append(lines, truncateToWidth(line, width))
+
+		for _, child := range n.Children {
+			walk(child, depth+1)
+		}
+	}
+	walk(root, 0)
+
+	return lines
+}
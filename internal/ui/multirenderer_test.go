@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMultiRendererIsNonTTYForABuffer(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewMultiRenderer(&buf)
+	defer r.Stop()
+
+	if r.isTTY {
+		t.Error("expected a *bytes.Buffer to be treated as a non-TTY writer")
+	}
+}
+
+func TestMultiRendererRowUpdatesAppearAfterStop(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewMultiRenderer(&buf)
+
+	row := r.Row("install")
+	row.Update("Installing dependencies")
+	row.SetProgress(1, 3)
+	row.Done("✓")
+
+	r.Stop()
+
+	output := buf.String()
+	if !strings.Contains(output, "Installing dependencies") {
+		t.Errorf("expected rendered output to contain the row's text, got: %q", output)
+	}
+}
+
+func TestMultiRendererRowReturnsSameInstanceForSameID(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewMultiRenderer(&buf)
+	defer r.Stop()
+
+	first := r.Row("android")
+	second := r.Row("android")
+	if first != second {
+		t.Error("expected repeated Row() calls with the same id to return the same *Row")
+	}
+}
+
+func TestRenderTreeTableIncludesNodeNamesIndentedByDepth(t *testing.T) {
+	root := &Node{
+		Name:   "configure",
+		Status: "running",
+		Children: []*Node{
+			{Name: "register app", Status: "done", Started: time.Now().Add(-2 * time.Second)},
+		},
+	}
+
+	lines := renderTreeTable(root, 80)
+	if len(lines) != 3 { // header + root + one child
+		t.Fatalf("expected 3 lines (header, root, child), got %d: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[1], "configure") {
+		t.Errorf("expected root line to contain its name, got: %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "register app") {
+		t.Errorf("expected child line to contain its name, got: %q", lines[2])
+	}
+}
+
+func TestTruncateToWidth(t *testing.T) {
+	if got := truncateToWidth("short", 80); got != "short" {
+		t.Errorf("expected a short line to pass through unchanged, got %q", got)
+	}
+
+	long := strings.Repeat("x", 100)
+	got := truncateToWidth(long, 10)
+	if len([]rune(got)) != 10 {
+		t.Errorf("expected truncation to %d runes, got %d: %q", 10, len([]rune(got)), got)
+	}
+	if !strings.HasSuffix(got, "…") {
+		t.Errorf("expected a truncated line to end with an ellipsis, got %q", got)
+	}
+}
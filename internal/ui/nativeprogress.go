@@ -0,0 +1,38 @@
+package ui
+
+// nativeProgressEnabled gates whether Progress/ByteProgress additionally
+// drive an OS-level progress indicator (macOS Dock tile, Windows taskbar,
+// a terminal OSC 9;4 fallback on Linux), on top of their normal terminal
+// rendering. Off by default so nativefire doesn't surprise users with
+// Dock/taskbar chrome unless they opt in.
+var nativeProgressEnabled bool
+
+// EnableNativeProgress turns on OS-level progress reporting for every
+// subsequent Progress/ByteProgress update. The OS-specific driver is
+// selected at compile time (see nativeprogress_*.go); on a platform with no
+// driver it's a harmless no-op.
+func EnableNativeProgress() {
+	nativeProgressEnabled = true
+}
+
+// DisableNativeProgress turns EnableNativeProgress back off and clears any
+// OS-level progress indicator currently shown.
+func DisableNativeProgress() {
+	nativeProgressEnabled = false
+	clearNativeProgress()
+}
+
+// reportNativeProgress drives the OS-level progress indicator if
+// EnableNativeProgress has been called. fraction is clamped to [0, 1].
+func reportNativeProgress(fraction float64) {
+	if !nativeProgressEnabled {
+		return
+	}
+	if fraction < 0 {
+		fraction = 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	setNativeProgress(fraction)
+}
@@ -0,0 +1,27 @@
+//go:build darwin
+
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// setNativeProgress drives the Dock tile's progress indicator via
+// osascript's JavaScript-for-Automation "Progress" object, avoiding a cgo
+// dependency on NSDockTile. Best-effort: osascript failures (no Dock, a
+// headless session) are ignored, matching how the rest of nativefire's
+// optional UI chrome degrades quietly rather than erroring.
+func setNativeProgress(fraction float64) {
+	script := fmt.Sprintf(
+		"Progress.totalUnitCount = 100; Progress.completedUnitCount = %d;",
+		int(fraction*100),
+	)
+	_ = exec.Command("osascript", "-l", "JavaScript", "-e", script).Run()
+}
+
+// clearNativeProgress removes the Dock tile's progress indicator.
+func clearNativeProgress() {
+	_ = exec.Command("osascript", "-l", "JavaScript", "-e",
+		"Progress.completedUnitCount = Progress.totalUnitCount;").Run()
+}
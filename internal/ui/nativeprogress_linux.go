@@ -0,0 +1,25 @@
+//go:build linux
+
+package ui
+
+import "fmt"
+
+// OSC 9;4 progress states, recognized by ConEmu, WezTerm, and Windows
+// Terminal. This is a portable terminal-escape fallback rather than a full
+// org.freedesktop.Notifications D-Bus client, so Linux support doesn't
+// need a new D-Bus dependency; terminals that don't recognize the escape
+// simply ignore it.
+const (
+	osc94StateRemove  = 0
+	osc94StateDefault = 1
+)
+
+// setNativeProgress emits an OSC 9;4 progress-state escape sequence.
+func setNativeProgress(fraction float64) {
+	fmt.Printf("\033]9;4;%d;%d\033\\", osc94StateDefault, int(fraction*100))
+}
+
+// clearNativeProgress emits an OSC 9;4 "remove" escape sequence.
+func clearNativeProgress() {
+	fmt.Printf("\033]9;4;%d;0\033\\", osc94StateRemove)
+}
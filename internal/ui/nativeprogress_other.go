@@ -0,0 +1,10 @@
+//go:build !darwin && !windows && !linux
+
+package ui
+
+// setNativeProgress is a no-op on platforms with no native progress driver
+// (e.g. the BSDs), so EnableNativeProgress is still safe to call there.
+func setNativeProgress(fraction float64) {}
+
+// clearNativeProgress is a no-op to match setNativeProgress.
+func clearNativeProgress() {}
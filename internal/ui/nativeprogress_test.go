@@ -0,0 +1,23 @@
+package ui
+
+import "testing"
+
+func TestReportNativeProgressNoopsWhenDisabled(t *testing.T) {
+	DisableNativeProgress()
+	// Should not panic even though no driver has been exercised yet.
+	reportNativeProgress(0.5)
+}
+
+func TestEnableAndDisableNativeProgressToggleTheGate(t *testing.T) {
+	defer DisableNativeProgress()
+
+	EnableNativeProgress()
+	if !nativeProgressEnabled {
+		t.Error("expected EnableNativeProgress to set the gate")
+	}
+
+	DisableNativeProgress()
+	if nativeProgressEnabled {
+		t.Error("expected DisableNativeProgress to clear the gate")
+	}
+}
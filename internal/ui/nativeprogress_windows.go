@@ -0,0 +1,131 @@
+//go:build windows
+
+package ui
+
+import (
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// CLSID_TaskbarList / IID_ITaskbarList3, from the Windows SDK's shobjidl.h.
+var (
+	clsidTaskbarList = windows.GUID{
+		Data1: 0x56FDF344, Data2: 0xFD6D, Data3: 0x11D0,
+		Data4: [8]byte{0x95, 0x8A, 0x00, 0x60, 0x97, 0xC9, 0xA0, 0x90},
+	}
+	iidITaskbarList3 = windows.GUID{
+		Data1: 0xEA1AFB91, Data2: 0x9E28, Data3: 0x4B86,
+		Data4: [8]byte{0x90, 0xE9, 0x9E, 0x9F, 0x8A, 0x5E, 0xEF, 0xAF},
+	}
+)
+
+// taskbarList3Vtbl mirrors ITaskbarList3's vtable: IUnknown, then
+// ITaskbarList (HrInit..SetActiveAlt), ITaskbarList2 (MarkFullscreenWindow),
+// then the two ITaskbarList3 methods this file calls.
+type taskbarList3Vtbl struct {
+	QueryInterface       uintptr
+	AddRef               uintptr
+	Release              uintptr
+	HrInit               uintptr
+	AddTab               uintptr
+	DeleteTab            uintptr
+	ActivateTab          uintptr
+	SetActiveAlt         uintptr
+	MarkFullscreenWindow uintptr
+	SetProgressValue     uintptr
+	SetProgressState     uintptr
+}
+
+type iTaskbarList3 struct {
+	vtbl *taskbarList3Vtbl
+}
+
+const (
+	tbpfNoProgress = 0x0
+	tbpfNormal     = 0x2
+)
+
+const clsctxInprocServer = 0x1
+
+var (
+	ole32                = windows.NewLazySystemDLL("ole32.dll")
+	procCoCreateInstance = ole32.NewProc("CoCreateInstance")
+
+	taskbarMu    sync.Mutex
+	taskbar      *iTaskbarList3
+	taskbarHwnd  windows.HWND
+	taskbarTried bool
+)
+
+// ensureTaskbarLocked lazily creates the ITaskbarList3 COM object and finds
+// the console window to report progress on. Callers must hold taskbarMu.
+// Returns nil if any step fails (no console window, COM unavailable) - the
+// caller then no-ops rather than errors, matching how the rest of this
+// optional feature degrades quietly.
+func ensureTaskbarLocked() *iTaskbarList3 {
+	if taskbarTried {
+		return taskbar
+	}
+	taskbarTried = true
+
+	_ = windows.CoInitializeEx(0, windows.COINIT_APARTMENTTHREADED)
+
+	var instance unsafe.Pointer
+	r, _, _ := procCoCreateInstance.Call(
+		uintptr(unsafe.Pointer(&clsidTaskbarList)),
+		0,
+		uintptr(clsctxInprocServer),
+		uintptr(unsafe.Pointer(&iidITaskbarList3)),
+		uintptr(unsafe.Pointer(&instance)),
+	)
+	if r != 0 || instance == nil {
+		return nil
+	}
+
+	hwnd := windows.GetConsoleWindow()
+	if hwnd == 0 {
+		return nil
+	}
+
+	taskbar = (*iTaskbarList3)(instance)
+	taskbarHwnd = hwnd
+	return taskbar
+}
+
+func (t *iTaskbarList3) setProgressValue(hwnd windows.HWND, completed, total uint64) {
+	_, _, _ = syscall.SyscallN(t.vtbl.SetProgressValue,
+		uintptr(unsafe.Pointer(t)), uintptr(hwnd), uintptr(completed), uintptr(total))
+}
+
+func (t *iTaskbarList3) setProgressState(hwnd windows.HWND, state uintptr) {
+	_, _, _ = syscall.SyscallN(t.vtbl.SetProgressState,
+		uintptr(unsafe.Pointer(t)), uintptr(hwnd), state)
+}
+
+// setNativeProgress drives the taskbar button's progress overlay via
+// ITaskbarList3.SetProgressValue.
+func setNativeProgress(fraction float64) {
+	taskbarMu.Lock()
+	defer taskbarMu.Unlock()
+
+	t := ensureTaskbarLocked()
+	if t == nil {
+		return
+	}
+	t.setProgressState(taskbarHwnd, tbpfNormal)
+	t.setProgressValue(taskbarHwnd, uint64(fraction*100), 100)
+}
+
+// clearNativeProgress removes the taskbar button's progress overlay.
+func clearNativeProgress() {
+	taskbarMu.Lock()
+	defer taskbarMu.Unlock()
+
+	if taskbar == nil {
+		return
+	}
+	taskbar.setProgressState(taskbarHwnd, tbpfNoProgress)
+}
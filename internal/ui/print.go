@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// printManager is implemented by renderers that own lines at the bottom of
+// the terminal (Spinner, Progress, MultiRenderer), so Printf/Println can
+// interleave arbitrary output above them without corrupting their
+// animation, following the pattern from Bubble Tea's "print above the
+// program" support.
+type printManager interface {
+	// linesOccupied returns how many terminal lines this renderer currently
+	// owns, so Printf/Println knows how far to move the cursor up before
+	// clearing and printing.
+	linesOccupied() int
+	// invalidate tells the renderer its on-screen frame was just
+	// overwritten by a Printf/Println call, so its next redraw must repaint
+	// everything rather than diffing against a frame that's no longer on
+	// screen.
+	invalidate()
+	// redraw reprints the renderer's current frame below the cursor.
+	redraw()
+}
+
+// activePrinter is the renderer currently occupying the bottom of the
+// terminal, if any. Only one is tracked at a time, matching the existing
+// assumption that Spinner/Progress/MultiRenderer each own the whole
+// terminal tail while running - starting two at once already corrupts
+// output today, so Printf/Println doesn't need to handle that case either.
+var (
+	activePrinterMu sync.Mutex
+	activePrinter   printManager
+)
+
+func setActivePrinter(p printManager) {
+	activePrinterMu.Lock()
+	activePrinter = p
+	activePrinterMu.Unlock()
+}
+
+func clearActivePrinter(p printManager) {
+	activePrinterMu.Lock()
+	if activePrinter == p {
+		activePrinter = nil
+	}
+	activePrinterMu.Unlock()
+}
+
+// Printf formats and prints a message above any currently-active renderer's
+// managed lines (a Spinner, Progress, or MultiRenderer) without corrupting
+// its animation, then lets the renderer redraw itself below. With no active
+// renderer, it behaves like fmt.Printf to stdout.
+func Printf(format string, args ...any) {
+	Println(fmt.Sprintf(format, args...))
+}
+
+// Println prints msg above any currently-active renderer's managed lines,
+// then lets the renderer redraw itself below. See Printf.
+func Println(msg string) {
+	activePrinterMu.Lock()
+	p := activePrinter
+	activePrinterMu.Unlock()
+
+	if p == nil || !isTTYWriter(os.Stdout) {
+		fmt.Println(msg)
+		return
+	}
+
+	if n := p.linesOccupied(); n > 0 {
+		// Move to the first managed line, then clear from the cursor to the
+		// end of the screen so the message doesn't leave stale fragments of
+		// the renderer's frame behind it.
+		fmt.Printf("\033[%dA\033[J", n)
+	}
+	fmt.Println(msg)
+	p.invalidate()
+	p.redraw()
+}
+
+// Logger is an io.Writer that routes each write through Println, so
+// log.New(ui.Logger{}, "", 0) or a slog handler can emit output through an
+// active Spinner/Progress/MultiRenderer without corrupting it.
+type Logger struct{}
+
+func (Logger) Write(b []byte) (int, error) {
+	Println(strings.TrimRight(string(b), "\n"))
+	return len(b), nil
+}
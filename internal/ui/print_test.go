@@ -0,0 +1,50 @@
+package ui
+
+import "testing"
+
+type fakePrintManager struct {
+	lines       int
+	invalidated bool
+	redrawn     bool
+}
+
+func (f *fakePrintManager) linesOccupied() int { return f.lines }
+func (f *fakePrintManager) invalidate()        { f.invalidated = true }
+func (f *fakePrintManager) redraw()            { f.redrawn = true }
+
+func TestPrintlnWithNoActiveRendererDoesNotPanic(t *testing.T) {
+	clearActivePrinter(activePrinter)
+	Println("hello")
+}
+
+func TestPrintlnWithActiveRendererOffTTYDoesNotPanic(t *testing.T) {
+	fake := &fakePrintManager{lines: 2}
+	setActivePrinter(fake)
+	defer clearActivePrinter(fake)
+
+	// isTTYWriter(os.Stdout) is false in the test harness (stdout is
+	// redirected), so Println takes its plain fmt.Println fallback and
+	// never touches fake - this only exercises that active-printer
+	// bookkeeping itself doesn't panic under that path.
+	Println("hello")
+}
+
+func TestSetAndClearActivePrinter(t *testing.T) {
+	fake := &fakePrintManager{}
+	setActivePrinter(fake)
+
+	activePrinterMu.Lock()
+	current := activePrinter
+	activePrinterMu.Unlock()
+	if current != printManager(fake) {
+		t.Fatal("expected setActivePrinter to register the renderer")
+	}
+
+	clearActivePrinter(fake)
+	activePrinterMu.Lock()
+	current = activePrinter
+	activePrinterMu.Unlock()
+	if current != nil {
+		t.Fatal("expected clearActivePrinter to unregister the renderer")
+	}
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Mode selects how a Renderer displays progress. ModeInteractive animates
+// spinners in place and is the default on a TTY; ModeText prints one
+// durable line per state transition with a timestamp, safe for CI logs;
+// ModeJSON does the same but as newline-delimited JSON events for machine
+// consumption.
+type Mode string
+
+const (
+	ModeInteractive Mode = "interactive"
+	ModeText        Mode = "text"
+	ModeJSON        Mode = "json"
+)
+
+// Options configures NewRenderer.
+type Options struct {
+	// Mode overrides auto-detection. Leave zero to fall back to
+	// DetectMode: the NATIVEFIRE_PROGRESS env var, then interactive if
+	// stdout is a TTY, else text.
+	Mode Mode
+	// Out is where Text/JSON mode write; defaults to os.Stdout. Interactive
+	// mode always writes to os.Stdout directly, matching Spinner/Progress.
+	Out io.Writer
+}
+
+// DetectMode picks a Mode when Options.Mode is left unset: the
+// NATIVEFIRE_PROGRESS env var wins, then interactive if stdout is a TTY,
+// else text.
+func DetectMode() Mode {
+	if env := os.Getenv("NATIVEFIRE_PROGRESS"); env != "" {
+		return Mode(env)
+	}
+	if isTTYWriter(os.Stdout) {
+		return ModeInteractive
+	}
+	return ModeText
+}
+
+// Renderer reports start/progress/done/error state transitions for a named
+// task. ShowLoader routes through CurrentRenderer so existing call sites
+// pick up CI-friendly, and optionally machine-readable, output depending on
+// Mode without changing how they're called.
+type Renderer interface {
+	Start(id, message string)
+	Progress(id string, current, total int, message string)
+	Done(id, message string)
+	Error(id string, err error)
+}
+
+// NewRenderer returns a Renderer for opts.Mode, auto-detected via
+// DetectMode if left unset.
+func NewRenderer(opts Options) Renderer {
+	mode := opts.Mode
+	if mode == "" {
+		mode = DetectMode()
+	}
+
+	switch mode {
+	case ModeJSON:
+		return &eventRenderer{out: outOrStdout(opts.Out), json: true}
+	case ModeText:
+		return &eventRenderer{out: outOrStdout(opts.Out)}
+	default:
+		return &interactiveRenderer{}
+	}
+}
+
+func outOrStdout(out io.Writer) io.Writer {
+	if out == nil {
+		return os.Stdout
+	}
+	return out
+}
+
+var (
+	currentRendererMu sync.Mutex
+	currentRenderer   Renderer
+)
+
+// CurrentRenderer returns the process-wide Renderer, auto-detecting Mode on
+// first use. Call SetCurrentRenderer to override it (e.g. from --progress).
+func CurrentRenderer() Renderer {
+	currentRendererMu.Lock()
+	defer currentRendererMu.Unlock()
+	if currentRenderer == nil {
+		currentRenderer = NewRenderer(Options{})
+	}
+	return currentRenderer
+}
+
+// SetCurrentRenderer overrides the process-wide Renderer returned by
+// CurrentRenderer.
+func SetCurrentRenderer(r Renderer) {
+	currentRendererMu.Lock()
+	currentRenderer = r
+	currentRendererMu.Unlock()
+}
+
+// event is the newline-delimited JSON shape emitted by eventRenderer in
+// ModeJSON.
+type event struct {
+	ID      string `json:"id"`
+	Event   string `json:"event"`
+	Current int    `json:"current,omitempty"`
+	Total   int    `json:"total,omitempty"`
+	Msg     string `json:"msg,omitempty"`
+	Time    string `json:"time"`
+}
+
+// eventRenderer implements ModeText and ModeJSON: it never emits ANSI
+// escapes or carriage returns, printing one durable line per state
+// transition instead of animating in place.
+type eventRenderer struct {
+	out  io.Writer
+	json bool
+	mu   sync.Mutex
+}
+
+func (r *eventRenderer) emit(e event) {
+	e.Time = time.Now().UTC().Format(time.RFC3339)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.json {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(r.out, string(data))
+		return
+	}
+
+	line := fmt.Sprintf("[%s] %s: %s", e.Time, e.ID, e.Event)
+	if e.Event == "progress" {
+		line = fmt.Sprintf("%s %d/%d", line, e.Current, e.Total)
+	}
+	if e.Msg != "" {
+		line = fmt.Sprintf("%s - %s", line, e.Msg)
+	}
+	fmt.Fprintln(r.out, line)
+}
+
+func (r *eventRenderer) Start(id, message string) {
+	r.emit(event{ID: id, Event: "start", Msg: message})
+}
+
+func (r *eventRenderer) Progress(id string, current, total int, message string) {
+	r.emit(event{ID: id, Event: "progress", Current: current, Total: total, Msg: message})
+}
+
+func (r *eventRenderer) Done(id, message string) {
+	r.emit(event{ID: id, Event: "done", Msg: message})
+}
+
+func (r *eventRenderer) Error(id string, err error) {
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+	r.emit(event{ID: id, Event: "error", Msg: msg})
+}
+
+// interactiveRenderer implements ModeInteractive by driving one Spinner per
+// id, preserving the existing animated ShowLoader/Spinner behavior.
+type interactiveRenderer struct {
+	mu       sync.Mutex
+	spinners map[string]*Spinner
+}
+
+func (r *interactiveRenderer) Start(id, message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.spinners == nil {
+		r.spinners = make(map[string]*Spinner)
+	}
+	s := NewDefaultSpinner(message)
+	s.Start()
+	r.spinners[id] = s
+}
+
+func (r *interactiveRenderer) Progress(id string, current, total int, message string) {
+	s, ok := r.spinner(id)
+	if !ok {
+		return
+	}
+	if message == "" {
+		message = fmt.Sprintf("(%d/%d)", current, total)
+	} else {
+		message = fmt.Sprintf("%s (%d/%d)", message, current, total)
+	}
+	s.Update(message)
+}
+
+func (r *interactiveRenderer) Done(id, message string) {
+	s, ok := r.takeSpinner(id)
+	if !ok {
+		return
+	}
+	s.Stop()
+	if message != "" {
+		SuccessMsg(message)
+	}
+}
+
+func (r *interactiveRenderer) Error(id string, err error) {
+	s, ok := r.takeSpinner(id)
+	if !ok {
+		return
+	}
+	s.Stop()
+	if err != nil {
+		ErrorMsg(fmt.Sprintf("Failed: %v", err))
+	}
+}
+
+func (r *interactiveRenderer) spinner(id string) (*Spinner, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.spinners[id]
+	return s, ok
+}
+
+func (r *interactiveRenderer) takeSpinner(id string) (*Spinner, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.spinners[id]
+	delete(r.spinners, id)
+	return s, ok
+}
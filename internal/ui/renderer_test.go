@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEventRendererTextModePrintsDurableLines(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRenderer(Options{Mode: ModeText, Out: &buf})
+
+	r.Start("build", "Building")
+	r.Progress("build", 1, 3, "compiling")
+	r.Done("build", "Complete!")
+
+	output := buf.String()
+	if strings.Contains(output, "\033") || strings.Contains(output, "\r") {
+		t.Errorf("expected ModeText to never emit ANSI escapes or carriage returns, got: %q", output)
+	}
+	for _, want := range []string{"start", "1/3", "done"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got: %q", want, output)
+		}
+	}
+}
+
+func TestEventRendererJSONModeEmitsNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRenderer(Options{Mode: ModeJSON, Out: &buf})
+
+	r.Start("build", "Building")
+	r.Error("build", errors.New("boom"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 NDJSON lines, got %d: %q", len(lines), buf.String())
+	}
+
+	var last event
+	if err := json.Unmarshal([]byte(lines[1]), &last); err != nil {
+		t.Fatalf("expected valid JSON, got error: %v (line: %q)", err, lines[1])
+	}
+	if last.Event != "error" || last.Msg != "boom" || last.ID != "build" {
+		t.Errorf("unexpected decoded event: %+v", last)
+	}
+}
+
+func TestDetectModeHonorsProgressEnvVar(t *testing.T) {
+	t.Setenv("NATIVEFIRE_PROGRESS", "json")
+	if got := DetectMode(); got != ModeJSON {
+		t.Errorf("expected DetectMode to honor NATIVEFIRE_PROGRESS=json, got %q", got)
+	}
+}
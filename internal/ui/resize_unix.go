@@ -0,0 +1,18 @@
+//go:build !windows
+
+package ui
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// resizeSignal returns a channel that receives a value whenever the
+// controlling terminal is resized, so Viewport can re-measure immediately
+// instead of waiting for its next poll tick.
+func resizeSignal() <-chan os.Signal {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGWINCH)
+	return ch
+}
@@ -0,0 +1,12 @@
+//go:build windows
+
+package ui
+
+import "os"
+
+// resizeSignal has no SIGWINCH equivalent on Windows consoles, so the
+// returned channel never fires; Viewport falls back to re-measuring the
+// terminal on every poll tick instead.
+func resizeSignal() <-chan os.Signal {
+	return make(chan os.Signal)
+}
@@ -1,10 +1,13 @@
 package ui
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"sync"
 	"time"
+
+	"golang.org/x/term"
 )
 
 // Spinner represents a loading spinner
@@ -13,6 +16,7 @@ type Spinner struct {
 	delay    time.Duration
 	message  string
 	active   bool
+	frameIdx int
 	stopChan chan struct{}
 	mutex    sync.Mutex
 }
@@ -26,16 +30,102 @@ var (
 	SpinnerArrow = []string{"←", "↖", "↑", "↗", "→", "↘", "↓", "↙"}
 )
 
-// NewSpinner creates a new spinner with the given style and message
-func NewSpinner(frames []string, message string) *Spinner {
+// SpinnerStyles is the registry of named spinner frame sets. NewSpinner
+// looks up a style name here; RegisterSpinnerStyle and LoadSpinnersFromFile
+// add to it, e.g. from the community cli-spinners JSON data so any of its
+// 70+ styles can be selected by name without a code change.
+var (
+	spinnerStylesMu sync.RWMutex
+	SpinnerStyles   = map[string][]string{
+		"dots":  SpinnerDots,
+		"line":  SpinnerLine,
+		"fire":  SpinnerFire,
+		"box":   SpinnerBox,
+		"arrow": SpinnerArrow,
+	}
+)
+
+// cliSpinnerSpec mirrors one entry of the cli-spinners JSON schema:
+// {"interval":80,"frames":["⠋", ...]}. Interval is parsed but unused -
+// NativeFire spinners share a single render interval rather than a
+// per-style one.
+type cliSpinnerSpec struct {
+	Interval int      `json:"interval"`
+	Frames   []string `json:"frames"`
+}
+
+// RegisterSpinnerStyle adds or replaces a named style from data in the
+// cli-spinners JSON schema.
+func RegisterSpinnerStyle(name string, data []byte) error {
+	var spec cliSpinnerSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return fmt.Errorf("parse spinner style %q: %w", name, err)
+	}
+	if len(spec.Frames) == 0 {
+		return fmt.Errorf("spinner style %q has no frames", name)
+	}
+
+	spinnerStylesMu.Lock()
+	SpinnerStyles[name] = spec.Frames
+	spinnerStylesMu.Unlock()
+	return nil
+}
+
+// LoadSpinnersFromFile registers every style in a cli-spinners.json file -
+// a map of style name to {"interval":..,"frames":[...]}, the format the
+// community cli-spinners package ships its 70+ styles in.
+func LoadSpinnersFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read spinners file %s: %w", path, err)
+	}
+
+	var specs map[string]cliSpinnerSpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return fmt.Errorf("parse spinners file %s: %w", path, err)
+	}
+
+	spinnerStylesMu.Lock()
+	defer spinnerStylesMu.Unlock()
+	for name, spec := range specs {
+		if len(spec.Frames) == 0 {
+			continue
+		}
+		SpinnerStyles[name] = spec.Frames
+	}
+	return nil
+}
+
+// NewSpinner creates a new spinner with the given style and message. style
+// may be a []string of frames (for backward compatibility) or the name of
+// a style registered in SpinnerStyles; an unrecognized name falls back to
+// SpinnerDots.
+func NewSpinner(style any, message string) *Spinner {
 	return &Spinner{
-		frames:   frames,
+		frames:   resolveSpinnerStyle(style),
 		delay:    100 * time.Millisecond,
 		message:  message,
 		stopChan: make(chan struct{}),
 	}
 }
 
+func resolveSpinnerStyle(style any) []string {
+	switch s := style.(type) {
+	case []string:
+		return s
+	case string:
+		spinnerStylesMu.RLock()
+		frames, ok := SpinnerStyles[s]
+		spinnerStylesMu.RUnlock()
+		if ok {
+			return frames
+		}
+		return SpinnerDots
+	default:
+		return SpinnerDots
+	}
+}
+
 // NewDotsSpinner creates a dots spinner
 func NewDotsSpinner(message string) *Spinner {
 	return NewSpinner(SpinnerDots, message)
@@ -46,6 +136,34 @@ func NewFireSpinner(message string) *Spinner {
 	return NewSpinner(SpinnerFire, message)
 }
 
+// DefaultSpinnerStyleEnvVar selects NewDefaultSpinner's style by name, e.g.
+// NATIVEFIRE_SPINNER=dots12, without any code change.
+const DefaultSpinnerStyleEnvVar = "NATIVEFIRE_SPINNER"
+
+// defaultSpinnerStyleOverride takes precedence over
+// DefaultSpinnerStyleEnvVar when set, e.g. from a "spinner-style" key in
+// .nativefire.yaml via SetDefaultSpinnerStyle.
+var defaultSpinnerStyleOverride string
+
+// SetDefaultSpinnerStyle overrides the style NewDefaultSpinner uses.
+func SetDefaultSpinnerStyle(name string) {
+	defaultSpinnerStyleOverride = name
+}
+
+// NewDefaultSpinner creates a spinner using the configured default style:
+// an explicit SetDefaultSpinnerStyle override, then NATIVEFIRE_SPINNER,
+// then the dots style.
+func NewDefaultSpinner(message string) *Spinner {
+	name := defaultSpinnerStyleOverride
+	if name == "" {
+		name = os.Getenv(DefaultSpinnerStyleEnvVar)
+	}
+	if name == "" {
+		return NewDotsSpinner(message)
+	}
+	return NewSpinner(name, message)
+}
+
 // Start begins the spinner animation
 func (s *Spinner) Start() {
 	s.mutex.Lock()
@@ -54,26 +172,28 @@ func (s *Spinner) Start() {
 		return
 	}
 	s.active = true
+	s.frameIdx = 0
 	s.mutex.Unlock()
 
+	setActivePrinter(s)
+
 	go func() {
 		for {
-			for _, frame := range s.frames {
-				select {
-				case <-s.stopChan:
-					return
-				default:
-					s.mutex.Lock()
-					if !s.active {
-						s.mutex.Unlock()
-						return
-					}
-					// Clear line and print spinner
-					fmt.Print("\r\033[K")
-					fmt.Printf("%s %s", Primary.Sprint(frame), s.message)
+			select {
+			case <-s.stopChan:
+				return
+			default:
+				s.mutex.Lock()
+				if !s.active {
 					s.mutex.Unlock()
-					time.Sleep(s.delay)
+					return
 				}
+				// Clear line and print spinner
+				fmt.Print("\r\033[K")
+				fmt.Printf("%s %s", Primary.Sprint(s.frames[s.frameIdx%len(s.frames)]), s.message)
+				s.frameIdx++
+				s.mutex.Unlock()
+				time.Sleep(s.delay)
 			}
 		}
 	}()
@@ -82,9 +202,8 @@ func (s *Spinner) Start() {
 // Stop ends the spinner animation
 func (s *Spinner) Stop() {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
 	if !s.active {
+		s.mutex.Unlock()
 		return
 	}
 
@@ -93,6 +212,34 @@ func (s *Spinner) Stop() {
 
 	// Clear the spinner line
 	fmt.Print("\r\033[K")
+	s.mutex.Unlock()
+
+	clearActivePrinter(s)
+}
+
+// linesOccupied implements printManager.
+func (s *Spinner) linesOccupied() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.active {
+		return 0
+	}
+	return 1
+}
+
+// invalidate implements printManager. The spinner always clears its line
+// with "\r\033[K" before redrawing, so it needs no extra bookkeeping.
+func (s *Spinner) invalidate() {}
+
+// redraw implements printManager: it reprints the spinner's current frame,
+// used by Printf/Println right after writing message lines to scrollback.
+func (s *Spinner) redraw() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.active {
+		return
+	}
+	fmt.Printf("%s %s", Primary.Sprint(s.frames[s.frameIdx%len(s.frames)]), s.message)
 }
 
 // Update changes the spinner message
@@ -119,12 +266,30 @@ type Progress struct {
 
 // NewProgress creates a new progress bar
 func NewProgress(total int, message string) *Progress {
-	return &Progress{
+	p := &Progress{
 		total:   total,
 		current: 0,
 		width:   50,
 		message: message,
 	}
+	setActivePrinter(p)
+	return p
+}
+
+// linesOccupied implements printManager.
+func (p *Progress) linesOccupied() int {
+	return 1
+}
+
+// invalidate implements printManager. Progress always redraws its single
+// line from a leading "\r", so it needs no extra bookkeeping.
+func (p *Progress) invalidate() {}
+
+// redraw implements printManager: it reprints the progress bar's current
+// state, used by Printf/Println right after writing message lines to
+// scrollback.
+func (p *Progress) redraw() {
+	p.render()
 }
 
 // Update updates the progress bar
@@ -145,6 +310,8 @@ func (p *Progress) Increment(message string) {
 func (p *Progress) Complete(message string) {
 	p.Update(p.total, message)
 	fmt.Println() // New line after completion
+	clearActivePrinter(p)
+	clearNativeProgress()
 }
 
 // render draws the progress bar
@@ -167,6 +334,8 @@ func (p *Progress) render() {
 		p.current,
 		p.total,
 		p.message)
+
+	reportNativeProgress(percentage)
 }
 
 // Typewriter effect for dramatic text display
@@ -208,19 +377,20 @@ func LoadingDots(message string, duration time.Duration) {
 	fmt.Print("\r\033[K") // Clear line
 }
 
-// ShowLoader displays a loader with callback
+// ShowLoader displays a loader with callback. It routes through
+// CurrentRenderer, so under ModeText/ModeJSON (CI logs, --progress=json)
+// it prints durable start/done/error lines instead of animating a spinner.
 func ShowLoader(message string, callback func() error) error {
-	spinner := NewDotsSpinner(message)
-	spinner.Start()
+	r := CurrentRenderer()
+	id := "loader:" + message
+	r.Start(id, message)
 
 	err := callback()
 
-	spinner.Stop()
-
 	if err != nil {
-		ErrorMsg(fmt.Sprintf("Failed: %v", err))
+		r.Error(id, err)
 	} else {
-		SuccessMsg("Complete!")
+		r.Done(id, "Complete!")
 	}
 
 	return err
@@ -246,9 +416,14 @@ func PromptWithSpinner(message string, options []string) string {
 	return response
 }
 
-// CheckIfTerminalSupportsColor checks if terminal supports color output
+// CheckIfTerminalSupportsColor checks if terminal supports color output.
+// Piping stdout to a file or another process (no isatty) disables color
+// even if COLORTERM/TERM_PROGRAM are set in the environment, since those
+// env vars describe the user's shell, not whatever is on the other end of
+// the pipe.
 func CheckIfTerminalSupportsColor() bool {
 	return os.Getenv("TERM") != "dumb" &&
+		term.IsTerminal(int(os.Stdout.Fd())) &&
 		(os.Getenv("COLORTERM") != "" || os.Getenv("TERM_PROGRAM") != "")
 }
 
@@ -0,0 +1,73 @@
+package ui
+
+import "testing"
+
+func TestRegisterSpinnerStyleAddsToRegistry(t *testing.T) {
+	defer delete(SpinnerStyles, "dots12")
+
+	err := RegisterSpinnerStyle("dots12", []byte(`{"interval":80,"frames":["⠁","⠂","⠄"]}`))
+	if err != nil {
+		t.Fatalf("RegisterSpinnerStyle() error = %v", err)
+	}
+
+	frames, ok := SpinnerStyles["dots12"]
+	if !ok || len(frames) != 3 {
+		t.Fatalf("expected dots12 to be registered with 3 frames, got %v", frames)
+	}
+}
+
+func TestRegisterSpinnerStyleRejectsEmptyFrames(t *testing.T) {
+	if err := RegisterSpinnerStyle("empty", []byte(`{"interval":80,"frames":[]}`)); err == nil {
+		t.Error("expected an error for a style with no frames")
+	}
+}
+
+func TestNewSpinnerResolvesRegisteredStyleByName(t *testing.T) {
+	defer delete(SpinnerStyles, "custom")
+	SpinnerStyles["custom"] = []string{"a", "b"}
+
+	s := NewSpinner("custom", "working")
+	if len(s.frames) != 2 || s.frames[0] != "a" {
+		t.Errorf("expected NewSpinner(\"custom\", ...) to use the registered frames, got %v", s.frames)
+	}
+}
+
+func TestNewSpinnerFallsBackToDotsForUnknownName(t *testing.T) {
+	s := NewSpinner("does-not-exist", "working")
+	if len(s.frames) != len(SpinnerDots) {
+		t.Errorf("expected an unknown style name to fall back to SpinnerDots, got %v", s.frames)
+	}
+}
+
+func TestNewSpinnerStillAcceptsRawFrameSlice(t *testing.T) {
+	s := NewSpinner([]string{"x", "y"}, "working")
+	if len(s.frames) != 2 || s.frames[1] != "y" {
+		t.Errorf("expected NewSpinner([]string{...}, ...) to keep working, got %v", s.frames)
+	}
+}
+
+func TestNewDefaultSpinnerHonorsEnvVar(t *testing.T) {
+	defer delete(SpinnerStyles, "envstyle")
+	SpinnerStyles["envstyle"] = []string{"1", "2"}
+
+	t.Setenv(DefaultSpinnerStyleEnvVar, "envstyle")
+	s := NewDefaultSpinner("working")
+	if len(s.frames) != 2 || s.frames[0] != "1" {
+		t.Errorf("expected NewDefaultSpinner to honor %s, got %v", DefaultSpinnerStyleEnvVar, s.frames)
+	}
+}
+
+func TestNewDefaultSpinnerOverrideTakesPrecedenceOverEnvVar(t *testing.T) {
+	defer func() {
+		delete(SpinnerStyles, "override-style")
+		SetDefaultSpinnerStyle("")
+	}()
+	SpinnerStyles["override-style"] = []string{"o"}
+	SetDefaultSpinnerStyle("override-style")
+	t.Setenv(DefaultSpinnerStyleEnvVar, "dots")
+
+	s := NewDefaultSpinner("working")
+	if len(s.frames) != 1 || s.frames[0] != "o" {
+		t.Errorf("expected SetDefaultSpinnerStyle to win over %s, got %v", DefaultSpinnerStyleEnvVar, s.frames)
+	}
+}
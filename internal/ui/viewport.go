@@ -0,0 +1,275 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// viewportChromeLines is the number of lines Viewport reserves for its
+// header and footer, on top of whatever lines of content are visible.
+const viewportChromeLines = 2
+
+// Viewport renders a long, growing list of lines - too many to fit on
+// screen at once - as a fixed-height scrollable window, in the spirit of
+// Pulumi's scrollable tree-table. It keeps the full logical line list in
+// memory, shows a header ("showing X-Y of Z") and footer (key hints), and
+// auto-follows the tail until the user scrolls up.
+//
+// On an interactive terminal, Viewport puts stdin into raw mode so arrow
+// keys scroll the window without an Enter keypress; pass interactive=false
+// (e.g. behind a --no-interactive flag) to skip that and just render.
+type Viewport struct {
+	out         io.Writer
+	interactive bool
+
+	mu        sync.Mutex
+	lines     []string
+	offset    int
+	follow    bool
+	prevFrame []string
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+
+	oldState *term.State
+}
+
+// NewViewport returns a Viewport writing to out and starts its background
+// render loop. interactive controls whether stdin is put into raw mode for
+// arrow-key scrolling; it's forced to false if out isn't a TTY or raw mode
+// can't be entered (e.g. stdin is redirected). Call Stop when done so the
+// final frame is left in scrollback and the terminal is restored to cooked
+// mode.
+func NewViewport(out io.Writer, interactive bool) *Viewport {
+	v := &Viewport{
+		out:    out,
+		follow: true,
+		stopCh: make(chan struct{}),
+	}
+
+	v.interactive = interactive && isTTYWriter(out) && term.IsTerminal(int(os.Stdin.Fd()))
+	if v.interactive {
+		state, err := term.MakeRaw(int(os.Stdin.Fd()))
+		if err != nil {
+			v.interactive = false
+		} else {
+			v.oldState = state
+		}
+	}
+
+	v.wg.Add(1)
+	go v.renderLoop()
+	if v.interactive {
+		// readKeys blocks on os.Stdin.Read for the process lifetime once
+		// started; a blocking read can't be cancelled cleanly without an
+		// extra OS-specific mechanism, so Stop does not wait on it - it
+		// keeps running harmlessly (mutating state nothing reads anymore)
+		// until the process exits or stdin is closed.
+		go v.readKeys()
+	}
+	return v
+}
+
+// SetLines replaces the full logical line list. If the viewport is
+// following the tail, the visible window jumps to the new bottom.
+func (v *Viewport) SetLines(lines []string) {
+	v.mu.Lock()
+	v.lines = lines
+	if v.follow {
+		v.offset = v.maxOffsetLocked(len(lines))
+	}
+	v.mu.Unlock()
+}
+
+// SetFollow enables or disables auto-follow. Enabling it jumps the visible
+// window to the current bottom, matching the "End" key's behavior.
+func (v *Viewport) SetFollow(follow bool) {
+	v.mu.Lock()
+	v.follow = follow
+	if follow {
+		v.offset = v.maxOffsetLocked(len(v.lines))
+	}
+	v.mu.Unlock()
+}
+
+// ScrollBy moves the visible window by n lines (negative scrolls up,
+// positive scrolls down), clamped to the content's bounds. Scrolling away
+// from the bottom disables follow; scrolling back to the bottom re-enables
+// it, matching the "End" key.
+func (v *Viewport) ScrollBy(n int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	maxOffset := v.maxOffsetLocked(len(v.lines))
+	offset := v.offset + n
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= maxOffset {
+		offset = maxOffset
+		v.follow = true
+	} else {
+		v.follow = false
+	}
+	v.offset = offset
+}
+
+// Stop renders one final frame, restores the terminal to cooked mode if it
+// was put into raw mode, and leaves the frame in scrollback rather than
+// erasing it.
+func (v *Viewport) Stop() {
+	close(v.stopCh)
+	v.wg.Wait()
+}
+
+func (v *Viewport) renderLoop() {
+	defer v.wg.Done()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	resize := resizeSignal()
+
+	for {
+		select {
+		case <-v.stopCh:
+			v.draw()
+			v.teardown()
+			return
+		case <-resize:
+			v.draw()
+		case <-ticker.C:
+			v.draw()
+		}
+	}
+}
+
+func (v *Viewport) teardown() {
+	if isTTYWriter(v.out) {
+		fmt.Fprintln(v.out)
+	}
+	if v.oldState != nil {
+		_ = term.Restore(int(os.Stdin.Fd()), v.oldState)
+	}
+}
+
+func (v *Viewport) draw() {
+	lines := v.frame()
+	width := terminalWidth(v.out)
+
+	if isTTYWriter(v.out) {
+		diffRedraw(v.out, v.prevFrame, lines, width)
+	} else {
+		for i, line := range lines {
+			if i < len(v.prevFrame) && v.prevFrame[i] == line {
+				continue
+			}
+			fmt.Fprintln(v.out, line)
+		}
+	}
+	v.prevFrame = lines
+}
+
+// frame builds the current header + visible content + footer lines.
+func (v *Viewport) frame() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	total := len(v.lines)
+	height := v.contentHeightLocked()
+
+	start := v.offset
+	if start > total {
+		start = total
+	}
+	end := start + height
+	if end > total {
+		end = total
+	}
+
+	footer := "↑/↓ scroll, End follow"
+	if v.follow {
+		footer = "↑/↓ scroll (following tail), End follow"
+	}
+
+	frame := make([]string, 0, end-start+viewportChromeLines)
+	frame = append(frame, Dim.Sprint(v.headerText(start, end, total)))
+	frame = append(frame, v.lines[start:end]...)
+	frame = append(frame, Dim.Sprint(footer))
+	return frame
+}
+
+func (v *Viewport) headerText(start, end, total int) string {
+	if total == 0 {
+		return "— showing 0-0 of 0 —"
+	}
+	return fmt.Sprintf("— showing %d-%d of %d —", start+1, end, total)
+}
+
+// contentHeightLocked returns how many content lines fit below the header
+// and footer, given the terminal's current height. Callers must hold v.mu.
+func (v *Viewport) contentHeightLocked() int {
+	height := terminalHeight(v.out) - viewportChromeLines
+	if height < 1 {
+		height = 1
+	}
+	return height
+}
+
+// maxOffsetLocked returns the offset that puts the window at the bottom of
+// total lines. Callers must hold v.mu.
+func (v *Viewport) maxOffsetLocked(total int) int {
+	height := v.contentHeightLocked()
+	if total <= height {
+		return 0
+	}
+	return total - height
+}
+
+// terminalHeight returns out's current row height via term.GetSize, falling
+// back to 24 rows for a non-*os.File writer or a query error.
+func terminalHeight(out io.Writer) int {
+	const defaultHeight = 24
+
+	f, ok := out.(*os.File)
+	if !ok {
+		return defaultHeight
+	}
+	_, height, err := term.GetSize(int(f.Fd()))
+	if err != nil || height <= 0 {
+		return defaultHeight
+	}
+	return height
+}
+
+// readKeys reads raw keystrokes from stdin and turns the arrow keys and End
+// into scroll/follow actions, until stdin returns an error (closed or EOF).
+func (v *Viewport) readKeys() {
+	buf := make([]byte, 3)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return
+		}
+		v.handleKey(buf[:n])
+	}
+}
+
+func (v *Viewport) handleKey(b []byte) {
+	if len(b) < 3 || b[0] != 0x1b || b[1] != '[' {
+		return
+	}
+	switch b[2] {
+	case 'A': // Up
+		v.ScrollBy(-1)
+	case 'B': // Down
+		v.ScrollBy(1)
+	case 'F': // End
+		v.SetFollow(true)
+	}
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestViewportFollowsTailBySetLines(t *testing.T) {
+	var buf bytes.Buffer
+	v := NewViewport(&buf, false)
+	defer v.Stop()
+
+	lines := make([]string, 100)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", 1) + string(rune('0'+i%10))
+	}
+	v.SetLines(lines)
+
+	frame := v.frame()
+	if !strings.Contains(frame[0], "of 100") {
+		t.Errorf("expected header to report 100 total lines, got: %q", frame[0])
+	}
+	if !strings.Contains(frame[len(frame)-1], "following tail") {
+		t.Errorf("expected footer to report follow mode, got: %q", frame[len(frame)-1])
+	}
+}
+
+func TestViewportScrollByDisablesAndRestoresFollow(t *testing.T) {
+	var buf bytes.Buffer
+	v := NewViewport(&buf, false)
+	defer v.Stop()
+
+	lines := make([]string, 100)
+	for i := range lines {
+		lines[i] = "line"
+	}
+	v.SetLines(lines)
+
+	v.ScrollBy(-5)
+	v.mu.Lock()
+	following := v.follow
+	v.mu.Unlock()
+	if following {
+		t.Error("expected scrolling up to disable follow")
+	}
+
+	v.SetFollow(true)
+	v.mu.Lock()
+	offset, total := v.offset, len(v.lines)
+	following = v.follow
+	v.mu.Unlock()
+	if !following {
+		t.Error("expected SetFollow(true) to re-enable follow")
+	}
+	if offset != v.maxOffsetLocked(total) {
+		t.Errorf("expected SetFollow(true) to jump to the bottom offset, got offset=%d", offset)
+	}
+}
+
+func TestViewportHeaderTextForEmptyContent(t *testing.T) {
+	var buf bytes.Buffer
+	v := NewViewport(&buf, false)
+	defer v.Stop()
+
+	if got := v.headerText(0, 0, 0); got != "— showing 0-0 of 0 —" {
+		t.Errorf("expected an empty-content header, got: %q", got)
+	}
+}
@@ -0,0 +1,628 @@
+// Package xcodegen scaffolds a brand-new single-target iOS app — source
+// files, Info.plist, and a project.pbxproj — from a minimal YAML spec, for
+// `nativefire ios init` to use when IOSPlatform.Detect finds no
+// .xcodeproj/.xcworkspace/Podfile to configure. It builds the pbxproj object
+// graph (PBXProject -> PBXGroup tree -> PBXNativeTarget -> build phases ->
+// XCConfigurationList -> XCBuildConfiguration) as a single in-memory
+// template, minting every object ID through xcodeproj.GenerateID so the
+// output uses the exact same deterministic-UUID scheme as the pbxproj
+// editor: regenerating from an identical spec reproduces byte-identical
+// output, and the project is immediately editable by internal/xcodeproj's
+// other mutators (AddResourceFile, AddSwiftPackageDependency, ...).
+package xcodegen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/clix-so/nativefire/internal/xcodeproj"
+	"gopkg.in/yaml.v3"
+)
+
+// Language values a Spec may declare.
+const (
+	LanguageSwift = "swift"
+	LanguageObjC  = "objc"
+)
+
+// UI values a Spec may declare.
+const (
+	UIKit   = "uikit"
+	SwiftUI = "swiftui"
+)
+
+const defaultDeploymentTarget = "13.0"
+
+// firebaseSwiftPackageURL/Version mirror the constants IOSPlatform uses to
+// wire the Firebase iOS SDK into an existing project, so a freshly generated
+// one starts out on the same version.
+const (
+	firebaseSwiftPackageURL     = "https://github.com/firebase/firebase-ios-sdk"
+	firebaseSwiftPackageVersion = "10.24.0"
+)
+
+// Spec is the minimal YAML app description `nativefire ios init` reads to
+// scaffold a project, mirroring the handful of fields XcodeGen's own
+// project.yml exposes for a single-target iOS app.
+type Spec struct {
+	AppName          string `yaml:"app_name"`
+	BundleID         string `yaml:"bundle_id"`
+	DeploymentTarget string `yaml:"deployment_target,omitempty"`
+	Language         string `yaml:"language,omitempty"` // "swift" (default) or "objc"
+	UI               string `yaml:"ui,omitempty"`       // "uikit" (default) or "swiftui"
+}
+
+// LoadSpec reads and validates a project spec, filling in defaults for
+// omitted fields (deployment target 13.0, Swift, UIKit). Generate does not
+// fill in these defaults itself; an empty deployment target is written to
+// the pbxproj as is.
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if spec.AppName == "" {
+		return nil, fmt.Errorf("%s: app_name is required", path)
+	}
+	if spec.BundleID == "" {
+		return nil, fmt.Errorf("%s: bundle_id is required", path)
+	}
+	if spec.DeploymentTarget == "" {
+		spec.DeploymentTarget = defaultDeploymentTarget
+	}
+	if spec.Language == "" {
+		spec.Language = LanguageSwift
+	}
+	if spec.UI == "" {
+		spec.UI = UIKit
+	}
+	if spec.UI == SwiftUI && spec.Language != LanguageSwift {
+		return nil, fmt.Errorf("%s: ui: swiftui requires language: swift", path)
+	}
+
+	return &spec, nil
+}
+
+// Generate scaffolds a fresh single-target iOS app under dir: an
+// AppName/ source directory (AppDelegate/App entry point, Info.plist, and
+// any SwiftUI/Objective-C companion files the spec's language/ui combination
+// needs) and an AppName.xcodeproj with the Firebase iOS SDK already
+// registered as a Swift Package Manager dependency. It returns the path to
+// the generated .xcodeproj.
+func Generate(spec *Spec, dir string) (string, error) {
+	appDir := filepath.Join(dir, spec.AppName)
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", appDir, err)
+	}
+
+	sources, err := writeSourceFiles(spec, appDir)
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeInfoPlist(appDir); err != nil {
+		return "", err
+	}
+
+	xcodeprojDir := filepath.Join(dir, spec.AppName+".xcodeproj")
+	if err := os.MkdirAll(xcodeprojDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", xcodeprojDir, err)
+	}
+
+	pbxprojPath := filepath.Join(xcodeprojDir, "project.pbxproj")
+	if err := os.WriteFile(pbxprojPath, []byte(renderPbxproj(spec, sources)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", pbxprojPath, err)
+	}
+
+	project, err := xcodeproj.Open(pbxprojPath)
+	if err != nil {
+		return "", err
+	}
+	if _, err := project.AddSwiftPackageDependency(firebaseSwiftPackageURL, firebaseSwiftPackageVersion, "FirebaseCore"); err != nil {
+		return "", fmt.Errorf("failed to wire Firebase Swift package into %s: %w", pbxprojPath, err)
+	}
+	if err := project.Save(false); err != nil {
+		return "", err
+	}
+
+	return xcodeprojDir, nil
+}
+
+// sourceFile is one file Generate writes into the app's source directory.
+// Compiled is false for headers, which are referenced in the project's
+// PBXGroup tree but never added to the Sources build phase.
+type sourceFile struct {
+	Name     string
+	Compiled bool
+}
+
+// writeSourceFiles emits the entry point (and any SwiftUI/Objective-C
+// companion files) spec's language/ui combination needs, returning the
+// files Generate must reference in the pbxproj, in the order they should
+// appear in Xcode's file list.
+func writeSourceFiles(spec *Spec, appDir string) ([]sourceFile, error) {
+	if spec.Language == LanguageObjC {
+		return writeObjCSources(spec, appDir)
+	}
+	if spec.UI == SwiftUI {
+		return writeSwiftUISources(spec, appDir)
+	}
+	return writeUIKitSwiftSources(spec, appDir)
+}
+
+const swiftUIKitAppDelegate = `import UIKit
+
+@main
+class AppDelegate: UIResponder, UIApplicationDelegate {
+
+    var window: UIWindow?
+
+    func application(_ application: UIApplication, didFinishLaunchingWithOptions launchOptions: [UIApplication.LaunchOptionsKey: Any]?) -> Bool {
+        window = UIWindow(frame: UIScreen.main.bounds)
+        window?.rootViewController = UIViewController()
+        window?.makeKeyAndVisible()
+        return true
+    }
+}
+`
+
+func writeUIKitSwiftSources(spec *Spec, appDir string) ([]sourceFile, error) {
+	if err := os.WriteFile(filepath.Join(appDir, "AppDelegate.swift"), []byte(swiftUIKitAppDelegate), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write AppDelegate.swift: %w", err)
+	}
+	return []sourceFile{{Name: "AppDelegate.swift", Compiled: true}}, nil
+}
+
+const swiftUIAppDelegate = `import UIKit
+
+class AppDelegate: NSObject, UIApplicationDelegate {
+    func application(_ application: UIApplication, didFinishLaunchingWithOptions launchOptions: [UIApplication.LaunchOptionsKey: Any]?) -> Bool {
+        return true
+    }
+}
+`
+
+const swiftUIContentView = `import SwiftUI
+
+struct ContentView: View {
+    var body: some View {
+        Text("Hello, world!")
+            .padding()
+    }
+}
+
+#Preview {
+    ContentView()
+}
+`
+
+func writeSwiftUISources(spec *Spec, appDir string) ([]sourceFile, error) {
+	appFile := fmt.Sprintf(`import SwiftUI
+
+@main
+struct %sApp: App {
+    @UIApplicationDelegateAdaptor(AppDelegate.self) var appDelegate
+
+    var body: some Scene {
+        WindowGroup {
+            ContentView()
+        }
+    }
+}
+`, sanitizeSwiftIdentifier(spec.AppName))
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{sanitizeSwiftIdentifier(spec.AppName) + "App.swift", appFile},
+		{"AppDelegate.swift", swiftUIAppDelegate},
+		{"ContentView.swift", swiftUIContentView},
+	}
+
+	var sources []sourceFile
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(appDir, f.name), []byte(f.content), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+		sources = append(sources, sourceFile{Name: f.name, Compiled: true})
+	}
+	return sources, nil
+}
+
+const objcMain = `#import <UIKit/UIKit.h>
+#import "AppDelegate.h"
+
+int main(int argc, char * argv[]) {
+    NSString * appDelegateClassName;
+    @autoreleasepool {
+        appDelegateClassName = NSStringFromClass([AppDelegate class]);
+    }
+    return UIApplicationMain(argc, argv, nil, appDelegateClassName);
+}
+`
+
+const objcAppDelegateHeader = `#import <UIKit/UIKit.h>
+
+@interface AppDelegate : UIResponder <UIApplicationDelegate>
+
+@property (strong, nonatomic) UIWindow *window;
+
+@end
+`
+
+const objcAppDelegateImpl = `#import "AppDelegate.h"
+
+@implementation AppDelegate
+
+- (BOOL)application:(UIApplication *)application didFinishLaunchingWithOptions:(NSDictionary *)launchOptions {
+    self.window = [[UIWindow alloc] initWithFrame:[[UIScreen mainScreen] bounds]];
+    self.window.rootViewController = [[UIViewController alloc] init];
+    [self.window makeKeyAndVisible];
+    return YES;
+}
+
+@end
+`
+
+func writeObjCSources(spec *Spec, appDir string) ([]sourceFile, error) {
+	files := []struct {
+		name     string
+		content  string
+		compiled bool
+	}{
+		{"main.m", objcMain, true},
+		{"AppDelegate.h", objcAppDelegateHeader, false},
+		{"AppDelegate.m", objcAppDelegateImpl, true},
+	}
+
+	var sources []sourceFile
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(appDir, f.name), []byte(f.content), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+		sources = append(sources, sourceFile{Name: f.name, Compiled: f.compiled})
+	}
+	return sources, nil
+}
+
+// sanitizeSwiftIdentifier strips characters that aren't valid in a Swift
+// type name from appName, so "My App" becomes a usable "MyApp" for the
+// generated @main struct.
+func sanitizeSwiftIdentifier(appName string) string {
+	var b strings.Builder
+	for _, r := range appName {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+const infoPlistTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleDevelopmentRegion</key>
+	<string>en</string>
+	<key>CFBundleExecutable</key>
+	<string>$(EXECUTABLE_NAME)</string>
+	<key>CFBundleIdentifier</key>
+	<string>$(PRODUCT_BUNDLE_IDENTIFIER)</string>
+	<key>CFBundleInfoDictionaryVersion</key>
+	<string>6.0</string>
+	<key>CFBundleName</key>
+	<string>$(PRODUCT_NAME)</string>
+	<key>CFBundlePackageType</key>
+	<string>APPL</string>
+	<key>CFBundleShortVersionString</key>
+	<string>1.0</string>
+	<key>CFBundleVersion</key>
+	<string>1</string>
+	<key>LSRequiresIPhoneOS</key>
+	<true/>
+	<key>UILaunchScreen</key>
+	<dict/>
+	<key>UIRequiredDeviceCapabilities</key>
+	<array>
+		<string>armv7</string>
+	</array>
+	<key>UISupportedInterfaceOrientations</key>
+	<array>
+		<string>UIInterfaceOrientationPortrait</string>
+	</array>
+</dict>
+</plist>
+`
+
+func writeInfoPlist(appDir string) error {
+	path := filepath.Join(appDir, "Info.plist")
+	if err := os.WriteFile(path, []byte(infoPlistTemplate), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// sourceFileType maps a source file's extension onto the lastKnownFileType
+// pbxproj expects for its PBXFileReference.
+func sourceFileType(name string) string {
+	switch filepath.Ext(name) {
+	case ".swift":
+		return "sourcecode.swift"
+	case ".m":
+		return "sourcecode.c.objc"
+	case ".h":
+		return "sourcecode.c.h"
+	default:
+		return "text"
+	}
+}
+
+// renderPbxproj assembles a minimal, valid project.pbxproj for a
+// single-target app named spec.AppName compiling sources, in the standard
+// object-section order Xcode itself writes (PBXBuildFile,
+// PBXFileReference, PBXFrameworksBuildPhase, PBXGroup, PBXNativeTarget,
+// PBXProject, PBXResourcesBuildPhase, PBXSourcesBuildPhase,
+// XCBuildConfiguration, XCConfigurationList). Every object ID is minted via
+// xcodeproj.GenerateID, namespaced by the app's bundle ID, so regenerating
+// from an identical spec reproduces byte-identical output.
+func renderPbxproj(spec *Spec, sources []sourceFile) string {
+	ns := spec.BundleID
+	id := func(kind string) string { return xcodeproj.GenerateID(ns + ":" + kind) }
+
+	projectID := id("PBXProject")
+	mainGroupID := id("PBXGroup:main")
+	appGroupID := id("PBXGroup:" + spec.AppName)
+	productsGroupID := id("PBXGroup:Products")
+	targetID := id("PBXNativeTarget:" + spec.AppName)
+	productFileRefID := id("PBXFileReference:" + spec.AppName + ".app")
+	infoPlistRefID := id("PBXFileReference:Info.plist")
+	sourcesPhaseID := id("PBXSourcesBuildPhase")
+	frameworksPhaseID := id("PBXFrameworksBuildPhase")
+	resourcesPhaseID := id("PBXResourcesBuildPhase")
+	projectConfigListID := id("XCConfigurationList:project")
+	targetConfigListID := id("XCConfigurationList:target")
+	debugProjectConfigID := id("XCBuildConfiguration:project:Debug")
+	releaseProjectConfigID := id("XCBuildConfiguration:project:Release")
+	debugTargetConfigID := id("XCBuildConfiguration:target:Debug")
+	releaseTargetConfigID := id("XCBuildConfiguration:target:Release")
+
+	var buildFiles, fileRefs, groupChildren, sourcesPhaseFiles strings.Builder
+
+	fileRefs.WriteString(fmt.Sprintf(
+		"\t\t%s /* Info.plist */ = {isa = PBXFileReference; lastKnownFileType = text.plist.xml; path = Info.plist; sourceTree = \"<group>\"; };\n",
+		infoPlistRefID))
+	groupChildren.WriteString(fmt.Sprintf("\t\t\t\t%s /* Info.plist */,\n", infoPlistRefID))
+
+	for _, f := range sources {
+		refID := id("PBXFileReference:" + f.Name)
+		fileRefs.WriteString(fmt.Sprintf(
+			"\t\t%s /* %s */ = {isa = PBXFileReference; lastKnownFileType = %s; path = %s; sourceTree = \"<group>\"; };\n",
+			refID, f.Name, sourceFileType(f.Name), f.Name))
+		groupChildren.WriteString(fmt.Sprintf("\t\t\t\t%s /* %s */,\n", refID, f.Name))
+
+		if !f.Compiled {
+			continue
+		}
+		buildFileID := id("PBXBuildFile:" + f.Name)
+		buildFiles.WriteString(fmt.Sprintf(
+			"\t\t%s /* %s in Sources */ = {isa = PBXBuildFile; fileRef = %s /* %s */; };\n",
+			buildFileID, f.Name, refID, f.Name))
+		sourcesPhaseFiles.WriteString(fmt.Sprintf("\t\t\t\t%s /* %s in Sources */,\n", buildFileID, f.Name))
+	}
+
+	fileRefs.WriteString(fmt.Sprintf(
+		"\t\t%s /* %s.app */ = {isa = PBXFileReference; explicitFileType = wrapper.application; includeInIndex = 0; "+
+			"path = %s.app; sourceTree = BUILT_PRODUCTS_DIR; };\n",
+		productFileRefID, spec.AppName, spec.AppName))
+
+	swiftVersionSetting := ""
+	if spec.Language == LanguageSwift {
+		swiftVersionSetting = "\t\t\t\tSWIFT_VERSION = 5.0;\n"
+	}
+
+	var b strings.Builder
+
+	fmt.Fprint(&b, "// !$*UTF8*$!\n{\n\tarchiveVersion = 1;\n\tobjectVersion = 56;\n\tobjects = {\n\n")
+
+	fmt.Fprint(&b, "/* Begin PBXBuildFile section */\n")
+	b.WriteString(buildFiles.String())
+	fmt.Fprint(&b, "/* End PBXBuildFile section */\n\n")
+
+	fmt.Fprint(&b, "/* Begin PBXFileReference section */\n")
+	b.WriteString(fileRefs.String())
+	fmt.Fprint(&b, "/* End PBXFileReference section */\n\n")
+
+	fmt.Fprintf(&b, "/* Begin PBXFrameworksBuildPhase section */\n"+
+		"\t\t%s /* Frameworks */ = {\n"+
+		"\t\t\tisa = PBXFrameworksBuildPhase;\n"+
+		"\t\t\tbuildActionMask = 2147483647;\n"+
+		"\t\t\tfiles = (\n"+
+		"\t\t\t);\n"+
+		"\t\t\trunOnlyForDeploymentPostprocessing = 0;\n"+
+		"\t\t};\n"+
+		"/* End PBXFrameworksBuildPhase section */\n\n",
+		frameworksPhaseID)
+
+	fmt.Fprintf(&b, "/* Begin PBXGroup section */\n"+
+		"\t\t%s /* %s */ = {\n"+
+			"\t\t\tisa = PBXGroup;\n"+
+			"\t\t\tchildren = (\n"+
+			"%s"+
+			"\t\t\t);\n"+
+			"\t\t\tpath = %s;\n"+
+			"\t\t\tsourceTree = \"<group>\";\n"+
+			"\t\t};\n"+
+		"\t\t%s /* %s */ = {\n"+
+			"\t\t\tisa = PBXGroup;\n"+
+			"\t\t\tchildren = (\n"+
+			"\t\t\t\t%s /* %s.app */,\n"+
+			"\t\t\t);\n"+
+			"\t\t\tname = Products;\n"+
+			"\t\t\tsourceTree = \"<group>\";\n"+
+			"\t\t};\n"+
+		"\t\t%s = {\n"+
+			"\t\t\tisa = PBXGroup;\n"+
+			"\t\t\tchildren = (\n"+
+			"\t\t\t\t%s /* %s */,\n"+
+			"\t\t\t\t%s /* Products */,\n"+
+			"\t\t\t);\n"+
+			"\t\t\tsourceTree = \"<group>\";\n"+
+			"\t\t};\n"+
+		"/* End PBXGroup section */\n\n",
+		appGroupID, spec.AppName, groupChildren.String(), spec.AppName,
+		productsGroupID, "Products", productFileRefID, spec.AppName,
+		mainGroupID, appGroupID, spec.AppName, productsGroupID)
+
+	fmt.Fprintf(&b, "/* Begin PBXNativeTarget section */\n"+
+		"\t\t%s /* %s */ = {\n"+
+			"\t\t\tisa = PBXNativeTarget;\n"+
+			"\t\t\tbuildConfigurationList = %s /* Build configuration list for PBXNativeTarget \"%s\" */;\n"+
+			"\t\t\tbuildPhases = (\n"+
+			"\t\t\t\t%s /* Sources */,\n"+
+			"\t\t\t\t%s /* Frameworks */,\n"+
+			"\t\t\t\t%s /* Resources */,\n"+
+			"\t\t\t);\n"+
+			"\t\t\tbuildRules = (\n"+
+			"\t\t\t);\n"+
+			"\t\t\tdependencies = (\n"+
+			"\t\t\t);\n"+
+			"\t\t\tname = %s;\n"+
+			"\t\t\tproductName = %s;\n"+
+			"\t\t\tproductReference = %s /* %s.app */;\n"+
+			"\t\t\tproductType = \"com.apple.product-type.application\";\n"+
+			"\t\t};\n"+
+		"/* End PBXNativeTarget section */\n\n",
+		targetID, spec.AppName, targetConfigListID, spec.AppName,
+		sourcesPhaseID, frameworksPhaseID, resourcesPhaseID,
+		spec.AppName, spec.AppName, productFileRefID, spec.AppName)
+
+	fmt.Fprintf(&b, "/* Begin PBXProject section */\n"+
+		"\t\t%s /* Project object */ = {\n"+
+			"\t\t\tisa = PBXProject;\n"+
+			"\t\t\tattributes = {\n"+
+			"\t\t\t\tLastSwiftUpdateCheck = 1500;\n"+
+			"\t\t\t\tLastUpgradeCheck = 1500;\n"+
+			"\t\t\t};\n"+
+			"\t\t\tbuildConfigurationList = %s /* Build configuration list for PBXProject \"%s\" */;\n"+
+			"\t\t\tcompatibilityVersion = \"Xcode 14.0\";\n"+
+			"\t\t\tdevelopmentRegion = en;\n"+
+			"\t\t\thasScannedForEncodings = 0;\n"+
+			"\t\t\tknownRegions = (\n"+
+			"\t\t\t\ten,\n"+
+			"\t\t\t\tBase,\n"+
+			"\t\t\t);\n"+
+			"\t\t\tmainGroup = %s;\n"+
+			"\t\t\tproductRefGroup = %s /* Products */;\n"+
+			"\t\t\tprojectDirPath = \"\";\n"+
+			"\t\t\tprojectRoot = \"\";\n"+
+			"\t\t\ttargets = (\n"+
+			"\t\t\t\t%s /* %s */,\n"+
+			"\t\t\t);\n"+
+			"\t\t};\n"+
+		"/* End PBXProject section */\n\n",
+		projectID, projectConfigListID, spec.AppName, mainGroupID, productsGroupID, targetID, spec.AppName)
+
+	fmt.Fprintf(&b, "/* Begin PBXResourcesBuildPhase section */\n"+
+		"\t\t%s /* Resources */ = {\n"+
+			"\t\t\tisa = PBXResourcesBuildPhase;\n"+
+			"\t\t\tbuildActionMask = 2147483647;\n"+
+			"\t\t\tfiles = (\n"+
+			"\t\t\t);\n"+
+			"\t\t\trunOnlyForDeploymentPostprocessing = 0;\n"+
+			"\t\t};\n"+
+		"/* End PBXResourcesBuildPhase section */\n\n",
+		resourcesPhaseID)
+
+	fmt.Fprintf(&b, "/* Begin PBXSourcesBuildPhase section */\n"+
+		"\t\t%s /* Sources */ = {\n"+
+			"\t\t\tisa = PBXSourcesBuildPhase;\n"+
+			"\t\t\tbuildActionMask = 2147483647;\n"+
+			"\t\t\tfiles = (\n"+
+			"%s"+
+			"\t\t\t);\n"+
+			"\t\t\trunOnlyForDeploymentPostprocessing = 0;\n"+
+			"\t\t};\n"+
+		"/* End PBXSourcesBuildPhase section */\n\n",
+		sourcesPhaseID, sourcesPhaseFiles.String())
+
+	fmt.Fprintf(&b, "/* Begin XCBuildConfiguration section */\n"+
+		"\t\t%s /* Debug */ = {\n"+
+			"\t\t\tisa = XCBuildConfiguration;\n"+
+			"\t\t\tbuildSettings = {\n"+
+			"\t\t\t\tIPHONEOS_DEPLOYMENT_TARGET = %s;\n"+
+			"\t\t\t\tONLY_ACTIVE_ARCH = YES;\n"+
+			"\t\t\t\tSDKROOT = iphoneos;\n"+
+			"\t\t\t};\n"+
+			"\t\t\tname = Debug;\n"+
+			"\t\t};\n"+
+		"\t\t%s /* Release */ = {\n"+
+			"\t\t\tisa = XCBuildConfiguration;\n"+
+			"\t\t\tbuildSettings = {\n"+
+			"\t\t\t\tIPHONEOS_DEPLOYMENT_TARGET = %s;\n"+
+			"\t\t\t\tSDKROOT = iphoneos;\n"+
+			"\t\t\t\tVALIDATE_PRODUCT = YES;\n"+
+			"\t\t\t};\n"+
+			"\t\t\tname = Release;\n"+
+			"\t\t};\n"+
+		"\t\t%s /* Debug */ = {\n"+
+			"\t\t\tisa = XCBuildConfiguration;\n"+
+			"\t\t\tbuildSettings = {\n"+
+			"\t\t\t\tINFOPLIST_FILE = %s/Info.plist;\n"+
+			"\t\t\t\tPRODUCT_BUNDLE_IDENTIFIER = %s;\n"+
+			"\t\t\t\tPRODUCT_NAME = \"$(TARGET_NAME)\";\n"+
+			"%s"+
+			"\t\t\t};\n"+
+			"\t\t\tname = Debug;\n"+
+			"\t\t};\n"+
+		"\t\t%s /* Release */ = {\n"+
+			"\t\t\tisa = XCBuildConfiguration;\n"+
+			"\t\t\tbuildSettings = {\n"+
+			"\t\t\t\tINFOPLIST_FILE = %s/Info.plist;\n"+
+			"\t\t\t\tPRODUCT_BUNDLE_IDENTIFIER = %s;\n"+
+			"\t\t\t\tPRODUCT_NAME = \"$(TARGET_NAME)\";\n"+
+			"%s"+
+			"\t\t\t};\n"+
+			"\t\t\tname = Release;\n"+
+			"\t\t};\n"+
+		"/* End XCBuildConfiguration section */\n\n",
+		debugProjectConfigID, spec.DeploymentTarget,
+		releaseProjectConfigID, spec.DeploymentTarget,
+		debugTargetConfigID, spec.AppName, spec.BundleID, swiftVersionSetting,
+		releaseTargetConfigID, spec.AppName, spec.BundleID, swiftVersionSetting)
+
+	fmt.Fprintf(&b, "/* Begin XCConfigurationList section */\n"+
+		"\t\t%s /* Build configuration list for PBXProject \"%s\" */ = {\n"+
+			"\t\t\tisa = XCConfigurationList;\n"+
+			"\t\t\tbuildConfigurations = (\n"+
+			"\t\t\t\t%s /* Debug */,\n"+
+			"\t\t\t\t%s /* Release */,\n"+
+			"\t\t\t);\n"+
+			"\t\t\tdefaultConfigurationIsVisible = 0;\n"+
+			"\t\t\tdefaultConfigurationName = Release;\n"+
+			"\t\t};\n"+
+		"\t\t%s /* Build configuration list for PBXNativeTarget \"%s\" */ = {\n"+
+			"\t\t\tisa = XCConfigurationList;\n"+
+			"\t\t\tbuildConfigurations = (\n"+
+			"\t\t\t\t%s /* Debug */,\n"+
+			"\t\t\t\t%s /* Release */,\n"+
+			"\t\t\t);\n"+
+			"\t\t\tdefaultConfigurationIsVisible = 0;\n"+
+			"\t\t\tdefaultConfigurationName = Release;\n"+
+			"\t\t};\n"+
+		"/* End XCConfigurationList section */\n\n",
+		projectConfigListID, spec.AppName, debugProjectConfigID, releaseProjectConfigID,
+		targetConfigListID, spec.AppName, debugTargetConfigID, releaseTargetConfigID)
+
+	fmt.Fprint(&b, "\t};\n\trootObject = "+projectID+" /* Project object */;\n}\n")
+
+	return b.String()
+}
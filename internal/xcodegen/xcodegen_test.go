@@ -0,0 +1,159 @@
+package xcodegen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSpecFile(t *testing.T, dir, yamlContent string) string {
+	t.Helper()
+	path := filepath.Join(dir, "spec.yaml")
+	if err := os.WriteFile(path, []byte(yamlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadSpecDefaults(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := writeSpecFile(t, tmpDir, "app_name: MyApp\nbundle_id: com.example.myapp\n")
+
+	spec, err := LoadSpec(path)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	if spec.DeploymentTarget != defaultDeploymentTarget {
+		t.Errorf("expected default deployment target %q, got %q", defaultDeploymentTarget, spec.DeploymentTarget)
+	}
+	if spec.Language != LanguageSwift {
+		t.Errorf("expected default language %q, got %q", LanguageSwift, spec.Language)
+	}
+	if spec.UI != UIKit {
+		t.Errorf("expected default ui %q, got %q", UIKit, spec.UI)
+	}
+}
+
+func TestLoadSpecRequiresAppNameAndBundleID(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	path := writeSpecFile(t, tmpDir, "bundle_id: com.example.myapp\n")
+	if _, err := LoadSpec(path); err == nil {
+		t.Error("expected error when app_name is missing")
+	}
+
+	path = writeSpecFile(t, tmpDir, "app_name: MyApp\n")
+	if _, err := LoadSpec(path); err == nil {
+		t.Error("expected error when bundle_id is missing")
+	}
+}
+
+func TestLoadSpecRejectsSwiftUIWithObjC(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := writeSpecFile(t, tmpDir, "app_name: MyApp\nbundle_id: com.example.myapp\nlanguage: objc\nui: swiftui\n")
+
+	if _, err := LoadSpec(path); err == nil {
+		t.Error("expected error when ui: swiftui is combined with language: objc")
+	}
+}
+
+func TestGenerateSwiftUIKit(t *testing.T) {
+	tmpDir := t.TempDir()
+	spec := &Spec{AppName: "MyApp", BundleID: "com.example.myapp", DeploymentTarget: "15.0", Language: LanguageSwift, UI: UIKit}
+
+	xcodeprojDir, err := Generate(spec, tmpDir)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if filepath.Base(xcodeprojDir) != "MyApp.xcodeproj" {
+		t.Errorf("expected xcodeproj dir named MyApp.xcodeproj, got %s", xcodeprojDir)
+	}
+
+	appDelegatePath := filepath.Join(tmpDir, "MyApp", "AppDelegate.swift")
+	if _, err := os.Stat(appDelegatePath); err != nil {
+		t.Errorf("expected %s to exist: %v", appDelegatePath, err)
+	}
+
+	infoPlistPath := filepath.Join(tmpDir, "MyApp", "Info.plist")
+	if _, err := os.Stat(infoPlistPath); err != nil {
+		t.Errorf("expected %s to exist: %v", infoPlistPath, err)
+	}
+
+	pbxprojData, err := os.ReadFile(filepath.Join(xcodeprojDir, "project.pbxproj"))
+	if err != nil {
+		t.Fatalf("expected project.pbxproj to exist: %v", err)
+	}
+	pbxproj := string(pbxprojData)
+
+	if !strings.Contains(pbxproj, "AppDelegate.swift") {
+		t.Error("expected pbxproj to reference AppDelegate.swift")
+	}
+	if !strings.Contains(pbxproj, "XCRemoteSwiftPackageReference") {
+		t.Error("expected pbxproj to have the Firebase Swift package wired in")
+	}
+	if !strings.Contains(pbxproj, "15.0") {
+		t.Error("expected pbxproj to use the spec's deployment target")
+	}
+}
+
+func TestGenerateSwiftUI(t *testing.T) {
+	tmpDir := t.TempDir()
+	spec := &Spec{AppName: "MyApp", BundleID: "com.example.myapp", DeploymentTarget: "16.0", Language: LanguageSwift, UI: SwiftUI}
+
+	if _, err := Generate(spec, tmpDir); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, name := range []string{"MyAppApp.swift", "AppDelegate.swift", "ContentView.swift"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, "MyApp", name)); err != nil {
+			t.Errorf("expected %s to exist: %v", name, err)
+		}
+	}
+}
+
+func TestGenerateObjC(t *testing.T) {
+	tmpDir := t.TempDir()
+	spec := &Spec{AppName: "MyApp", BundleID: "com.example.myapp", DeploymentTarget: "13.0", Language: LanguageObjC, UI: UIKit}
+
+	if _, err := Generate(spec, tmpDir); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, name := range []string{"main.m", "AppDelegate.h", "AppDelegate.m"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, "MyApp", name)); err != nil {
+			t.Errorf("expected %s to exist: %v", name, err)
+		}
+	}
+}
+
+func TestGenerateIsReproducible(t *testing.T) {
+	spec := &Spec{AppName: "MyApp", BundleID: "com.example.myapp", DeploymentTarget: "13.0", Language: LanguageSwift, UI: UIKit}
+
+	dirA := t.TempDir()
+	dirB := t.TempDir()
+
+	xcodeprojA, err := Generate(spec, dirA)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	xcodeprojB, err := Generate(spec, dirB)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	pbxprojA, err := os.ReadFile(filepath.Join(xcodeprojA, "project.pbxproj"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pbxprojB, err := os.ReadFile(filepath.Join(xcodeprojB, "project.pbxproj"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(pbxprojA) != string(pbxprojB) {
+		t.Error("expected Generate() to produce byte-identical output for an identical spec")
+	}
+}
@@ -0,0 +1,481 @@
+// Package xcodeproj does minimal, targeted mutation of Xcode's ASCII-plist
+// project.pbxproj format. It is intentionally string-based rather than a
+// full ASCII-plist parser (see the Firebase bundle ID/pbxproj scanning in
+// internal/firebase for the same pattern elsewhere in this codebase) — it
+// knows just enough about the handful of sections it edits to stay
+// idempotent and to preserve the tab indentation and `isa = ...;` ordering
+// Xcode expects.
+package xcodeproj
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// BackupSuffix is appended to the original pbxproj path before it is
+// overwritten.
+const BackupSuffix = ".nativefire.bak"
+
+// Project holds the raw contents of a project.pbxproj file being mutated.
+type Project struct {
+	Path     string
+	content  string
+	original string
+}
+
+// Open reads the pbxproj file at path.
+func Open(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return &Project{Path: path, content: string(data), original: string(data)}, nil
+}
+
+// Changed reports whether any mutation has been applied since Open.
+func (p *Project) Changed() bool {
+	return p.content != p.original
+}
+
+// AddResourceFile registers fileName as a PBXFileReference, attaches it to
+// the project's main group, and appends it to the PBXResourcesBuildPhase of
+// every target so Xcode copies it into the app bundle. It is idempotent: if
+// a PBXFileReference for fileName already exists, it returns false without
+// modifying the project.
+func (p *Project) AddResourceFile(fileName string) (bool, error) {
+	if strings.Contains(p.content, fmt.Sprintf("/* %s */ = {isa = PBXFileReference;", fileName)) {
+		return false, nil
+	}
+
+	fileRefID := generateID("PBXFileReference:" + fileName)
+	buildFileID := generateID("PBXBuildFile:" + fileName)
+
+	if err := p.insertBuildFile(fileName, fileRefID, buildFileID); err != nil {
+		return false, err
+	}
+	if err := p.insertFileReference(fileName, fileRefID); err != nil {
+		return false, err
+	}
+	if err := p.addToMainGroup(fileName, fileRefID); err != nil {
+		return false, err
+	}
+	if err := p.addToResourcesBuildPhases(fileName, buildFileID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (p *Project) insertBuildFile(fileName, fileRefID, buildFileID string) error {
+	marker := "/* Begin PBXBuildFile section */\n"
+	idx := strings.Index(p.content, marker)
+	if idx == -1 {
+		return fmt.Errorf("could not find PBXBuildFile section in %s", p.Path)
+	}
+
+	line := fmt.Sprintf("\t\t%s /* %s in Resources */ = {isa = PBXBuildFile; fileRef = %s /* %s */; };\n",
+		buildFileID, fileName, fileRefID, fileName)
+
+	insertAt := idx + len(marker)
+	p.content = p.content[:insertAt] + line + p.content[insertAt:]
+	return nil
+}
+
+func (p *Project) insertFileReference(fileName, fileRefID string) error {
+	marker := "/* Begin PBXFileReference section */\n"
+	idx := strings.Index(p.content, marker)
+	if idx == -1 {
+		return fmt.Errorf("could not find PBXFileReference section in %s", p.Path)
+	}
+
+	line := fmt.Sprintf("\t\t%s /* %s */ = {isa = PBXFileReference; lastKnownFileType = text.plist.xml; "+
+		"name = %s; path = %s; sourceTree = \"<group>\"; };\n", fileRefID, fileName, fileName, fileName)
+
+	insertAt := idx + len(marker)
+	p.content = p.content[:insertAt] + line + p.content[insertAt:]
+	return nil
+}
+
+// addToMainGroup attaches fileRefID to the first PBXGroup's children list.
+// Xcode projects conventionally list the project's main group first in the
+// PBXGroup section, so this is enough for the common single-group layout
+// nativefire targets without a full object-graph parser.
+func (p *Project) addToMainGroup(fileName, fileRefID string) error {
+	groupMarker := "/* Begin PBXGroup section */\n"
+	groupStart := strings.Index(p.content, groupMarker)
+	if groupStart == -1 {
+		return fmt.Errorf("could not find PBXGroup section in %s", p.Path)
+	}
+
+	childrenMarker := "children = (\n"
+	childrenIdx := strings.Index(p.content[groupStart:], childrenMarker)
+	if childrenIdx == -1 {
+		return fmt.Errorf("could not find a children list in the main group in %s", p.Path)
+	}
+
+	insertAt := groupStart + childrenIdx + len(childrenMarker)
+	line := fmt.Sprintf("\t\t\t\t%s /* %s */,\n", fileRefID, fileName)
+	p.content = p.content[:insertAt] + line + p.content[insertAt:]
+	return nil
+}
+
+// addToResourcesBuildPhases appends buildFileID to the `files = (` list of
+// every PBXResourcesBuildPhase in the project, i.e. every application
+// target's "Copy Bundle Resources" phase.
+func (p *Project) addToResourcesBuildPhases(fileName, buildFileID string) error {
+	const phaseISA = "isa = PBXResourcesBuildPhase;"
+	const filesMarker = "files = (\n"
+
+	count := 0
+	searchFrom := 0
+	for {
+		isaIdx := strings.Index(p.content[searchFrom:], phaseISA)
+		if isaIdx == -1 {
+			break
+		}
+		isaIdx += searchFrom
+
+		filesIdx := strings.Index(p.content[isaIdx:], filesMarker)
+		if filesIdx == -1 {
+			searchFrom = isaIdx + len(phaseISA)
+			continue
+		}
+
+		insertAt := isaIdx + filesIdx + len(filesMarker)
+		line := fmt.Sprintf("\t\t\t\t%s /* %s in Resources */,\n", buildFileID, fileName)
+		p.content = p.content[:insertAt] + line + p.content[insertAt:]
+
+		count++
+		searchFrom = insertAt + len(line)
+	}
+
+	if count == 0 {
+		return fmt.Errorf("could not find any PBXResourcesBuildPhase in %s", p.Path)
+	}
+	return nil
+}
+
+// AddSwiftPackageDependency registers repoURL as an
+// XCRemoteSwiftPackageReference and productName as an
+// XCSwiftPackageProductDependency, then wires the product into every
+// PBXNativeTarget's packageProductDependencies and the reference into the
+// project's packageReferences — the pbxproj equivalent of using Xcode's
+// File > Add Package Dependencies... UI. It is idempotent per (repoURL,
+// productName) pair: if productName is already registered for repoURL, it
+// returns false without modifying the project. Multiple products from the
+// same repoURL share a single XCRemoteSwiftPackageReference, matching how
+// Xcode itself dedupes the repo reference across products.
+func (p *Project) AddSwiftPackageDependency(repoURL, requirement, productName string) (bool, error) {
+	packageRefID := generateID("XCRemoteSwiftPackageReference:" + repoURL)
+	productDepID := generateID("XCSwiftPackageProductDependency:" + repoURL + ":" + productName)
+
+	if strings.Contains(p.content, productDepID) {
+		return false, nil
+	}
+
+	packageRefAlreadyExists := strings.Contains(p.content, packageRefID)
+
+	packageRefLine := fmt.Sprintf(
+		"\t\t%s /* %s */ = {\n"+
+			"\t\t\tisa = XCRemoteSwiftPackageReference;\n"+
+			"\t\t\trepositoryURL = \"%s\";\n"+
+			"\t\t\trequirement = {\n"+
+			"\t\t\t\tkind = upToNextMajorVersion;\n"+
+			"\t\t\t\tminimumVersion = %s;\n"+
+			"\t\t\t};\n"+
+			"\t\t};\n",
+		packageRefID, repoURL, repoURL, requirement)
+	if !packageRefAlreadyExists {
+		if err := p.ensureSection("XCRemoteSwiftPackageReference", packageRefLine); err != nil {
+			return false, err
+		}
+	}
+
+	productLine := fmt.Sprintf(
+		"\t\t%s /* %s */ = {\n"+
+			"\t\t\tisa = XCSwiftPackageProductDependency;\n"+
+			"\t\t\tpackage = %s /* %s */;\n"+
+			"\t\t\tproductName = %s;\n"+
+			"\t\t};\n",
+		productDepID, productName, packageRefID, repoURL, productName)
+	if err := p.ensureSection("XCSwiftPackageProductDependency", productLine); err != nil {
+		return false, err
+	}
+
+	if !packageRefAlreadyExists {
+		if err := p.addPackageReferenceToProject(packageRefID, repoURL); err != nil {
+			return false, err
+		}
+	}
+	if err := p.addPackageProductToTargets(productDepID, productName); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ensureSection inserts line into the named object section, creating the
+// section if the project doesn't have one yet — a vanilla project with no
+// Swift package dependencies won't have an XCRemoteSwiftPackageReference
+// section at all until one is added.
+func (p *Project) ensureSection(sectionName, line string) error {
+	marker := fmt.Sprintf("/* Begin %s section */\n", sectionName)
+	if idx := strings.Index(p.content, marker); idx != -1 {
+		insertAt := idx + len(marker)
+		p.content = p.content[:insertAt] + line + p.content[insertAt:]
+		return nil
+	}
+	return p.appendSection(sectionName, line)
+}
+
+// appendSection creates a brand-new section right after the last existing
+// one. That keeps it inside the `objects = { ... }` dictionary regardless
+// of where this particular ISA type conventionally sorts among the
+// sections Xcode itself writes.
+func (p *Project) appendSection(sectionName, line string) error {
+	const endMarker = "/* End "
+	lastIdx := strings.LastIndex(p.content, endMarker)
+	if lastIdx == -1 {
+		return fmt.Errorf("could not find any object section in %s", p.Path)
+	}
+	lineEnd := strings.Index(p.content[lastIdx:], "\n")
+	if lineEnd == -1 {
+		return fmt.Errorf("malformed section footer in %s", p.Path)
+	}
+	insertAt := lastIdx + lineEnd + 1
+	block := fmt.Sprintf("/* Begin %s section */\n%s/* End %s section */\n", sectionName, line, sectionName)
+	p.content = p.content[:insertAt] + block + p.content[insertAt:]
+	return nil
+}
+
+// addPackageReferenceToProject appends packageRefID to the single
+// PBXProject object's packageReferences array, creating that array (next
+// to its always-present mainGroup key) if this is the project's first
+// Swift package dependency.
+func (p *Project) addPackageReferenceToProject(packageRefID, repoURL string) error {
+	const isaMarker = "isa = PBXProject;"
+	const closeMarker = "\n\t\t};\n"
+
+	idx := strings.Index(p.content, isaMarker)
+	if idx == -1 {
+		return fmt.Errorf("could not find PBXProject in %s", p.Path)
+	}
+	closeIdx := strings.Index(p.content[idx:], closeMarker)
+	if closeIdx == -1 {
+		return fmt.Errorf("could not find end of PBXProject in %s", p.Path)
+	}
+	blockEnd := idx + closeIdx
+
+	const listMarker = "packageReferences = (\n"
+	line := fmt.Sprintf("\t\t\t\t%s /* %s */,\n", packageRefID, repoURL)
+
+	if listIdx := strings.Index(p.content[idx:blockEnd], listMarker); listIdx != -1 {
+		insertAt := idx + listIdx + len(listMarker)
+		p.content = p.content[:insertAt] + line + p.content[insertAt:]
+		return nil
+	}
+
+	const mainGroupMarker = "mainGroup = "
+	mgIdx := strings.Index(p.content[idx:blockEnd], mainGroupMarker)
+	if mgIdx == -1 {
+		return fmt.Errorf("could not find mainGroup in PBXProject in %s", p.Path)
+	}
+	lineEnd := strings.Index(p.content[idx+mgIdx:], "\n")
+	insertAt := idx + mgIdx + lineEnd + 1
+	block := fmt.Sprintf("\t\t\tpackageReferences = (\n%s\t\t\t);\n", line)
+	p.content = p.content[:insertAt] + block + p.content[insertAt:]
+	return nil
+}
+
+// addPackageProductToTargets appends productDepID to every
+// PBXNativeTarget's packageProductDependencies array (i.e. every
+// application target), creating the array on targets that don't have one
+// yet.
+func (p *Project) addPackageProductToTargets(productDepID, productName string) error {
+	const isaMarker = "isa = PBXNativeTarget;"
+	const closeMarker = "\n\t\t};\n"
+	const listMarker = "packageProductDependencies = (\n"
+
+	count := 0
+	searchFrom := 0
+	for {
+		isaIdx := strings.Index(p.content[searchFrom:], isaMarker)
+		if isaIdx == -1 {
+			break
+		}
+		isaIdx += searchFrom
+
+		closeIdx := strings.Index(p.content[isaIdx:], closeMarker)
+		if closeIdx == -1 {
+			return fmt.Errorf("could not find end of PBXNativeTarget in %s", p.Path)
+		}
+		blockEnd := isaIdx + closeIdx
+
+		line := fmt.Sprintf("\t\t\t\t%s /* %s */,\n", productDepID, productName)
+		if listIdx := strings.Index(p.content[isaIdx:blockEnd], listMarker); listIdx != -1 {
+			insertAt := isaIdx + listIdx + len(listMarker)
+			p.content = p.content[:insertAt] + line + p.content[insertAt:]
+			blockEnd += len(line)
+		} else {
+			lineEnd := strings.Index(p.content[isaIdx:], "\n")
+			insertAt := isaIdx + lineEnd + 1
+			block := fmt.Sprintf("\t\t\tpackageProductDependencies = (\n%s\t\t\t);\n", line)
+			p.content = p.content[:insertAt] + block + p.content[insertAt:]
+			blockEnd += len(block)
+		}
+
+		count++
+		searchFrom = blockEnd
+	}
+
+	if count == 0 {
+		return fmt.Errorf("could not find any PBXNativeTarget in %s", p.Path)
+	}
+	return nil
+}
+
+// AddRunScriptPhase registers shellScript as a new PBXShellScriptBuildPhase
+// named name and appends it to every PBXNativeTarget's buildPhases array —
+// the pbxproj equivalent of adding a Run Script build phase through Xcode's
+// UI. It is idempotent: if a phase named name already exists, it returns
+// false without modifying the project.
+func (p *Project) AddRunScriptPhase(name, shellScript string) (bool, error) {
+	nameMarker := fmt.Sprintf("name = \"%s\";", name)
+	if strings.Contains(p.content, nameMarker) {
+		return false, nil
+	}
+
+	phaseID := generateID("PBXShellScriptBuildPhase:" + name)
+	escapedScript := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n").Replace(shellScript)
+
+	phaseLine := fmt.Sprintf(
+		"\t\t%s /* %s */ = {\n"+
+			"\t\t\tisa = PBXShellScriptBuildPhase;\n"+
+			"\t\t\tbuildActionMask = 2147483647;\n"+
+			"\t\t\tfiles = (\n"+
+			"\t\t\t);\n"+
+			"\t\t\tinputPaths = (\n"+
+			"\t\t\t);\n"+
+			"\t\t\tname = \"%s\";\n"+
+			"\t\t\toutputPaths = (\n"+
+			"\t\t\t);\n"+
+			"\t\t\trunOnlyForDeploymentPostprocessing = 0;\n"+
+			"\t\t\tshellPath = /bin/sh;\n"+
+			"\t\t\tshellScript = \"%s\";\n"+
+			"\t\t};\n",
+		phaseID, name, name, escapedScript)
+	if err := p.ensureSection("PBXShellScriptBuildPhase", phaseLine); err != nil {
+		return false, err
+	}
+
+	if err := p.addBuildPhaseToTargets(phaseID, name); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// addBuildPhaseToTargets appends phaseID to every PBXNativeTarget's
+// buildPhases array.
+func (p *Project) addBuildPhaseToTargets(phaseID, name string) error {
+	const isaMarker = "isa = PBXNativeTarget;"
+	const closeMarker = "\n\t\t};\n"
+	const listMarker = "buildPhases = (\n"
+
+	count := 0
+	searchFrom := 0
+	for {
+		isaIdx := strings.Index(p.content[searchFrom:], isaMarker)
+		if isaIdx == -1 {
+			break
+		}
+		isaIdx += searchFrom
+
+		closeIdx := strings.Index(p.content[isaIdx:], closeMarker)
+		if closeIdx == -1 {
+			return fmt.Errorf("could not find end of PBXNativeTarget in %s", p.Path)
+		}
+		blockEnd := isaIdx + closeIdx
+
+		listIdx := strings.Index(p.content[isaIdx:blockEnd], listMarker)
+		if listIdx == -1 {
+			searchFrom = blockEnd
+			continue
+		}
+
+		insertAt := isaIdx + listIdx + len(listMarker)
+		line := fmt.Sprintf("\t\t\t\t%s /* %s */,\n", phaseID, name)
+		p.content = p.content[:insertAt] + line + p.content[insertAt:]
+
+		count++
+		searchFrom = insertAt + len(line)
+	}
+
+	if count == 0 {
+		return fmt.Errorf("could not find any PBXNativeTarget with a buildPhases list in %s", p.Path)
+	}
+	return nil
+}
+
+// Diff renders a minimal line-oriented diff between the original and
+// mutated content, for `--dry-run` inspection.
+func (p *Project) Diff() string {
+	oldLines := strings.Split(p.original, "\n")
+	newLines := strings.Split(p.content, "\n")
+
+	oldSet := make(map[string]bool, len(oldLines))
+	for _, l := range oldLines {
+		oldSet[l] = true
+	}
+
+	var b strings.Builder
+	for _, l := range newLines {
+		if !oldSet[l] && strings.TrimSpace(l) != "" {
+			fmt.Fprintf(&b, "+ %s\n", l)
+		}
+	}
+	return b.String()
+}
+
+// Save backs up the original pbxproj to Path+BackupSuffix and writes the
+// mutated content. In dry-run mode it prints the diff and writes nothing.
+func (p *Project) Save(dryRun bool) error {
+	if !p.Changed() {
+		return nil
+	}
+
+	if dryRun {
+		fmt.Print(p.Diff())
+		return nil
+	}
+
+	if err := os.WriteFile(p.Path+BackupSuffix, []byte(p.original), 0644); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", p.Path, err)
+	}
+
+	if err := os.WriteFile(p.Path, []byte(p.content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", p.Path, err)
+	}
+
+	return nil
+}
+
+// generateID mints a 24-character uppercase hex object ID in the style
+// Xcode assigns to pbxproj objects, deterministically derived from seed
+// (e.g. an object kind plus the path/URL it represents) so re-running
+// configure against an already-wired project assigns the exact same IDs
+// instead of new ones every time.
+func generateID(seed string) string {
+	sum := md5.Sum([]byte(seed))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))[:24]
+}
+
+// GenerateID exports generateID's deterministic object-ID scheme so other
+// packages that build pbxproj content from scratch (internal/xcodegen) mint
+// IDs the same, reproducible way instead of inventing their own.
+func GenerateID(seed string) string {
+	return generateID(seed)
+}
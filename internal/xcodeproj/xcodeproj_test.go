@@ -0,0 +1,282 @@
+package xcodeproj
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const samplePbxproj = `// !$*UTF8*$!
+{
+	archiveVersion = 1;
+	objects = {
+
+/* Begin PBXBuildFile section */
+/* End PBXBuildFile section */
+
+/* Begin PBXFileReference section */
+/* End PBXFileReference section */
+
+/* Begin PBXGroup section */
+		ABCDEF0000000000000000AA /* MyApp */ = {
+			isa = PBXGroup;
+			children = (
+			);
+			sourceTree = "<group>";
+		};
+/* End PBXGroup section */
+
+/* Begin PBXResourcesBuildPhase section */
+		ABCDEF0000000000000000BB /* Resources */ = {
+			isa = PBXResourcesBuildPhase;
+			buildActionMask = 2147483647;
+			files = (
+			);
+			runOnlyForDeploymentPostprocessing = 0;
+		};
+/* End PBXResourcesBuildPhase section */
+
+	};
+}
+`
+
+const sampleProjectWithTargetPbxproj = `// !$*UTF8*$!
+{
+	archiveVersion = 1;
+	objects = {
+
+/* Begin PBXNativeTarget section */
+		ABCDEF0000000000000000CC /* MyApp */ = {
+			isa = PBXNativeTarget;
+			buildPhases = (
+			);
+			name = MyApp;
+		};
+/* End PBXNativeTarget section */
+
+/* Begin PBXProject section */
+		ABCDEF0000000000000000DD /* Project object */ = {
+			isa = PBXProject;
+			mainGroup = ABCDEF0000000000000000AA;
+			targets = (
+				ABCDEF0000000000000000CC /* MyApp */,
+			);
+		};
+/* End PBXProject section */
+
+	};
+}
+`
+
+func writeSampleProjectWithTarget(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "project.pbxproj")
+	if err := os.WriteFile(path, []byte(sampleProjectWithTargetPbxproj), 0644); err != nil {
+		t.Fatalf("failed to write sample project: %v", err)
+	}
+	return path
+}
+
+func writeSampleProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "project.pbxproj")
+	if err := os.WriteFile(path, []byte(samplePbxproj), 0644); err != nil {
+		t.Fatalf("failed to write sample project: %v", err)
+	}
+	return path
+}
+
+func TestAddResourceFileIsIdempotent(t *testing.T) {
+	path := writeSampleProject(t)
+
+	project, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	added, err := project.AddResourceFile("GoogleService-Info.plist")
+	if err != nil {
+		t.Fatalf("AddResourceFile() error = %v", err)
+	}
+	if !added {
+		t.Fatal("expected AddResourceFile to report a change on first run")
+	}
+
+	for section, want := range map[string]string{
+		"PBXBuildFile":     "/* GoogleService-Info.plist in Resources */ = {isa = PBXBuildFile;",
+		"PBXFileReference": "/* GoogleService-Info.plist */ = {isa = PBXFileReference;",
+	} {
+		if !strings.Contains(project.content, want) {
+			t.Fatalf("expected %s section to reference GoogleService-Info.plist", section)
+		}
+	}
+	if !strings.Contains(project.content, "files = (\n\t\t\t\t") {
+		t.Fatal("expected buildFileID to be inserted into PBXResourcesBuildPhase files list")
+	}
+
+	added, err = project.AddResourceFile("GoogleService-Info.plist")
+	if err != nil {
+		t.Fatalf("second AddResourceFile() error = %v", err)
+	}
+	if added {
+		t.Fatal("expected second AddResourceFile call to be a no-op")
+	}
+}
+
+func TestSaveBacksUpOriginal(t *testing.T) {
+	path := writeSampleProject(t)
+
+	project, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	if _, err := project.AddResourceFile("GoogleService-Info.plist"); err != nil {
+		t.Fatalf("AddResourceFile() error = %v", err)
+	}
+
+	if err := project.Save(false); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	backup, err := os.ReadFile(path + BackupSuffix)
+	if err != nil {
+		t.Fatalf("expected backup file to exist: %v", err)
+	}
+	if string(backup) != samplePbxproj {
+		t.Fatal("expected backup to preserve the original content")
+	}
+
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read written project: %v", err)
+	}
+	if !strings.Contains(string(written), "GoogleService-Info.plist") {
+		t.Fatal("expected written project to contain the mutation")
+	}
+}
+
+func TestAddSwiftPackageDependencyIsIdempotent(t *testing.T) {
+	path := writeSampleProjectWithTarget(t)
+
+	project, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	const repoURL = "https://github.com/firebase/firebase-ios-sdk"
+	added, err := project.AddSwiftPackageDependency(repoURL, "10.24.0", "FirebaseCore")
+	if err != nil {
+		t.Fatalf("AddSwiftPackageDependency() error = %v", err)
+	}
+	if !added {
+		t.Fatal("expected first AddSwiftPackageDependency call to report a change")
+	}
+
+	for _, want := range []string{
+		"isa = XCRemoteSwiftPackageReference;",
+		"isa = XCSwiftPackageProductDependency;",
+		"packageReferences = (",
+		"packageProductDependencies = (",
+	} {
+		if !strings.Contains(project.content, want) {
+			t.Errorf("expected content to contain %q", want)
+		}
+	}
+
+	added, err = project.AddSwiftPackageDependency(repoURL, "10.24.0", "FirebaseCore")
+	if err != nil {
+		t.Fatalf("second AddSwiftPackageDependency() error = %v", err)
+	}
+	if added {
+		t.Fatal("expected second AddSwiftPackageDependency call to be a no-op")
+	}
+}
+
+func TestAddSwiftPackageDependencyDeterministicIDs(t *testing.T) {
+	path1 := writeSampleProjectWithTarget(t)
+	path2 := writeSampleProjectWithTarget(t)
+
+	p1, _ := Open(path1)
+	p2, _ := Open(path2)
+
+	if _, err := p1.AddSwiftPackageDependency("https://example.com/pkg", "1.0.0", "Pkg"); err != nil {
+		t.Fatalf("AddSwiftPackageDependency() error = %v", err)
+	}
+	if _, err := p2.AddSwiftPackageDependency("https://example.com/pkg", "1.0.0", "Pkg"); err != nil {
+		t.Fatalf("AddSwiftPackageDependency() error = %v", err)
+	}
+
+	if p1.content != p2.content {
+		t.Fatal("expected identical input to produce identical generated IDs across runs")
+	}
+}
+
+func TestAddRunScriptPhaseIsIdempotent(t *testing.T) {
+	path := writeSampleProjectWithTarget(t)
+
+	project, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	added, err := project.AddRunScriptPhase("Copy GoogleService-Info.plist", "echo \"copying config\"\n")
+	if err != nil {
+		t.Fatalf("AddRunScriptPhase() error = %v", err)
+	}
+	if !added {
+		t.Fatal("expected first AddRunScriptPhase call to report a change")
+	}
+
+	for _, want := range []string{
+		"isa = PBXShellScriptBuildPhase;",
+		"name = \"Copy GoogleService-Info.plist\";",
+		"shellScript = \"echo \\\"copying config\\\"\\n\";",
+	} {
+		if !strings.Contains(project.content, want) {
+			t.Errorf("expected content to contain %q", want)
+		}
+	}
+	if !strings.Contains(project.content, "buildPhases = (\n\t\t\t\t") {
+		t.Fatal("expected shell script phase ID to be inserted into PBXNativeTarget buildPhases list")
+	}
+
+	added, err = project.AddRunScriptPhase("Copy GoogleService-Info.plist", "echo \"copying config\"\n")
+	if err != nil {
+		t.Fatalf("second AddRunScriptPhase() error = %v", err)
+	}
+	if added {
+		t.Fatal("expected second AddRunScriptPhase call to be a no-op")
+	}
+}
+
+func TestSaveDryRunDoesNotWrite(t *testing.T) {
+	path := writeSampleProject(t)
+
+	project, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if _, err := project.AddResourceFile("GoogleService-Info.plist"); err != nil {
+		t.Fatalf("AddResourceFile() error = %v", err)
+	}
+
+	if err := project.Save(true); err != nil {
+		t.Fatalf("Save(dryRun) error = %v", err)
+	}
+
+	if _, err := os.Stat(path + BackupSuffix); !os.IsNotExist(err) {
+		t.Fatal("expected no backup file to be created in dry-run mode")
+	}
+
+	unchanged, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read project: %v", err)
+	}
+	if string(unchanged) != samplePbxproj {
+		t.Fatal("expected dry-run to leave the file on disk untouched")
+	}
+}